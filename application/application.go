@@ -14,47 +14,300 @@ package application
 
 import (
 	"context"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/mintzhao/topachain/common/comm"
+	_ "github.com/mintzhao/topachain/common/logging"
+	"github.com/mintzhao/topachain/config"
 	"github.com/mintzhao/topachain/types"
+	"github.com/op/go-logging"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// Application
+const (
+	// minBackoff is the delay before reconnecting to a lost consensus module
+	minBackoff = time.Second
+
+	// maxBackoff is the longest delay between reconnections
+	maxBackoff = time.Minute
+
+	// maxQueuedRequests is how many requests may wait for the one being dispatched, before receiving stalls
+	maxQueuedRequests = 1024
+)
+
+var (
+	// ErrRegisterRejected means consensus module refuses the application, reconnecting won't help
+	ErrRegisterRejected = errors.New("register rejected")
+
+	// logger
+	logger = logging.MustGetLogger("application")
+)
+
+// Application is implemented by blockchain applications, consensus module drives it through these callbacks.
+// For each block, BeginBlock is called first, then DeliverTx for every tx, EndBlock and at last Commit.
 type Application interface {
 	// Metadata define Application's metadata
 	Metadata() (*types.AppMetadata, error)
 
 	// Config
 	Config() *types.AppConfig
+
+	// CheckTx validates tx before it's accepted into txpool, an error rejects it
+	CheckTx(tx *types.Transaction) error
+
+	// BeginBlock signals the beginning of a new block
+	BeginBlock(header *types.BlockHeader) error
+
+	// DeliverTx executes tx against the state of the current block
+	DeliverTx(tx *types.Transaction) error
+
+	// EndBlock signals the end of the current block
+	EndBlock(header *types.BlockHeader) error
+
+	// Commit persists the state of the current block, returns the state hash
+	Commit() ([]byte, error)
+
+	// Query queries application state, query and result are defined by application
+	Query(query []byte) ([]byte, error)
+
+	// LastHeight returns height of the last block committed, 0 if none.
+	// Blocks after it are replayed to application on registration.
+	LastHeight() (uint64, error)
 }
 
-// Run start the app, connecting with blockchain and hold
+// Run start the app, connecting with blockchain and hold.
+// It registers app to the consensus module at Config().MasterAddress, then serves its requests.
+// Lost connection is re-established with exponential backoff, Run returns only if app is rejected.
 func Run(app Application) error {
-	cfg := app.Config()
+	return RunContext(context.Background(), app)
+}
+
+// RunContext is Run returning ctx.Err() once ctx is done
+func RunContext(ctx context.Context, app Application) error {
+	return RunTLS(ctx, app, nil, nil)
+}
 
-	conn, err := comm.NewgRPCClient(cfg.MasterAddress)
+// RunTLS is RunContext connecting to the consensus module over TLS configured by tlsConf, insecure if tlsConf is nil or disabled.
+// The connection is tuned by grpcConf whether secured or not, the node defaults if nil.
+// A node requiring client certificates only registers app under a name its certificate is issued for.
+func RunTLS(ctx context.Context, app Application, tlsConf *config.TLS, grpcConf *config.GRPC) error {
+	if grpcConf == nil {
+		grpcConf = config.Defaults().Node.GRPC
+	}
+
+	conns := comm.NewConnManager(comm.NewClientBuilder(grpcConf).WithTLS(tlsConf))
+	defer conns.Close()
+
+	return RunConns(ctx, app, conns)
+}
+
+// RunConns is RunContext getting the connection to the consensus module from conns,
+// which health checks and redials it, and may be shared with other applications
+func RunConns(ctx context.Context, app Application, conns *comm.ConnManager) error {
+	address := app.Config().GetMasterAddress()
+	return run(ctx, app, address, func() (types.ApplicationClient, func(), error) {
+		conn, err := conns.Get(address)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return types.NewApplicationClient(conn.ClientConn), func() { conns.Release(conn) }, nil
+	})
+}
+
+// RunWith is RunContext talking with the consensus module through cli instead of dialing Config().MasterAddress,
+// e.g. the in-process client of the node app is linked into
+func RunWith(ctx context.Context, app Application, cli types.ApplicationClient) error {
+	return run(ctx, app, "local", func() (types.ApplicationClient, func(), error) {
+		return cli, func() {}, nil
+	})
+}
+
+// run serves app through the client returned by connect, reconnecting with exponential backoff.
+// The func returned by connect releases the client, target names it in logs.
+func run(ctx context.Context, app Application, target string, connect func() (types.ApplicationClient, func(), error)) error {
+	meta, err := app.Metadata()
 	if err != nil {
 		return err
 	}
-	appCli := types.NewApplicationClient(conn)
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	backoff := minBackoff
+	for {
+		registered, err := serve(ctx, app, meta, target, connect)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Cause(err) == ErrRegisterRejected {
+			return err
+		}
+
+		if registered {
+			backoff = minBackoff
+		}
+		logger.Warningf("application %s disconnected from %s: %v, reconnect in %s", meta.GetName(), target, err, backoff)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// serve connects and registers app to the consensus module, then serves its requests until disconnected.
+// It returns whether app was registered before disconnection.
+func serve(ctx context.Context, app Application, meta *types.AppMetadata, target string, connect func() (types.ApplicationClient, func(), error)) (bool, error) {
+	appCli, release, err := connect()
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	regCtx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
-	if _, err := appCli.Ping(ctx, &types.Empty{}); err != nil {
-		return err
+	if _, err := appCli.Register(regCtx, meta); err != nil {
+		switch status.Code(err) {
+		case codes.NotFound, codes.FailedPrecondition, codes.PermissionDenied:
+			return false, errors.Wrap(ErrRegisterRejected, status.Convert(err).Message())
+		default:
+			return false, err
+		}
 	}
 
-	stream, err := appCli.Stream(context.Background())
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+
+	stream, err := appCli.AppStream(streamCtx)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	lastHeight, err := app.LastHeight()
+	if err != nil {
+		return false, err
+	}
+
+	// first message identifies the application
+	ack, err := register(stream, meta, lastHeight)
+	if err != nil {
+		return false, err
+	}
+	logger.Infof("application %s registered at %s, committed block %d, node delivered %d", meta.GetName(), target, lastHeight, ack.GetHeight())
+
+	return true, serveRequests(stream, app, meta, cancelStream)
+}
+
+// serveRequests serves the requests received on stream until it fails.
+// Requests are dispatched to app one by one in order by a worker, while heartbeats are answered at once,
+// so that a slow block doesn't make app look dead. cancel aborts stream, so that receiving fails once the worker does.
+func serveRequests(stream types.Application_AppStreamClient, app Application, meta *types.AppMetadata, cancel func()) error {
+	// stream isn't safe for concurrent sends
+	var sendMutex sync.Mutex
+	send := func(msg *types.AppMessage) error {
+		sendMutex.Lock()
+		defer sendMutex.Unlock()
+
+		return stream.Send(msg)
 	}
 
+	reqc := make(chan *types.AppMessage, maxQueuedRequests)
+	errc := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for msg := range reqc {
+			resp, err := dispatch(app, meta, msg)
+			if err == nil {
+				err = send(resp)
+			}
+			if err != nil {
+				errc <- err
+				cancel()
+				return
+			}
+		}
+	}()
+	// app callbacks never outlive the stream, the next one is served after them
+	defer func() {
+		cancel()
+		close(reqc)
+		<-done
+	}()
+
 	for {
-		stream.Recv()
+		msg, err := stream.Recv()
+		if err != nil {
+			select {
+			case werr := <-errc:
+				return werr
+			default:
+			}
+			if err == io.EOF {
+				return errors.New("stream closed")
+			}
+			return err
+		}
+
+		if msg.GetHeader().GetType() == types.HEARTBEAT {
+			resp, err := dispatch(app, meta, msg)
+			if err == nil {
+				err = send(resp)
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		select {
+		case reqc <- msg:
+		case <-done:
+			return <-errc
+		}
+	}
+}
+
+// register sends REGISTER with the committed height on stream and waits for its REGISTER_ACK
+func register(stream types.Application_AppStreamClient, meta *types.AppMetadata, lastHeight uint64) (*types.RegisterAck, error) {
+	msg, err := types.NewAppMessage(meta, types.REGISTER, 0, &types.RegisterRequest{Meta: meta, LastHeight: lastHeight})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.Send(msg); err != nil {
+		return nil, err
+	}
 
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	switch resp.GetHeader().GetType() {
+	case types.REGISTER_ACK:
+		ack := new(types.RegisterAck)
+		if err := resp.DecodePayload(ack); err != nil {
+			return nil, err
+		}
+
+		return ack, nil
+	case types.ERROR:
+		appErr := new(types.AppError)
+		if err := resp.DecodePayload(appErr); err != nil {
+			return nil, err
+		}
+
+		return nil, errors.Errorf("register error: %s", appErr.GetMessage())
+	default:
+		return nil, errors.Errorf("unexpected %s message, expect %s", resp.GetHeader().GetType(), types.REGISTER_ACK)
+	}
 }
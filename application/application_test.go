@@ -0,0 +1,235 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package application
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// counter counts delivered txs, rejects empty txs
+type counter struct {
+	pending   int
+	committed int
+}
+
+func (c *counter) Metadata() (*types.AppMetadata, error) {
+	return &types.AppMetadata{Name: "counter"}, nil
+}
+
+func (c *counter) Config() *types.AppConfig {
+	return &types.AppConfig{}
+}
+
+func (c *counter) CheckTx(tx *types.Transaction) error {
+	if len(tx.GetPayload()) == 0 {
+		return errors.New("empty tx")
+	}
+
+	return nil
+}
+
+func (c *counter) BeginBlock(header *types.BlockHeader) error {
+	c.pending = 0
+	return nil
+}
+
+func (c *counter) DeliverTx(tx *types.Transaction) error {
+	c.pending++
+	return nil
+}
+
+func (c *counter) EndBlock(header *types.BlockHeader) error {
+	return nil
+}
+
+func (c *counter) Commit() ([]byte, error) {
+	c.committed += c.pending
+	return []byte{byte(c.committed)}, nil
+}
+
+func (c *counter) Query(query []byte) ([]byte, error) {
+	return []byte{byte(c.committed)}, nil
+}
+
+func (c *counter) LastHeight() (uint64, error) {
+	return 0, nil
+}
+
+func request(t *testing.T, typ types.AppMessageType, payload proto.Message) *types.AppMessage {
+	msg, err := types.NewAppMessage(nil, typ, 1, payload)
+	assert.NoError(t, err)
+
+	return msg
+}
+
+func response(t *testing.T, app Application, req *types.AppMessage, payload proto.Message) *types.AppMessage {
+	meta, _ := app.Metadata()
+	resp, err := dispatch(app, meta, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "counter", resp.GetHeader().GetMeta().GetName())
+	assert.Equal(t, req.GetHeader().GetRequestId(), resp.GetHeader().GetRequestId())
+	if payload != nil {
+		assert.NoError(t, resp.DecodePayload(payload))
+	}
+
+	return resp
+}
+
+func TestDispatch(t *testing.T) {
+	app := &counter{}
+	tx := &types.Transaction{Payload: []byte("tx")}
+	header := &types.BlockHeader{BlockHeight: 1}
+
+	checkResp := new(types.CheckTxResponse)
+	resp := response(t, app, request(t, types.CHECK_TX, &types.CheckTxRequest{Tx: tx}), checkResp)
+	assert.Equal(t, types.CHECK_TX, resp.GetHeader().GetType())
+	assert.Equal(t, codeOK, checkResp.GetCode())
+
+	response(t, app, request(t, types.CHECK_TX, &types.CheckTxRequest{Tx: &types.Transaction{}}), checkResp)
+	assert.Equal(t, codeError, checkResp.GetCode())
+	assert.Equal(t, "empty tx", checkResp.GetLog())
+
+	for _, req := range []*types.AppMessage{
+		request(t, types.BEGIN_BLOCK, &types.BeginBlockRequest{Header: header}),
+		request(t, types.DELIVER_TX, &types.DeliverTxRequest{Tx: tx}),
+		request(t, types.DELIVER_TX, &types.DeliverTxRequest{Tx: tx}),
+		request(t, types.END_BLOCK, &types.EndBlockRequest{Header: header}),
+		request(t, types.HEARTBEAT, &types.Heartbeat{}),
+	} {
+		resp = response(t, app, req, nil)
+		assert.Equal(t, req.GetHeader().GetType(), resp.GetHeader().GetType())
+	}
+
+	commitResp := new(types.CommitResponse)
+	resp = response(t, app, request(t, types.COMMIT, &types.CommitRequest{}), commitResp)
+	assert.Equal(t, types.COMMIT, resp.GetHeader().GetType())
+	assert.Equal(t, []byte{2}, commitResp.GetAppHash())
+
+	queryResp := new(types.QueryResponse)
+	response(t, app, request(t, types.QUERY, &types.QueryRequest{}), queryResp)
+	assert.Equal(t, []byte{2}, queryResp.GetValue())
+
+	// malformed payload and unknown type
+	req := request(t, types.DELIVER_TX, nil)
+	req.Payload = []byte{0xff}
+	appErr := new(types.AppError)
+	resp = response(t, app, req, appErr)
+	assert.Equal(t, types.ERROR, resp.GetHeader().GetType())
+	assert.NotEmpty(t, appErr.GetMessage())
+
+	resp = response(t, app, request(t, types.UNKNOWN, nil), nil)
+	assert.Equal(t, types.ERROR, resp.GetHeader().GetType())
+}
+
+func TestFactory(t *testing.T) {
+	factory := func(dir string) (Application, error) {
+		return &counter{}, nil
+	}
+
+	assert.NoError(t, RegisterFactory("counter", factory))
+	assert.Equal(t, ErrFactoryAlreadyRegistered, RegisterFactory("counter", factory))
+
+	f, err := GetFactory("counter")
+	assert.NoError(t, err)
+	app, err := f("")
+	assert.NoError(t, err)
+	assert.IsType(t, &counter{}, app)
+
+	_, err = GetFactory("unknown")
+	assert.Equal(t, ErrFactoryNotFound, errors.Cause(err))
+}
+
+// slow is a counter delivering txs once released
+type slow struct {
+	counter
+	release chan struct{}
+}
+
+func (s *slow) DeliverTx(tx *types.Transaction) error {
+	<-s.release
+	return s.counter.DeliverTx(tx)
+}
+
+// fakeStream is the client end of an AppStream, requests received from recvc, responses sent to sendc
+type fakeStream struct {
+	grpc.ClientStream
+	recvc chan *types.AppMessage
+	sendc chan *types.AppMessage
+}
+
+func (s *fakeStream) Send(msg *types.AppMessage) error {
+	s.sendc <- msg
+	return nil
+}
+
+func (s *fakeStream) Recv() (*types.AppMessage, error) {
+	msg, ok := <-s.recvc
+	if !ok {
+		return nil, io.EOF
+	}
+
+	return msg, nil
+}
+
+func TestServeRequests_Heartbeat(t *testing.T) {
+	app := &slow{release: make(chan struct{})}
+	meta, _ := app.Metadata()
+	stream := &fakeStream{recvc: make(chan *types.AppMessage, 2), sendc: make(chan *types.AppMessage, 2)}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- serveRequests(stream, app, meta, func() {})
+	}()
+
+	// heartbeat is answered while tx delivery hangs
+	deliver, err := types.NewAppMessage(nil, types.DELIVER_TX, 1, &types.DeliverTxRequest{Tx: &types.Transaction{Payload: []byte("tx")}})
+	assert.NoError(t, err)
+	heartbeat, err := types.NewAppMessage(nil, types.HEARTBEAT, 2, &types.Heartbeat{})
+	assert.NoError(t, err)
+	stream.recvc <- deliver
+	stream.recvc <- heartbeat
+
+	select {
+	case resp := <-stream.sendc:
+		assert.Equal(t, types.HEARTBEAT, resp.GetHeader().GetType())
+		assert.Equal(t, uint64(2), resp.GetHeader().GetRequestId())
+	case <-time.After(5 * time.Second):
+		t.Fatal("heartbeat not answered")
+	}
+
+	close(app.release)
+	select {
+	case resp := <-stream.sendc:
+		assert.Equal(t, types.DELIVER_TX, resp.GetHeader().GetType())
+		assert.Equal(t, uint64(1), resp.GetHeader().GetRequestId())
+	case <-time.After(5 * time.Second):
+		t.Fatal("tx not delivered")
+	}
+
+	// closed stream ends serving
+	close(stream.recvc)
+	select {
+	case err := <-errc:
+		assert.EqualError(t, err, "stream closed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("serving not ended")
+	}
+}
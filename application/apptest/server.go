@@ -0,0 +1,481 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apptest provides an in-memory consensus server for testing application.Application implementations.
+//
+// A test starts the application against a Server, submits txs, cuts blocks and asserts on the responses:
+//
+//	s, err := apptest.Start(app)
+//	defer s.Close()
+//	err = s.SubmitTx(payload)
+//	result, err := s.CutBlock()
+//	value, err := s.Query(query)
+package apptest
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/common/genesis"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// registerTimeout is how long to wait for application registered
+	registerTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNotRegistered means no application registered to server
+	ErrNotRegistered = errors.New("application not registered")
+
+	// ErrAlreadyRegistered means server already has an application registered
+	ErrAlreadyRegistered = errors.New("application already registered")
+
+	// ErrClosed means server is closed
+	ErrClosed = errors.New("server closed")
+)
+
+// BlockResult is the result of a block executed by application
+type BlockResult struct {
+	Block *types.Block
+
+	// TxResults are DeliverTx responses, in tx order of Block
+	TxResults []*types.DeliverTxResponse
+
+	// AppHash is the state hash application committed
+	AppHash []byte
+}
+
+// Server is an in-memory types.ApplicationServer serving a single application.
+// Requests are sent to application one by one from the calling goroutine, so that blocks are cut deterministically.
+type Server struct {
+	// requests to application, answered on their response channels
+	reqc   chan *request
+	closed chan struct{}
+	once   sync.Once
+
+	registered chan struct{}
+
+	mutex   sync.Mutex
+	lastID  uint64
+	meta    *types.AppMetadata
+	genesis *types.Block
+	hash    string
+	height  uint64
+	appHash []byte
+	pool    []*types.Transaction
+	blocks  []*types.Block
+
+	cancel context.CancelFunc
+	runc   chan error
+}
+
+// request is a message sent to application with the channel its response delivered to
+type request struct {
+	msg   *types.AppMessage
+	respc chan *types.AppMessage
+}
+
+// NewServer returns a Server, application connects to it by Client, or over gRPC by types.RegisterApplicationServer
+func NewServer() *Server {
+	return &Server{
+		reqc:       make(chan *request),
+		closed:     make(chan struct{}),
+		registered: make(chan struct{}),
+	}
+}
+
+// Start runs app against a new Server in-process, returns once app registered.
+// Blocks are cut following the genesis block of app's metadata and config.
+func Start(app application.Application) (*Server, error) {
+	meta, err := app.Metadata()
+	if err != nil {
+		return nil, err
+	}
+	blk, err := genesis.GenesisBlock(meta.GetName(), app.Config(), meta.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewServer()
+	if err := s.SetGenesis(blk); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.runc = make(chan error, 1)
+	go func() {
+		s.runc <- application.RunWith(ctx, app, s.Client())
+	}()
+
+	select {
+	case <-s.registered:
+		return s, nil
+	case err := <-s.runc:
+		cancel()
+		return nil, errors.Wrap(err, "run application error")
+	case <-time.After(registerTimeout):
+		s.Close()
+		return nil, ErrNotRegistered
+	}
+}
+
+// SetGenesis has s cut block 1 following the genesis block blk, hashing blocks with the hasher of its config.
+// Without it, the first application registered gets a genesis block of its name and the default config.
+func (s *Server) SetGenesis(blk *types.Block) error {
+	gtxp, err := genesis.GenesisProposal(blk)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.genesis = blk
+	s.hash = gtxp.GetConfig().GetHash()
+	return nil
+}
+
+// Genesis returns the genesis block s cuts blocks following, nil if none yet
+func (s *Server) Genesis() *types.Block {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.genesis
+}
+
+// Client returns a types.ApplicationClient calling s in-process
+func (s *Server) Client() types.ApplicationClient {
+	return types.NewLocalApplicationClient(s)
+}
+
+// Close stops s, and the application started by Start
+func (s *Server) Close() {
+	s.once.Do(func() {
+		close(s.closed)
+	})
+
+	if s.cancel != nil {
+		s.cancel()
+		<-s.runc
+		s.cancel = nil
+	}
+}
+
+// Register accepts any application
+func (s *Server) Register(ctx context.Context, meta *types.AppMetadata) (*types.Empty, error) {
+	return &types.Empty{}, nil
+}
+
+// AppStream registers the application, then sends it requests until s closed.
+// The first application registered sets the height blocks are cut from to its committed height.
+func (s *Server) AppStream(stream types.Application_AppStreamServer) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	req := new(types.RegisterRequest)
+	if msg.GetHeader().GetType() != types.REGISTER {
+		return errors.Errorf("expect %s message, got %s", types.REGISTER, msg.GetHeader().GetType())
+	}
+	if err := msg.DecodePayload(req); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	if s.meta != nil {
+		s.mutex.Unlock()
+		return ErrAlreadyRegistered
+	}
+	s.meta = req.GetMeta()
+	if len(s.blocks) == 0 {
+		s.height = req.GetLastHeight()
+	}
+	height := s.height
+	if s.genesis == nil {
+		if s.genesis, err = genesis.GenesisBlock(s.meta.GetName(), nil, s.meta.GetVersion()); err != nil {
+			s.mutex.Unlock()
+			return err
+		}
+	}
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		s.meta = nil
+		s.mutex.Unlock()
+	}()
+
+	ack, err := types.NewAppMessage(nil, types.REGISTER_ACK, msg.GetHeader().GetRequestId(), &types.RegisterAck{
+		ProtocolVersion: types.ProtocolVersion,
+		Height:          height,
+	})
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(ack); err != nil {
+		return err
+	}
+
+	select {
+	case <-s.registered:
+	default:
+		close(s.registered)
+	}
+
+	for {
+		select {
+		case r := <-s.reqc:
+			if err := stream.Send(r.msg); err != nil {
+				close(r.respc)
+				return err
+			}
+
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				close(r.respc)
+				return nil
+			}
+			if err != nil {
+				close(r.respc)
+				return err
+			}
+			r.respc <- resp
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-s.closed:
+			return nil
+		}
+	}
+}
+
+// Metadata returns metadata of the registered application
+func (s *Server) Metadata() (*types.AppMetadata, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.meta == nil {
+		return nil, ErrNotRegistered
+	}
+
+	return s.meta, nil
+}
+
+// Height returns height of the last block cut
+func (s *Server) Height() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.height
+}
+
+// AppHash returns the state hash application committed at the last block cut
+func (s *Server) AppHash() []byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.appHash
+}
+
+// Blocks returns the blocks cut by s
+func (s *Server) Blocks() []*types.Block {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*types.Block{}, s.blocks...)
+}
+
+// Pending returns the txs accepted but not cut into block yet
+func (s *Server) Pending() []*types.Transaction {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*types.Transaction{}, s.pool...)
+}
+
+// CheckTx asks application to check tx payload, returns its response
+func (s *Server) CheckTx(payload []byte) (*types.CheckTxResponse, error) {
+	resp := new(types.CheckTxResponse)
+	if err := s.request(types.CHECK_TX, &types.CheckTxRequest{Tx: &types.Transaction{Payload: payload}}, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SubmitTx adds tx payload to the next block if application accepts it, otherwise returns the rejection
+func (s *Server) SubmitTx(payload []byte) error {
+	resp, err := s.CheckTx(payload)
+	if err != nil {
+		return err
+	}
+	if resp.GetCode() != 0 {
+		return errors.Errorf("tx rejected: %s", resp.GetLog())
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.pool = append(s.pool, &types.Transaction{Payload: payload})
+	return nil
+}
+
+// CutBlock packs the submitted txs in submission order into the next block and has application execute it.
+// Blocks are cut deterministically, the same txs make the same block, linked as the node links them:
+// block 1 follows the genesis block, blocks following a height application resumed from are unlinked.
+func (s *Server) CutBlock() (*BlockResult, error) {
+	s.mutex.Lock()
+	txs := s.pool
+	s.pool = nil
+	blk, err := s.next(txs)
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	header := blk.GetHeader()
+
+	result, err := s.execute(blk)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.height = header.GetBlockHeight()
+	s.appHash = result.AppHash
+	s.blocks = append(s.blocks, blk)
+
+	return result, nil
+}
+
+// next returns the block of txs following the last one cut, s.mutex must be held
+func (s *Server) next(txs []*types.Transaction) (*types.Block, error) {
+	blkTxs := &types.BlockTxs{Txs: txs}
+	txroot, err := blkTxs.Hash(s.hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "hash block txs error")
+	}
+
+	header := &types.BlockHeader{
+		BlockHeight: s.height + 1,
+		Txroot:      txroot,
+	}
+
+	var prev *types.Block
+	if n := len(s.blocks); n > 0 {
+		prev = s.blocks[n-1]
+	} else if s.height == 0 {
+		prev = s.genesis
+	}
+	if prev != nil {
+		if header.PreviousBlock, err = prev.GetHeader().Hash(s.hash); err != nil {
+			return nil, errors.Wrap(err, "hash previous block error")
+		}
+	}
+
+	return &types.Block{
+		Header: header,
+		Txs:    blkTxs,
+	}, nil
+}
+
+// Query queries application state
+func (s *Server) Query(query []byte) ([]byte, error) {
+	resp := new(types.QueryResponse)
+	if err := s.request(types.QUERY, &types.QueryRequest{Data: query}, resp); err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != 0 {
+		return nil, errors.Errorf("query error: %s", resp.GetLog())
+	}
+
+	return resp.GetValue(), nil
+}
+
+// execute drives application through BeginBlock, DeliverTx, EndBlock and Commit of blk
+func (s *Server) execute(blk *types.Block) (*BlockResult, error) {
+	if err := s.request(types.BEGIN_BLOCK, &types.BeginBlockRequest{Header: blk.GetHeader()}, &types.BeginBlockResponse{}); err != nil {
+		return nil, errors.Wrap(err, "begin block error")
+	}
+
+	result := &BlockResult{Block: blk}
+	for _, tx := range blk.GetTxs().GetTxs() {
+		resp := new(types.DeliverTxResponse)
+		if err := s.request(types.DELIVER_TX, &types.DeliverTxRequest{Tx: tx}, resp); err != nil {
+			return nil, errors.Wrap(err, "deliver tx error")
+		}
+		result.TxResults = append(result.TxResults, resp)
+	}
+
+	if err := s.request(types.END_BLOCK, &types.EndBlockRequest{Header: blk.GetHeader()}, &types.EndBlockResponse{}); err != nil {
+		return nil, errors.Wrap(err, "end block error")
+	}
+
+	resp := new(types.CommitResponse)
+	if err := s.request(types.COMMIT, &types.CommitRequest{}, resp); err != nil {
+		return nil, errors.Wrap(err, "commit error")
+	}
+	result.AppHash = resp.GetAppHash()
+
+	return result, nil
+}
+
+// request sends a typ request with payload req to application, decodes its response into resp
+func (s *Server) request(typ types.AppMessageType, req, resp proto.Message) error {
+	s.mutex.Lock()
+	s.lastID++
+	id := s.lastID
+	s.mutex.Unlock()
+
+	msg, err := types.NewAppMessage(nil, typ, id, req)
+	if err != nil {
+		return err
+	}
+
+	r := &request{msg: msg, respc: make(chan *types.AppMessage, 1)}
+	select {
+	case s.reqc <- r:
+	case <-s.closed:
+		return ErrClosed
+	case <-time.After(registerTimeout):
+		return ErrNotRegistered
+	}
+
+	respMsg, ok := <-r.respc
+	if !ok {
+		return ErrNotRegistered
+	}
+
+	switch respMsg.GetHeader().GetType() {
+	case typ:
+		return respMsg.DecodePayload(resp)
+	case types.ERROR:
+		appErr := new(types.AppError)
+		if err := respMsg.DecodePayload(appErr); err != nil {
+			return err
+		}
+
+		return errors.New(appErr.GetMessage())
+	default:
+		return errors.Errorf("unexpected %s response to %s request", respMsg.GetHeader().GetType(), typ)
+	}
+}
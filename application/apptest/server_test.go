@@ -0,0 +1,274 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package apptest
+
+import (
+	"context"
+	"crypto/sha256"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/common/genesis"
+	"github.com/mintzhao/topachain/consensus"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// words keeps a set of words, tx adds the word in payload, query tells whether a word is set
+type words struct {
+	mutex     sync.Mutex
+	height    uint64
+	pending   []string
+	committed map[string]bool
+}
+
+func newWords() *words {
+	return &words{committed: make(map[string]bool)}
+}
+
+func (w *words) Metadata() (*types.AppMetadata, error) {
+	return &types.AppMetadata{Name: "words"}, nil
+}
+
+func (w *words) Config() *types.AppConfig {
+	return &types.AppConfig{}
+}
+
+func (w *words) CheckTx(tx *types.Transaction) error {
+	if strings.TrimSpace(string(tx.GetPayload())) == "" {
+		return errors.New("empty word")
+	}
+
+	return nil
+}
+
+func (w *words) BeginBlock(header *types.BlockHeader) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.height = header.GetBlockHeight()
+	w.pending = nil
+	return nil
+}
+
+func (w *words) DeliverTx(tx *types.Transaction) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	word := string(tx.GetPayload())
+	if w.committed[word] {
+		return errors.Errorf("duplicated word %s", word)
+	}
+
+	w.pending = append(w.pending, word)
+	return nil
+}
+
+func (w *words) EndBlock(header *types.BlockHeader) error {
+	return nil
+}
+
+func (w *words) Commit() ([]byte, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, word := range w.pending {
+		w.committed[word] = true
+	}
+
+	all := make([]string, 0, len(w.committed))
+	for word := range w.committed {
+		all = append(all, word)
+	}
+	sort.Strings(all)
+
+	hash := sha256.Sum256([]byte(strings.Join(all, ",")))
+	return hash[:], nil
+}
+
+func (w *words) Query(query []byte) ([]byte, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if !w.committed[string(query)] {
+		return nil, errors.Errorf("word %s not found", query)
+	}
+
+	return query, nil
+}
+
+func (w *words) LastHeight() (uint64, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.height, nil
+}
+
+func TestServer(t *testing.T) {
+	s, err := Start(newWords())
+	assert.NoError(t, err)
+	defer s.Close()
+
+	meta, err := s.Metadata()
+	assert.NoError(t, err)
+	assert.Equal(t, "words", meta.GetName())
+
+	assert.NoError(t, s.SubmitTx([]byte("hello")))
+	assert.NoError(t, s.SubmitTx([]byte("world")))
+	assert.EqualError(t, s.SubmitTx([]byte(" ")), "tx rejected: empty word")
+	assert.Len(t, s.Pending(), 2)
+
+	_, err = s.Query([]byte("hello"))
+	assert.Error(t, err)
+
+	result, err := s.CutBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), result.Block.GetHeader().GetBlockHeight())
+	assert.Len(t, result.Block.GetTxs().GetTxs(), 2)
+	assert.Len(t, result.TxResults, 2)
+	assert.NotEmpty(t, result.AppHash)
+	assert.Equal(t, result.AppHash, s.AppHash())
+	assert.Equal(t, uint64(1), s.Height())
+	assert.Empty(t, s.Pending())
+
+	value, err := s.Query([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), value)
+
+	// failed tx is reported in result, not failing the block
+	assert.NoError(t, s.SubmitTx([]byte("hello")))
+	result, err = s.CutBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), result.TxResults[0].GetCode())
+	assert.Equal(t, "duplicated word hello", result.TxResults[0].GetLog())
+
+	// empty block
+	result, err = s.CutBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), result.Block.GetHeader().GetBlockHeight())
+	assert.Len(t, s.Blocks(), 3)
+
+	s.Close()
+	assert.Equal(t, ErrClosed, s.SubmitTx([]byte("closed")))
+}
+
+func TestServer_Deterministic(t *testing.T) {
+	run := func() []*BlockResult {
+		s, err := Start(newWords())
+		assert.NoError(t, err)
+		defer s.Close()
+
+		var results []*BlockResult
+		for _, block := range [][]string{{"a", "b"}, {"c"}, {}} {
+			for _, word := range block {
+				assert.NoError(t, s.SubmitTx([]byte(word)))
+			}
+
+			result, err := s.CutBlock()
+			assert.NoError(t, err)
+			results = append(results, result)
+		}
+
+		return results
+	}
+
+	results1, results2 := run(), run()
+	assert.Len(t, results2, len(results1))
+	for i := range results1 {
+		assert.True(t, results1[i].Block.Equal(results2[i].Block))
+		assert.Equal(t, results1[i].AppHash, results2[i].AppHash)
+	}
+}
+
+// configured is words of config
+type configured struct {
+	*words
+	config *types.AppConfig
+}
+
+func (c *configured) Config() *types.AppConfig {
+	return c.config
+}
+
+func TestServer_Chain(t *testing.T) {
+	config := &types.AppConfig{Hash: "SHA3-256", BlockInterval: 60000, BlockTxCount: 2}
+	blocks := [][]string{{"a", "b"}, {"c", "d"}}
+
+	s, err := Start(&configured{words: newWords(), config: config})
+	assert.NoError(t, err)
+	defer s.Close()
+
+	// block 1 follows genesis, txroot and links are hashed by the configured hasher
+	for _, block := range blocks {
+		for _, word := range block {
+			assert.NoError(t, s.SubmitTx([]byte(word)))
+		}
+		_, err := s.CutBlock()
+		assert.NoError(t, err)
+	}
+	cut := s.Blocks()
+	genesisHash, err := s.Genesis().GetHeader().Hash(config.GetHash())
+	assert.NoError(t, err)
+	assert.Equal(t, genesisHash, cut[0].GetHeader().GetPreviousBlock())
+	prevHash, err := cut[0].GetHeader().Hash(config.GetHash())
+	assert.NoError(t, err)
+	assert.Equal(t, prevHash, cut[1].GetHeader().GetPreviousBlock())
+
+	// the node cuts the same blocks of the same txs
+	gblk, err := genesis.GenesisBlock("words", config, nil)
+	assert.NoError(t, err)
+	assert.True(t, gblk.Equal(s.Genesis()))
+
+	m := consensus.NewManager()
+	defer m.Stop()
+	assert.NoError(t, m.AddApplication(gblk, nil))
+	assert.NoError(t, m.StartApplication("words"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go application.RunWith(ctx, &configured{words: newWords(), config: config}, consensus.NewLocalClient(m))
+
+	for height, block := range blocks {
+		waitNode(t, m, func(status *consensus.AppStatus) bool {
+			return status.Registered && status.Height == uint64(height)
+		})
+		for _, word := range block {
+			_, err := m.ReceiveTxSync("words", []byte(word))
+			assert.NoError(t, err)
+		}
+	}
+	waitNode(t, m, func(status *consensus.AppStatus) bool { return status.Height == uint64(len(blocks)) })
+
+	for i, blk := range cut {
+		nodeBlk, err := m.GetBlock("words", uint64(i+1))
+		assert.NoError(t, err)
+		assert.True(t, blk.Equal(nodeBlk))
+	}
+}
+
+// waitNode waits the status of words application of m satisfies ok
+func waitNode(t *testing.T, m *consensus.Manager, ok func(*consensus.AppStatus) bool) {
+	for i := 0; i < 100; i++ {
+		if status, err := m.Status("words"); err == nil && ok(status) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatal("words application not in expected status")
+}
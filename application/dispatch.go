@@ -0,0 +1,169 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package application
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// codeOK is the response code of successful CheckTx, DeliverTx and Query
+	codeOK uint32 = 0
+
+	// codeError is the response code of CheckTx, DeliverTx and Query returned error
+	codeError uint32 = 1
+)
+
+// dispatch calls the app callback of msg type, returns the response message
+func dispatch(app Application, meta *types.AppMetadata, msg *types.AppMessage) (*types.AppMessage, error) {
+	typ := msg.GetHeader().GetType()
+	reqID := msg.GetHeader().GetRequestId()
+	logger.Debugf("receive %s request %d", typ, reqID)
+
+	resp, err := call(app, msg)
+	if err != nil {
+		logger.Warningf("handle %s request %d error: %s", typ, reqID, err)
+		return types.NewAppMessage(meta, types.ERROR, reqID, &types.AppError{Message: err.Error()})
+	}
+
+	return types.NewAppMessage(meta, typ, reqID, resp)
+}
+
+// call decodes msg payload and calls the app callback of its type, returns the response payload.
+// Errors of CheckTx, DeliverTx and Query are carried by response code, others are returned.
+func call(app Application, msg *types.AppMessage) (proto.Message, error) {
+	switch typ := msg.GetHeader().GetType(); typ {
+	case types.CHECK_TX:
+		req := new(types.CheckTxRequest)
+		if err := msg.DecodePayload(req); err != nil {
+			return nil, err
+		}
+
+		code, log := result(app.CheckTx(req.GetTx()))
+		return &types.CheckTxResponse{Code: code, Log: log}, nil
+	case types.BEGIN_BLOCK:
+		req := new(types.BeginBlockRequest)
+		if err := msg.DecodePayload(req); err != nil {
+			return nil, err
+		}
+
+		return &types.BeginBlockResponse{}, app.BeginBlock(req.GetHeader())
+	case types.DELIVER_TX:
+		req := new(types.DeliverTxRequest)
+		if err := msg.DecodePayload(req); err != nil {
+			return nil, err
+		}
+
+		if emitter, ok := app.(EventEmitter); ok {
+			events, err := emitter.DeliverTxEvents(req.GetTx())
+			if err != nil {
+				events = nil
+			}
+
+			code, log := result(err)
+			return &types.DeliverTxResponse{Code: code, Log: log, Events: events}, nil
+		}
+
+		code, log := result(app.DeliverTx(req.GetTx()))
+		return &types.DeliverTxResponse{Code: code, Log: log}, nil
+	case types.END_BLOCK:
+		req := new(types.EndBlockRequest)
+		if err := msg.DecodePayload(req); err != nil {
+			return nil, err
+		}
+
+		return &types.EndBlockResponse{}, app.EndBlock(req.GetHeader())
+	case types.COMMIT:
+		appHash, err := app.Commit()
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.CommitResponse{AppHash: appHash}, nil
+	case types.QUERY:
+		req := new(types.QueryRequest)
+		if err := msg.DecodePayload(req); err != nil {
+			return nil, err
+		}
+
+		value, err := app.Query(req.GetData())
+		code, log := result(err)
+		return &types.QueryResponse{Code: code, Log: log, Value: value}, nil
+	case types.HEARTBEAT:
+		return &types.Heartbeat{}, nil
+	case types.SNAPSHOT, types.LOAD_SNAPSHOT_CHUNK, types.APPLY_SNAPSHOT_CHUNK:
+		snapshotter, ok := app.(Snapshotter)
+		if !ok {
+			return nil, ErrSnapshotUnsupported
+		}
+
+		return callSnapshotter(snapshotter, msg)
+	default:
+		return nil, errors.Errorf("unsupported message type %s", typ)
+	}
+}
+
+// callSnapshotter decodes msg payload and calls the snapshotter callback of its type, returns the response payload
+func callSnapshotter(snapshotter Snapshotter, msg *types.AppMessage) (proto.Message, error) {
+	switch typ := msg.GetHeader().GetType(); typ {
+	case types.SNAPSHOT:
+		req := new(types.TakeSnapshotRequest)
+		if err := msg.DecodePayload(req); err != nil {
+			return nil, err
+		}
+
+		snapshot, err := snapshotter.TakeSnapshot(req.GetHeight())
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.TakeSnapshotResponse{Snapshot: snapshot}, nil
+	case types.LOAD_SNAPSHOT_CHUNK:
+		req := new(types.LoadSnapshotChunkRequest)
+		if err := msg.DecodePayload(req); err != nil {
+			return nil, err
+		}
+
+		chunk, err := snapshotter.LoadSnapshotChunk(req.GetHeight(), req.GetIndex())
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.LoadSnapshotChunkResponse{Chunk: chunk}, nil
+	case types.APPLY_SNAPSHOT_CHUNK:
+		req := new(types.ApplySnapshotChunkRequest)
+		if err := msg.DecodePayload(req); err != nil {
+			return nil, err
+		}
+
+		appHash, err := snapshotter.ApplySnapshotChunk(req.GetSnapshot(), req.GetIndex(), req.GetChunk())
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.ApplySnapshotChunkResponse{AppHash: appHash}, nil
+	default:
+		return nil, errors.Errorf("unsupported message type %s", typ)
+	}
+}
+
+// result converts callback error to response code and log
+func result(err error) (uint32, string) {
+	if err != nil {
+		return codeError, err.Error()
+	}
+
+	return codeOK, ""
+}
@@ -0,0 +1,25 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package application
+
+import (
+	"github.com/mintzhao/topachain/types"
+)
+
+// EventEmitter is implemented by applications telling clients what happened inside txs.
+// DeliverTxEvents is called instead of DeliverTx, events of the txs executed are indexed by node
+// and streamed to subscribers once the block committed.
+type EventEmitter interface {
+	// DeliverTxEvents is DeliverTx returning the events tx emitted, events of failed tx are dropped
+	DeliverTxEvents(tx *types.Transaction) ([]*types.Event, error)
+}
@@ -0,0 +1,76 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/application/kvset"
+	"github.com/mintzhao/topachain/common/database/badger"
+	_ "github.com/mintzhao/topachain/common/logging"
+	"github.com/mintzhao/topachain/config"
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("kvset")
+
+func main() {
+	address := flag.String("address", "127.0.0.1:9024", "address of consensus node")
+	dir := flag.String("dir", "./kvset-data", "directory kvset state stored in")
+	tlsCert := flag.String("tlsCert", "", "certificate presented to consensus node, enables TLS")
+	tlsKey := flag.String("tlsKey", "", "key of the certificate presented to consensus node")
+	tlsRootCA := flag.String("tlsRootCA", "", "CA bundle verifying consensus node certificate, system roots if empty")
+	maxBlockSize := flag.Uint64("maxBlockSize", 0, "max block size of consensus node, gRPC message limits are derived from, defaults if 0")
+	flag.Parse()
+
+	grpcConf := config.Defaults().Node.GRPC
+	grpcConf.MaxBlockSize = *maxBlockSize
+
+	tlsConf := &config.TLS{
+		Enabled:  *tlsCert != "",
+		CertFile: *tlsCert,
+		KeyFile:  *tlsKey,
+	}
+	if *tlsRootCA != "" {
+		tlsConf.RootCAs = []string{*tlsRootCA}
+	}
+
+	// step 1: open database
+	if err := os.MkdirAll(*dir, os.ModePerm); err != nil {
+		logger.Errorf("create data directory error: %s", err)
+		os.Exit(-1)
+	}
+
+	db, err := badger.New(*dir)
+	if err != nil {
+		logger.Errorf("open database error: %s", err)
+		os.Exit(-1)
+	}
+	defer db.Close()
+
+	// step 2: run application
+	kv, err := kvset.New(db, *address)
+	if err != nil {
+		logger.Errorf("create kvset error: %s", err)
+		os.Exit(-1)
+	}
+
+	if err := application.RunTLS(context.Background(), kv, tlsConf, grpcConf); err != nil {
+		logger.Errorf("kvset stopped: %s", err)
+		db.Close()
+		os.Exit(-1)
+	}
+}
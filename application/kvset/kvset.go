@@ -10,12 +10,78 @@
 // WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 // See the License for the specific language governing permissions and
 // limitations under the License.
-package main
+package kvset
 
-import "github.com/mintzhao/topachain/types"
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
 
-// KVset
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/common/crypto/hasher"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/common/merkle/basic"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// stateBucket is the bucket key/value pairs set stored in
+	stateBucket = "kvset"
+
+	// metaBucket is the bucket application's own info stored in
+	metaBucket = "kvset.meta"
+
+	// leafBucket is the bucket the leaf hashes of pairs indexed in, under subtree/key
+	leafBucket = "kvset.leaf"
+
+	// heightKey is the key height of the last committed block stored under
+	heightKey = "height"
+
+	// indexedKey marks the leaf hashes and subtree roots of state are indexed
+	indexedKey = "indexed"
+
+	// subtreeKeyPrefix prefixes the keys subtree roots stored under in metaBucket
+	subtreeKeyPrefix = "subtree/"
+)
+
+var (
+	// ErrEmptyKey means tx or query has no key
+	ErrEmptyKey = errors.New("empty key")
+
+	// ErrUnknownOp means tx op is not SET or DELETE
+	ErrUnknownOp = errors.New("unknown op")
+)
+
+// KVset is a key/value store application, state is changed by SET/DELETE txs and persisted in db
 type KVset struct {
+	db      database.Database
+	address string
+	hasher  hasher.Hasher
+
+	// txs of the current block, applied on commit
+	height  uint64
+	pending []*KVTx
+
+	// subtrees are the merkle roots of pairs grouped by the first byte of key hash, loaded on first commit
+	subtrees map[byte][]byte
+}
+
+// New returns a KVset persisted in db, registers to consensus module at address
+func New(db database.Database, address string) (*KVset, error) {
+	h, err := hasher.GetHasher("SHA256")
+	if err != nil {
+		return nil, err
+	}
+
+	return &KVset{
+		db:      db,
+		address: address,
+		hasher:  h,
+	}, nil
 }
 
 func (kv *KVset) Metadata() (*types.AppMetadata, error) {
@@ -33,6 +99,414 @@ func (kv *KVset) Metadata() (*types.AppMetadata, error) {
 
 func (kv *KVset) Config() *types.AppConfig {
 	return &types.AppConfig{
-		MasterAddress: "127.0.0.1:9024",
+		MasterAddress: kv.address,
+		Hash:          "SHA256",
+	}
+}
+
+// CheckTx accepts valid SET/DELETE txs
+func (kv *KVset) CheckTx(tx *types.Transaction) error {
+	_, err := decodeTx(tx)
+	return err
+}
+
+func (kv *KVset) BeginBlock(header *types.BlockHeader) error {
+	kv.height = header.GetBlockHeight()
+	kv.pending = nil
+	return nil
+}
+
+// DeliverTx stages tx to be applied on commit
+func (kv *KVset) DeliverTx(tx *types.Transaction) error {
+	_, err := kv.DeliverTxEvents(tx)
+	return err
+}
+
+// DeliverTxEvents stages tx to be applied on commit, emits a "set" or "delete" event of the key
+func (kv *KVset) DeliverTxEvents(tx *types.Transaction) ([]*types.Event, error) {
+	kvtx, err := decodeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	kv.pending = append(kv.pending, kvtx)
+	return []*types.Event{
+		types.NewEvent(strings.ToLower(kvtx.GetOp().String()), map[string]string{"key": kvtx.GetKey()}),
+	}, nil
+}
+
+func (kv *KVset) EndBlock(header *types.BlockHeader) error {
+	return nil
+}
+
+// Commit applies txs of the current block with its height atomically, returns the merkle root of state.
+// Only the subtrees of the keys changed are rehashed.
+func (kv *KVset) Commit() ([]byte, error) {
+	if err := kv.loadSubtrees(); err != nil {
+		return nil, err
+	}
+
+	batch, err := kv.db.NewBatch()
+	if err != nil {
+		return nil, err
+	}
+	defer batch.Release()
+
+	// leaf hashes of the changed keys by subtree, nil if deleted
+	changes := make(map[byte]map[string][]byte)
+	for _, kvtx := range kv.pending {
+		var leafHash []byte
+		switch kvtx.GetOp() {
+		case SET:
+			if err = batch.Set(stateBucket, kvtx.GetKey(), kvtx.GetValue()); err == nil {
+				leafHash, err = kv.leafHash(&Pair{Key: kvtx.GetKey(), Value: kvtx.GetValue()})
+			}
+		case DELETE:
+			err = batch.Delete(stateBucket, kvtx.GetKey())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		subtree, err := kv.subtree(kvtx.GetKey())
+		if err != nil {
+			return nil, err
+		}
+		if changes[subtree] == nil {
+			changes[subtree] = make(map[string][]byte)
+		}
+		changes[subtree][kvtx.GetKey()] = leafHash
+	}
+
+	roots := make(map[byte][]byte)
+	for subtree, leafs := range changes {
+		if roots[subtree], err = kv.updateSubtree(batch, subtree, leafs); err != nil {
+			return nil, err
+		}
+	}
+
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, kv.height)
+	if err := batch.Set(metaBucket, heightKey, heightBytes); err != nil {
+		return nil, err
+	}
+
+	if err := batch.Commit(); err != nil {
+		return nil, err
+	}
+	kv.pending = nil
+	for subtree, root := range roots {
+		if root == nil {
+			delete(kv.subtrees, subtree)
+		} else {
+			kv.subtrees[subtree] = root
+		}
+	}
+
+	return kv.stateHash()
+}
+
+// Query decodes query as Query, returns the encoded QueryResult
+func (kv *KVset) Query(query []byte) ([]byte, error) {
+	q := new(Query)
+	if err := proto.Unmarshal(query, q); err != nil {
+		return nil, errors.Wrap(err, "unmarshal query error")
+	}
+
+	var pairs []*Pair
+	if q.GetKey() != "" {
+		value, err := kv.db.Get(stateBucket, q.GetKey())
+		switch err {
+		case nil:
+			pairs = append(pairs, &Pair{Key: q.GetKey(), Value: value})
+		case database.ErrKeyNotFound:
+		default:
+			return nil, err
+		}
+	} else {
+		var err error
+		if pairs, err = kv.scan(q.GetStart(), q.GetEnd(), q.GetLimit()); err != nil {
+			return nil, err
+		}
+	}
+
+	return proto.Marshal(&QueryResult{Pairs: pairs})
+}
+
+// LastHeight returns height of the last committed block
+func (kv *KVset) LastHeight() (uint64, error) {
+	heightBytes, err := kv.db.Get(metaBucket, heightKey)
+	switch err {
+	case nil:
+		return binary.BigEndian.Uint64(heightBytes), nil
+	case database.ErrKeyNotFound:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// scan returns at most limit pairs of key in [start, end) in key order, empty end means no upper bound
+func (kv *KVset) scan(start, end string, limit uint32) ([]*Pair, error) {
+	it, err := kv.db.NewIterator(stateBucket, "")
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	if err := it.Seek(start); err != nil {
+		return nil, err
+	}
+
+	var pairs []*Pair
+	for ; it.HasNext(); it.Next() {
+		if limit > 0 && uint32(len(pairs)) >= limit {
+			break
+		}
+
+		kvp, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+		if end != "" && kvp.Key >= end {
+			break
+		}
+
+		pairs = append(pairs, &Pair{Key: kvp.Key, Value: append([]byte{}, kvp.Value...)})
+	}
+
+	return pairs, nil
+}
+
+// stateHash returns the merkle root of the non-empty subtree roots in subtree order, nil if state is empty.
+// A subtree root is the merkle root of its pairs in key order.
+func (kv *KVset) stateHash() ([]byte, error) {
+	if err := kv.loadSubtrees(); err != nil {
+		return nil, err
+	}
+
+	var roots [][]byte
+	for subtree := 0; subtree <= 0xff; subtree++ {
+		if root, ok := kv.subtrees[byte(subtree)]; ok {
+			roots = append(roots, root)
+		}
+	}
+
+	return kv.merkleRoot(roots)
+}
+
+// loadSubtrees loads the subtree roots, state committed before they're indexed is indexed first
+func (kv *KVset) loadSubtrees() error {
+	if kv.subtrees != nil {
+		return nil
+	}
+
+	if _, err := kv.db.Get(metaBucket, indexedKey); err == database.ErrKeyNotFound {
+		if err := kv.index(); err != nil {
+			return errors.Wrap(err, "index state error")
+		}
+	} else if err != nil {
+		return err
+	}
+
+	it, err := kv.db.NewIterator(metaBucket, subtreeKeyPrefix)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	subtrees := make(map[byte][]byte)
+	for ; it.HasNext(); it.Next() {
+		kvp, err := it.Value()
+		if err != nil {
+			return err
+		}
+
+		subtree, err := hex.DecodeString(strings.TrimPrefix(kvp.Key, subtreeKeyPrefix))
+		if err != nil || len(subtree) != 1 {
+			return errors.Errorf("invalid subtree key %s", kvp.Key)
+		}
+		subtrees[subtree[0]] = append([]byte{}, kvp.Value...)
+	}
+	kv.subtrees = subtrees
+
+	return nil
+}
+
+// index indexes the leaf hashes and subtree roots of all pairs
+func (kv *KVset) index() error {
+	pairs, err := kv.scan("", "", 0)
+	if err != nil {
+		return err
+	}
+
+	changes := make(map[byte]map[string][]byte)
+	for _, pair := range pairs {
+		subtree, err := kv.subtree(pair.GetKey())
+		if err != nil {
+			return err
+		}
+		leafHash, err := kv.leafHash(pair)
+		if err != nil {
+			return err
+		}
+
+		if changes[subtree] == nil {
+			changes[subtree] = make(map[string][]byte)
+		}
+		changes[subtree][pair.GetKey()] = leafHash
+	}
+
+	batch, err := kv.db.NewBatch()
+	if err != nil {
+		return err
+	}
+	defer batch.Release()
+
+	for subtree, leafs := range changes {
+		if _, err := kv.updateSubtree(batch, subtree, leafs); err != nil {
+			return err
+		}
+	}
+	if err := batch.Set(metaBucket, indexedKey, []byte{1}); err != nil {
+		return err
+	}
+
+	return batch.Commit()
+}
+
+// updateSubtree applies the leaf hashes changed to subtree in batch, nil ones are removed.
+// It returns the new root of subtree, nil if it's empty.
+func (kv *KVset) updateSubtree(batch database.Batch, subtree byte, changes map[string][]byte) ([]byte, error) {
+	prefix := fmt.Sprintf("%02x/", subtree)
+	it, err := kv.db.NewIterator(leafBucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var leafs []*Pair
+	for ; it.HasNext(); it.Next() {
+		kvp, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.TrimPrefix(kvp.Key, prefix)
+		if _, ok := changes[key]; !ok {
+			leafs = append(leafs, &Pair{Key: key, Value: append([]byte{}, kvp.Value...)})
+		}
+	}
+
+	for key, leafHash := range changes {
+		if leafHash == nil {
+			if err := batch.Delete(leafBucket, prefix+key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := batch.Set(leafBucket, prefix+key, leafHash); err != nil {
+			return nil, err
+		}
+		leafs = append(leafs, &Pair{Key: key, Value: leafHash})
+	}
+	sort.Slice(leafs, func(i, j int) bool {
+		return leafs[i].GetKey() < leafs[j].GetKey()
+	})
+
+	hashes := make([][]byte, len(leafs))
+	for i, leaf := range leafs {
+		hashes[i] = leaf.GetValue()
+	}
+	root, err := kv.merkleRoot(hashes)
+	if err != nil {
+		return nil, err
 	}
+
+	subtreeKey := fmt.Sprintf("%s%02x", subtreeKeyPrefix, subtree)
+	if root == nil {
+		err = batch.Delete(metaBucket, subtreeKey)
+	} else {
+		err = batch.Set(metaBucket, subtreeKey, root)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// subtree returns the subtree key is in, the first byte of its hash
+func (kv *KVset) subtree(key string) (byte, error) {
+	keyHash, err := kv.hasher.Hash([]byte(key))
+	if err != nil {
+		return 0, err
+	}
+
+	return keyHash[0], nil
+}
+
+// leafHash returns the hash of pair as a merkle tree leaf
+func (kv *KVset) leafHash(pair *Pair) ([]byte, error) {
+	pairBytes, err := proto.Marshal(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	return kv.hasher.Hash(pairBytes)
+}
+
+// merkleRoot returns the merkle root of hashes, nil if none
+func (kv *KVset) merkleRoot(hashes [][]byte) ([]byte, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	leafs := make([]basic.Value, len(hashes))
+	for i, hash := range hashes {
+		leafs[i] = leaf(hash)
+	}
+
+	tree, err := basic.New(leafs, kv.hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	return tree.Root(), nil
+}
+
+// decodeTx decodes and validates KVTx in tx payload
+func decodeTx(tx *types.Transaction) (*KVTx, error) {
+	kvtx := new(KVTx)
+	if err := proto.Unmarshal(tx.GetPayload(), kvtx); err != nil {
+		return nil, errors.Wrap(err, "unmarshal tx error")
+	}
+
+	if kvtx.GetKey() == "" {
+		return nil, ErrEmptyKey
+	}
+
+	switch kvtx.GetOp() {
+	case SET, DELETE:
+		return kvtx, nil
+	default:
+		return nil, errors.Wrapf(ErrUnknownOp, "op %d", kvtx.GetOp())
+	}
+}
+
+// leaf is a hashed node in merkle tree
+type leaf []byte
+
+func (l leaf) Hash() ([]byte, error) {
+	return l, nil
+}
+
+func (l leaf) Equals(other interface{}) bool {
+	o, ok := other.(leaf)
+	if !ok {
+		return false
+	}
+
+	return bytes.Equal(o, l)
 }
@@ -0,0 +1,432 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package kvset
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/common/database/badger"
+	"github.com/mintzhao/topachain/common/genesis"
+	"github.com/mintzhao/topachain/consensus"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+var testdbdir = "./testdata"
+
+func openDB(t *testing.T, name string) database.Database {
+	dir := filepath.Join(testdbdir, name)
+	assert.NoError(t, os.MkdirAll(dir, os.ModePerm))
+
+	db, err := badger.New(dir)
+	assert.NoError(t, err)
+
+	return db
+}
+
+func kvTx(t *testing.T, op OpType, key, value string) []byte {
+	payload, err := proto.Marshal(&KVTx{Op: op, Key: key, Value: []byte(value)})
+	assert.NoError(t, err)
+
+	return payload
+}
+
+func query(t *testing.T, q *Query) []byte {
+	queryBytes, err := proto.Marshal(q)
+	assert.NoError(t, err)
+
+	return queryBytes
+}
+
+func queryResult(t *testing.T, resultBytes []byte) map[string]string {
+	result := new(QueryResult)
+	assert.NoError(t, proto.Unmarshal(resultBytes, result))
+
+	pairs := make(map[string]string)
+	for _, pair := range result.GetPairs() {
+		pairs[pair.GetKey()] = string(pair.GetValue())
+	}
+
+	return pairs
+}
+
+func block(height uint64, payloads ...[]byte) *types.Block {
+	txs := make([]*types.Transaction, len(payloads))
+	for i, payload := range payloads {
+		txs[i] = &types.Transaction{Payload: payload}
+	}
+
+	return &types.Block{
+		Header: &types.BlockHeader{BlockHeight: height},
+		Txs:    &types.BlockTxs{Txs: txs},
+	}
+}
+
+// waitStatus waits the status of kvset of m satisfies ok
+func waitStatus(t *testing.T, m *consensus.Manager, ok func(*consensus.AppStatus) bool) {
+	for i := 0; i < 100; i++ {
+		if status, err := m.Status("kvset"); err == nil && ok(status) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatal("kvset not in expected status")
+}
+
+func TestKVset(t *testing.T) {
+	os.Mkdir(testdbdir, os.ModePerm)
+	defer os.RemoveAll(testdbdir)
+
+	db := openDB(t, "kvset")
+	defer db.Close()
+
+	kv, err := New(db, "")
+	assert.NoError(t, err)
+
+	assert.NoError(t, kv.CheckTx(&types.Transaction{Payload: kvTx(t, DELETE, "a", "")}))
+	assert.Equal(t, ErrEmptyKey, kv.CheckTx(&types.Transaction{Payload: kvTx(t, SET, "", "1")}))
+	assert.Equal(t, ErrUnknownOp, errors.Cause(kv.CheckTx(&types.Transaction{Payload: kvTx(t, OpType(9), "a", "")})))
+	assert.Error(t, kv.CheckTx(&types.Transaction{Payload: []byte{0xff}}))
+
+	// empty state has no hash
+	height, err := kv.LastHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), height)
+	hash, err := kv.stateHash()
+	assert.NoError(t, err)
+	assert.Nil(t, hash)
+
+	assert.NoError(t, kv.BeginBlock(&types.BlockHeader{BlockHeight: 1}))
+	for _, payload := range [][]byte{
+		kvTx(t, SET, "a", "1"),
+		kvTx(t, SET, "b", "2"),
+		kvTx(t, SET, "c", "3"),
+		kvTx(t, SET, "d", "4"),
+		kvTx(t, DELETE, "c", ""),
+	} {
+		assert.NoError(t, kv.DeliverTx(&types.Transaction{Payload: payload}))
+	}
+
+	// nothing applied before commit
+	resultBytes, err := kv.Query(query(t, &Query{Key: "a"}))
+	assert.NoError(t, err)
+	assert.Empty(t, queryResult(t, resultBytes))
+
+	assert.NoError(t, kv.EndBlock(&types.BlockHeader{BlockHeight: 1}))
+	hash1, err := kv.Commit()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hash1)
+
+	height, err = kv.LastHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), height)
+
+	resultBytes, err = kv.Query(query(t, &Query{Key: "a"}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1"}, queryResult(t, resultBytes))
+
+	resultBytes, err = kv.Query(query(t, &Query{Start: "b"}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"b": "2", "d": "4"}, queryResult(t, resultBytes))
+
+	resultBytes, err = kv.Query(query(t, &Query{Start: "a", End: "d"}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, queryResult(t, resultBytes))
+
+	resultBytes, err = kv.Query(query(t, &Query{Limit: 1}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1"}, queryResult(t, resultBytes))
+
+	// hash follows state, not history
+	assert.NoError(t, kv.BeginBlock(&types.BlockHeader{BlockHeight: 2}))
+	assert.NoError(t, kv.DeliverTx(&types.Transaction{Payload: kvTx(t, SET, "e", "5")}))
+	hash2, err := kv.Commit()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash1, hash2)
+
+	assert.NoError(t, kv.BeginBlock(&types.BlockHeader{BlockHeight: 3}))
+	events, err := kv.DeliverTxEvents(&types.Transaction{Payload: kvTx(t, DELETE, "e", "")})
+	assert.NoError(t, err)
+	assert.Equal(t, []*types.Event{types.NewEvent("delete", map[string]string{"key": "e"})}, events)
+	hash3, err := kv.Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, hash1, hash3)
+
+	// state of the same pairs committed at once hashes the same, and so does the state indexed afresh
+	fresh := openDB(t, "fresh")
+	defer fresh.Close()
+	kv2, err := New(fresh, "")
+	assert.NoError(t, err)
+	assert.NoError(t, kv2.BeginBlock(&types.BlockHeader{BlockHeight: 1}))
+	for _, key := range []string{"d", "b", "a"} {
+		assert.NoError(t, kv2.DeliverTx(&types.Transaction{Payload: kvTx(t, SET, key, map[string]string{"a": "1", "b": "2", "d": "4"}[key])}))
+	}
+	hash, err = kv2.Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, hash1, hash)
+
+	assert.NoError(t, fresh.Delete(metaBucket, indexedKey))
+	kv2, err = New(fresh, "")
+	assert.NoError(t, err)
+	hash, err = kv2.stateHash()
+	assert.NoError(t, err)
+	assert.Equal(t, hash1, hash)
+}
+
+func TestKVset_EndToEnd(t *testing.T) {
+	os.Mkdir(testdbdir, os.ModePerm)
+	defer os.RemoveAll(testdbdir)
+
+	nodeDB := openDB(t, "node")
+	defer nodeDB.Close()
+	appDB := openDB(t, "app")
+	defer appDB.Close()
+
+	// node side, consensus manager serving applications
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	kv, err := New(appDB, lis.Addr().String())
+	assert.NoError(t, err)
+	meta, err := kv.Metadata()
+	assert.NoError(t, err)
+
+	// blocks of 3 txs cut by the consensus instance of kvset
+	m := consensus.NewManager()
+	defer m.Stop()
+	genesisBlock, err := genesis.GenesisBlock(meta.GetName(), &types.AppConfig{BlockInterval: 60000, BlockTxCount: 3}, meta.GetVersion())
+	assert.NoError(t, err)
+	assert.NoError(t, m.AddApplication(genesisBlock, nodeDB))
+	assert.NoError(t, m.StartApplication("kvset"))
+
+	server := grpc.NewServer()
+	types.RegisterApplicationServer(server, consensus.NewApplicationServer(m))
+	go server.Serve(lis)
+	defer server.Stop()
+
+	// application side
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go application.RunContext(ctx, kv)
+
+	submit := func(payload []byte) error {
+		var err error
+		for i := 0; i < 100; i++ {
+			if _, err = m.ReceiveTxSync("kvset", payload); err != consensus.ErrApplicationUnregistered {
+				return err
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		return err
+	}
+
+	// txs checked by application before they're packed into block
+	assert.Error(t, submit(kvTx(t, SET, "", "0")))
+	for _, payload := range [][]byte{kvTx(t, SET, "alice", "100"), kvTx(t, SET, "bob", "50"), kvTx(t, SET, "carol", "10")} {
+		assert.NoError(t, submit(payload))
+	}
+	waitStatus(t, m, func(status *consensus.AppStatus) bool { return status.Height == 1 })
+
+	resultBytes, err := m.Query("kvset", query(t, &Query{Key: "bob"}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"bob": "50"}, queryResult(t, resultBytes))
+
+	// events indexed by block, and streamed to subscribers
+	events, err := m.Events("kvset", 1, "")
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, "set", events[2].GetEvent().GetType())
+
+	sub := m.Subscribe(&types.EventFilter{Application: "kvset", Type: "delete"})
+	defer sub.Cancel()
+
+	_, err = m.DeliverBlock("kvset", block(2, kvTx(t, DELETE, "bob", ""), kvTx(t, SET, "alice", "90")))
+	assert.NoError(t, err)
+
+	select {
+	case event := <-sub.Events():
+		assert.Equal(t, uint64(2), event.GetHeight())
+		assert.Equal(t, uint32(0), event.GetTxIndex())
+		assert.Equal(t, "bob", event.GetEvent().GetAttributes()[0].GetValue())
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+
+	resultBytes, err = m.Query("kvset", query(t, &Query{Start: "a"}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"alice": "90", "carol": "10"}, queryResult(t, resultBytes))
+
+	height, err := kv.LastHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), height)
+}
+
+func TestKVset_Local(t *testing.T) {
+	os.Mkdir(testdbdir, os.ModePerm)
+	defer os.RemoveAll(testdbdir)
+
+	nodeDB := openDB(t, "node")
+	defer nodeDB.Close()
+
+	factory, err := application.GetFactory("kvset")
+	assert.NoError(t, err)
+	app, err := factory(filepath.Join(testdbdir, "local"))
+	assert.NoError(t, err)
+
+	m := consensus.NewManager()
+	defer m.Stop()
+	meta, err := app.Metadata()
+	assert.NoError(t, err)
+	genesisBlock, err := genesis.GenesisBlock(meta.GetName(), &types.AppConfig{BlockInterval: 60000, BlockTxCount: 1}, meta.GetVersion())
+	assert.NoError(t, err)
+	assert.NoError(t, m.AddApplication(genesisBlock, nodeDB))
+	assert.NoError(t, m.StartApplication("kvset"))
+
+	// served in-process, without dialing
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		application.RunWith(ctx, app, consensus.NewLocalClient(m))
+	}()
+
+	payload := kvTx(t, SET, "alice", "100")
+	for i := 0; i < 100; i++ {
+		if _, err = m.ReceiveTxSync("kvset", payload); err != consensus.ErrApplicationUnregistered {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.NoError(t, err)
+	waitStatus(t, m, func(status *consensus.AppStatus) bool { return status.Height == 1 })
+
+	cancel()
+	<-done
+	assert.NoError(t, app.(io.Closer).Close())
+
+	// state is kept in its own database under dir
+	app, err = factory(filepath.Join(testdbdir, "local"))
+	assert.NoError(t, err)
+	defer app.(io.Closer).Close()
+
+	height, err := app.(*localKVset).LastHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), height)
+}
+
+func TestKVset_StateSync(t *testing.T) {
+	os.Mkdir(testdbdir, os.ModePerm)
+	defer os.RemoveAll(testdbdir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// serve starts a node of kvset snapshotting every 2 blocks, the kvset application stored in db connected to it
+	genesisBlock, err := genesis.GenesisBlock("kvset", &types.AppConfig{Hash: "SHA256", SnapshotInterval: 2}, nil)
+	assert.NoError(t, err)
+	serve := func(name string, sync func(m *consensus.Manager)) (*consensus.Manager, *KVset, string) {
+		nodeDB := openDB(t, name+".node")
+		m := consensus.NewManager()
+		assert.NoError(t, m.AddApplication(genesisBlock, nodeDB))
+		if sync != nil {
+			sync(m)
+		}
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		server := grpc.NewServer()
+		types.RegisterApplicationServer(server, consensus.NewApplicationServer(m))
+		types.RegisterStateSyncServer(server, consensus.NewStateSyncServer(m))
+		go server.Serve(lis)
+
+		appDB := openDB(t, name+".app")
+		kv, err := New(appDB, lis.Addr().String())
+		assert.NoError(t, err)
+		go application.RunContext(ctx, kv)
+
+		go func() {
+			<-ctx.Done()
+			server.Stop()
+			m.Stop()
+			appDB.Close()
+			nodeDB.Close()
+		}()
+
+		return m, kv, lis.Addr().String()
+	}
+
+	source, _, address := serve("source", nil)
+	waitHeight := func(kv *KVset, height uint64) {
+		for i := 0; i < 100; i++ {
+			if h, _ := kv.LastHeight(); h == height {
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		t.Fatalf("kvset not at height %d", height)
+	}
+
+	blocks := [][][]byte{
+		{kvTx(t, SET, "alice", "100"), kvTx(t, SET, "bob", "50")},
+		{kvTx(t, SET, "carol", "10"), kvTx(t, DELETE, "bob", "")},
+	}
+	waitStatus(t, source, func(status *consensus.AppStatus) bool { return status.Registered })
+	for i, payloads := range blocks {
+		_, err := source.DeliverBlock("kvset", block(uint64(i+1), payloads...))
+		assert.NoError(t, err)
+	}
+
+	snapshots, err := source.ListSnapshots("kvset")
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	trustHash, err := source.BlockHash("kvset", 2)
+	assert.NoError(t, err)
+
+	// fresh kvset restores the state of block 2 from the source node
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	fresh, kv, _ := serve("fresh", func(m *consensus.Manager) {
+		assert.NoError(t, m.StateSync("kvset", consensus.NewStateSyncSource(types.NewStateSyncClient(conn)), trustHash, snapshots[0].GetAppHash()))
+	})
+	waitHeight(kv, 2)
+
+	hash, err := kv.stateHash()
+	assert.NoError(t, err)
+	assert.Equal(t, snapshots[0].GetAppHash(), hash)
+	resultBytes, err := fresh.Query("kvset", query(t, &Query{Start: "a"}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"alice": "100", "carol": "10"}, queryResult(t, resultBytes))
+
+	// and continues from it
+	appHash, err := fresh.DeliverBlock("kvset", block(3, kvTx(t, SET, "dave", "1")))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, appHash)
+	resultBytes, err = fresh.Query("kvset", query(t, &Query{Key: "dave"}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"dave": "1"}, queryResult(t, resultBytes))
+}
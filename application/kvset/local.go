@@ -0,0 +1,59 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package kvset
+
+import (
+	"os"
+
+	"github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/common/database/badger"
+)
+
+// kvset is linked into the node binary by importing this package, and served in-process
+// if node.applications configures it local
+func init() {
+	application.RegisterFactory("kvset", NewLocal)
+}
+
+// localKVset is a KVset served in-process, owning its database
+type localKVset struct {
+	*KVset
+	db database.Database
+}
+
+// NewLocal returns a KVset persisted in its own database under dir, to be served in-process.
+// The database is closed with Close.
+func NewLocal(dir string) (application.Application, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	db, err := badger.New(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	kv, err := New(db, "")
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &localKVset{KVset: kv, db: db}, nil
+}
+
+// Close closes the database of kvset
+func (l *localKVset) Close() error {
+	return l.db.Close()
+}
@@ -0,0 +1,195 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package kvset
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// snapshotBucket is the bucket snapshot chunks stored in, under height/index
+	snapshotBucket = "kvset.snapshot"
+
+	// chunkPairs is the max number of pairs in a snapshot chunk
+	chunkPairs = 1024
+
+	// snapshotsKept is how many of the latest snapshots are kept
+	snapshotsKept = 2
+)
+
+var (
+	// ErrSnapshotNotFound means no snapshot chunk stored at the height and index
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+)
+
+// TakeSnapshot snapshots the state committed at height into chunks of pairs in key order.
+// Chunks are stored until snapshotsKept newer snapshots taken.
+func (kv *KVset) TakeSnapshot(height uint64) (*types.Snapshot, error) {
+	if last, err := kv.LastHeight(); err != nil {
+		return nil, err
+	} else if last != height {
+		return nil, errors.Errorf("snapshot at %d, committed %d", height, last)
+	}
+
+	pairs, err := kv.scan("", "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// empty state is a single empty chunk
+	var chunks [][]byte
+	for start := 0; start == 0 || start < len(pairs); start += chunkPairs {
+		end := start + chunkPairs
+		if end > len(pairs) {
+			end = len(pairs)
+		}
+
+		chunk, err := proto.Marshal(&Chunk{Pairs: pairs[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	appHash, err := kv.stateHash()
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := kv.db.NewBatch()
+	if err != nil {
+		return nil, err
+	}
+	defer batch.Release()
+
+	for i, chunk := range chunks {
+		if err := batch.Set(snapshotBucket, chunkKey(height, uint32(i)), chunk); err != nil {
+			return nil, err
+		}
+	}
+	if err := kv.prune(batch, height); err != nil {
+		return nil, err
+	}
+	if err := batch.Commit(); err != nil {
+		return nil, err
+	}
+
+	return types.NewSnapshot(height, appHash, chunks, kv.Config().GetHash())
+}
+
+// LoadSnapshotChunk returns chunk index of the snapshot taken at height
+func (kv *KVset) LoadSnapshotChunk(height uint64, index uint32) ([]byte, error) {
+	chunk, err := kv.db.Get(snapshotBucket, chunkKey(height, index))
+	if err == database.ErrKeyNotFound {
+		return nil, errors.Wrapf(ErrSnapshotNotFound, "chunk %d of snapshot %d", index, height)
+	}
+
+	return chunk, err
+}
+
+// ApplySnapshotChunk sets the pairs of chunk index of snapshot, state is expected empty before the first one.
+// Once the last one applied, state is indexed and committed at snapshot height, its hash returned.
+func (kv *KVset) ApplySnapshotChunk(snapshot *types.Snapshot, index uint32, chunk []byte) ([]byte, error) {
+	c := new(Chunk)
+	if err := proto.Unmarshal(chunk, c); err != nil {
+		return nil, errors.Wrap(err, "unmarshal chunk error")
+	}
+
+	batch, err := kv.db.NewBatch()
+	if err != nil {
+		return nil, err
+	}
+	defer batch.Release()
+
+	for _, pair := range c.GetPairs() {
+		if err := batch.Set(stateBucket, pair.GetKey(), pair.GetValue()); err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		return nil, err
+	}
+
+	if int(index) < len(snapshot.GetChunkHashes())-1 {
+		return nil, nil
+	}
+
+	if err := kv.index(); err != nil {
+		return nil, errors.Wrap(err, "index state error")
+	}
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, snapshot.GetHeight())
+	if err := kv.db.Set(metaBucket, heightKey, heightBytes); err != nil {
+		return nil, err
+	}
+	kv.height = snapshot.GetHeight()
+	kv.pending = nil
+	kv.subtrees = nil
+
+	return kv.stateHash()
+}
+
+// prune deletes in batch the chunks of the snapshots older than the snapshotsKept latest ones, height included
+func (kv *KVset) prune(batch database.Batch, height uint64) error {
+	it, err := kv.db.NewIterator(snapshotBucket, "")
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	// chunk keys by snapshot height
+	keys := make(map[uint64][]string)
+	heights := []uint64{height}
+	for ; it.HasNext(); it.Next() {
+		kvp, err := it.Value()
+		if err != nil {
+			return err
+		}
+
+		h, err := strconv.ParseUint(strings.SplitN(kvp.Key, "/", 2)[0], 10, 64)
+		if err != nil {
+			return errors.Errorf("invalid snapshot chunk key %s", kvp.Key)
+		}
+		if _, ok := keys[h]; !ok && h != height {
+			heights = append(heights, h)
+		}
+		keys[h] = append(keys[h], kvp.Key)
+	}
+
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] > heights[j]
+	})
+	for i := snapshotsKept; i < len(heights); i++ {
+		for _, key := range keys[heights[i]] {
+			if err := batch.Delete(snapshotBucket, key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// chunkKey returns the key chunk index of the snapshot at height stored under, ordered by height
+func chunkKey(height uint64, index uint32) string {
+	return fmt.Sprintf("%020d/%08d", height, index)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package application
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrFactoryAlreadyRegistered means an application of the name is already linked
+	ErrFactoryAlreadyRegistered = errors.New("application factory already registered")
+
+	// ErrFactoryNotFound means no application of the name is linked
+	ErrFactoryNotFound = errors.New("application factory not found")
+
+	// factories of applications linked into the binary
+	factories sync.Map
+)
+
+// Factory creates an application linked into the node binary, keeping its own state under dir.
+// Applications implementing io.Closer are closed once they stop serving.
+type Factory func(dir string) (Application, error)
+
+// RegisterFactory links application name into the binary, so that node can serve it in-process.
+// It's usually called in init of the application package.
+func RegisterFactory(name string, factory Factory) error {
+	if _, loaded := factories.LoadOrStore(name, factory); loaded {
+		logger.Warningf("application factory %s already registered", name)
+		return ErrFactoryAlreadyRegistered
+	}
+
+	return nil
+}
+
+// GetFactory returns the factory of linked application name
+func GetFactory(name string) (Factory, error) {
+	factory, ok := factories.Load(name)
+	if !ok {
+		return nil, errors.Wrapf(ErrFactoryNotFound, "application %s", name)
+	}
+
+	return factory.(Factory), nil
+}
@@ -0,0 +1,38 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package application
+
+import (
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrSnapshotUnsupported means application doesn't implement Snapshotter
+	ErrSnapshotUnsupported = errors.New("snapshot unsupported")
+)
+
+// Snapshotter is implemented by applications supporting state sync.
+// Node asks application to snapshot every AppConfig.SnapshotInterval blocks, fresh nodes restore state from the snapshots.
+type Snapshotter interface {
+	// TakeSnapshot snapshots the state committed at height, called right after its Commit.
+	// Use types.NewSnapshot to describe the chunks, which are loaded by LoadSnapshotChunk later.
+	TakeSnapshot(height uint64) (*types.Snapshot, error)
+
+	// LoadSnapshotChunk returns chunk index of the snapshot taken at height
+	LoadSnapshotChunk(height uint64, index uint32) ([]byte, error)
+
+	// ApplySnapshotChunk restores state from chunk index of snapshot, chunks are applied in index order.
+	// Once the last one applied, state is the one committed at snapshot height, its hash is returned.
+	ApplySnapshotChunk(snapshot *types.Snapshot, index uint32, chunk []byte) ([]byte, error)
+}
@@ -0,0 +1,68 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mintzhao/topachain/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Operate node config",
+	Long:  `Operate node config, which is merged from defaults, config file and TOPA_* environment variables`,
+	// overrides root's, config must be inspectable even if crypto can't be inited from it
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// configCheckCmd represents the config check command
+var configCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Print the effective config and validate it",
+	Long:  `Print the effective config merged from defaults, config file and TOPA_* environment variables, then report every invalid value`,
+	Run: func(cmd *cobra.Command, args []string) {
+		out, err := yaml.Marshal(conf)
+		if err != nil {
+			logger.Errorf("format config error: %s", err)
+			os.Exit(-1)
+		}
+
+		fmt.Println(string(out))
+
+		if err := conf.Validate(); err != nil {
+			problems := err.(config.ValidationError)
+			for _, p := range problems {
+				fmt.Println(p)
+			}
+
+			logger.Errorf("config check failed, %d problem(s) found", len(problems))
+			os.Exit(-1)
+		}
+
+		logger.Info("config check done!")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configCheckCmd)
+}
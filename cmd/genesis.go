@@ -32,11 +32,25 @@ var genesisCmd = &cobra.Command{
 
 		// step 1: generate genesis block
 		logger.Info("generate genesis block")
+		var version *types.AppVersion
+		if appVersion != "" {
+			if appBackwards == "" {
+				appBackwards = appVersion
+			}
+
+			var err error
+			if version, err = types.NewAppVersion(appVersion, appBackwards); err != nil {
+				logger.Errorf("parse application version error: %s", err)
+				os.Exit(-1)
+			}
+		}
+
 		blk, err := genesis.GenesisBlock(appName, &types.AppConfig{
-			BlockInterval: appBlockInterval,
-			BlockTxCount:  appBlockTxCount,
-			Hash:          appHash,
-		})
+			BlockInterval:    appBlockInterval,
+			BlockTxCount:     appBlockTxCount,
+			Hash:             appHash,
+			SnapshotInterval: appSnapshotInterval,
+		}, version)
 		if err != nil {
 			logger.Errorf("generate genesis block error: %s", err)
 			os.Exit(-1)
@@ -67,6 +81,9 @@ var (
 	appBlockInterval      int64
 	appBlockTxCount       int64
 	appHash               string
+	appVersion            string
+	appBackwards          string
+	appSnapshotInterval   uint64
 	genesisBlockOutputDir string
 )
 
@@ -77,6 +94,9 @@ func init() {
 	genesisCmd.MarkFlagRequired("appName")
 	genesisCmd.Flags().Int64VarP(&appBlockInterval, "blockInterval", "t", 2000, "max block interval, unit: millisecond")
 	genesisCmd.Flags().Int64VarP(&appBlockTxCount, "blockTxCount", "c", 10, "max block tx count")
-	genesisCmd.Flags().StringVarP(&appHash, "appHash", "", "SHA256", "application hash algorithm")
+	genesisCmd.Flags().StringVarP(&appHash, "appHash", "", "SHA256", "application hash algorithm, one of MD5, SHA256, SHA512, SHA3-256, KECCAK256, BLAKE2B-256, SM3")
+	genesisCmd.Flags().StringVarP(&appVersion, "appVersion", "", "", "initial application version, empty accepts any version")
+	genesisCmd.Flags().StringVarP(&appBackwards, "appBackwards", "", "", "oldest application version compatible with appVersion, default is appVersion")
+	genesisCmd.Flags().Uint64VarP(&appSnapshotInterval, "snapshotInterval", "", 0, "blocks between application snapshots, 0 disables snapshots")
 	genesisCmd.Flags().StringVarP(&genesisBlockOutputDir, "output", "o", "./", "genesis block output folder")
 }
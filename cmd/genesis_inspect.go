@@ -0,0 +1,159 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mintzhao/topachain/common/genesis"
+	"github.com/mintzhao/topachain/types"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// genesisInspectCmd represents the genesis inspect command
+var genesisInspectCmd = &cobra.Command{
+	Use:   "inspect <block file>",
+	Short: "Print a genesis block file in human readable form",
+	Long:  `Decode a genesis block file, include its genesis tx proposal and application config, print it as json or yaml`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		blk, err := readGenesisBlockFile(args[0])
+		if err != nil {
+			logger.Errorf("read genesis block error: %s", err)
+			os.Exit(-1)
+		}
+
+		gtxp, err := genesis.GenesisProposal(blk)
+		if err != nil {
+			logger.Errorf("decode genesis tx proposal error: %s", err)
+			os.Exit(-1)
+		}
+
+		view := newGenesisBlockView(blk, gtxp)
+
+		var out []byte
+		switch genesisInspectFormat {
+		case "json":
+			out, err = json.MarshalIndent(view, "", "  ")
+		case "yaml":
+			out, err = yaml.Marshal(view)
+		default:
+			err = fmt.Errorf("unsupported format %s", genesisInspectFormat)
+		}
+		if err != nil {
+			logger.Errorf("format genesis block error: %s", err)
+			os.Exit(-1)
+		}
+
+		fmt.Println(string(out))
+	},
+}
+
+// genesisVerifyCmd represents the genesis verify command
+var genesisVerifyCmd = &cobra.Command{
+	Use:   "verify <block file>",
+	Short: "Verify a genesis block file",
+	Long:  `Recompute genesis block txs hash with the recorded hash algorithm and compare it with the block header txroot`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		blk, err := readGenesisBlockFile(args[0])
+		if err != nil {
+			logger.Errorf("read genesis block error: %s", err)
+			os.Exit(-1)
+		}
+
+		if err := genesis.VerifyGenesisBlock(blk); err != nil {
+			logger.Errorf("verify genesis block error: %s", err)
+			os.Exit(-1)
+		}
+
+		logger.Info("verify genesis block done!")
+	},
+}
+
+var (
+	genesisInspectFormat string
+)
+
+func init() {
+	genesisCmd.AddCommand(genesisInspectCmd)
+	genesisCmd.AddCommand(genesisVerifyCmd)
+
+	genesisInspectCmd.Flags().StringVarP(&genesisInspectFormat, "format", "f", "json", "output format, json or yaml")
+}
+
+// genesisBlockView is the human readable form of a genesis block
+type genesisBlockView struct {
+	Header   genesisHeaderView   `json:"header" yaml:"header"`
+	Proposal genesisProposalView `json:"proposal" yaml:"proposal"`
+}
+
+type genesisHeaderView struct {
+	BlockHeight   uint64 `json:"blockHeight" yaml:"blockHeight"`
+	PreviousBlock string `json:"previousBlock" yaml:"previousBlock"`
+	Txroot        string `json:"txroot" yaml:"txroot"`
+}
+
+type genesisProposalView struct {
+	Name    string        `json:"name" yaml:"name"`
+	Config  appConfigView `json:"config" yaml:"config"`
+	Version string        `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type appConfigView struct {
+	BlockInterval    int64  `json:"blockInterval" yaml:"blockInterval"`
+	BlockTxCount     int64  `json:"blockTxCount" yaml:"blockTxCount"`
+	Hash             string `json:"hash" yaml:"hash"`
+	SnapshotInterval uint64 `json:"snapshotInterval" yaml:"snapshotInterval"`
+}
+
+func newGenesisBlockView(blk *types.Block, gtxp *types.GenesisTxProposal) *genesisBlockView {
+	var version string
+	if gtxp.GetVersion() != nil {
+		version = gtxp.GetVersion().Text()
+	}
+
+	return &genesisBlockView{
+		Header: genesisHeaderView{
+			BlockHeight:   blk.GetHeader().GetBlockHeight(),
+			PreviousBlock: hex.EncodeToString(blk.GetHeader().GetPreviousBlock()),
+			Txroot:        hex.EncodeToString(blk.GetHeader().GetTxroot()),
+		},
+		Proposal: genesisProposalView{
+			Name: gtxp.GetName(),
+			Config: appConfigView{
+				BlockInterval:    gtxp.GetConfig().GetBlockInterval(),
+				BlockTxCount:     gtxp.GetConfig().GetBlockTxCount(),
+				Hash:             gtxp.GetConfig().GetHash(),
+				SnapshotInterval: gtxp.GetConfig().GetSnapshotInterval(),
+			},
+			Version: version,
+		},
+	}
+}
+
+// readGenesisBlockFile reads genesis block from file
+func readGenesisBlockFile(file string) (*types.Block, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return genesis.ReadGenesisBlock(f)
+}
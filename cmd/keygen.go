@@ -0,0 +1,122 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mintzhao/topachain/common/crypto/keys"
+	"github.com/mintzhao/topachain/common/crypto/keystore"
+	"github.com/spf13/cobra"
+)
+
+// keygenCmd represents the keygen command
+var keygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate a key pair",
+	Long: `Generate a key pair for a signer, write private key in PKCS#8 PEM and public key in PKIX PEM, named after the key id.
+If keystore given, private key is stored in it encrypted by passphrase instead.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if keygenKeystore != "" {
+			generateKeystoreKey()
+			return
+		}
+
+		// step 1: generate key pair
+		logger.Infof("generate %s key pair", keygenSigner)
+		privKey, err := keys.GenerateKey(keygenSigner)
+		if err != nil {
+			logger.Errorf("generate key error: %s", err)
+			os.Exit(-1)
+		}
+
+		pubKey, err := keys.PublicKey(privKey)
+		if err != nil {
+			logger.Errorf("get public key error: %s", err)
+			os.Exit(-1)
+		}
+
+		// step 2: derive key id
+		logger.Info("derive key id")
+		keyID, err := keys.KeyID(pubKey)
+		if err != nil {
+			logger.Errorf("derive key id error: %s", err)
+			os.Exit(-1)
+		}
+		id := hex.EncodeToString(keyID)
+
+		// step 3: write key files
+		logger.Info("write key files")
+		if err := keys.WritePrivateKeyFile(filepath.Join(keygenOutputDir, fmt.Sprintf("%s_sk.pem", id)), privKey); err != nil {
+			logger.Errorf("write private key file error: %s", err)
+			os.Exit(-1)
+		}
+
+		if err := keys.WritePublicKeyFile(filepath.Join(keygenOutputDir, fmt.Sprintf("%s_pk.pem", id)), pubKey); err != nil {
+			logger.Errorf("write public key file error: %s", err)
+			os.Exit(-1)
+		}
+
+		logger.Infof("generate key %s done!", id)
+	},
+}
+
+// generateKeystoreKey generates a key into keystore
+func generateKeystoreKey() {
+	passphrase, err := ioutil.ReadFile(keygenPassphraseFile)
+	if err != nil {
+		logger.Errorf("read passphrase file error: %s", err)
+		os.Exit(-1)
+	}
+
+	ks, err := keystore.New(keygenKeystore, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		logger.Errorf("open keystore error: %s", err)
+		os.Exit(-1)
+	}
+	if err := ks.SetCipher(conf.Common.Crypto.Cipher); err != nil {
+		logger.Errorf("set keystore cipher error: %s", err)
+		os.Exit(-1)
+	}
+
+	logger.Infof("generate %s key into keystore", keygenSigner)
+	id, err := ks.Generate(keygenSigner, strings.TrimRight(string(passphrase), "\r\n"))
+	if err != nil {
+		logger.Errorf("generate key error: %s", err)
+		os.Exit(-1)
+	}
+
+	logger.Infof("generate key %s done!", id)
+}
+
+var (
+	keygenSigner         string
+	keygenOutputDir      string
+	keygenKeystore       string
+	keygenPassphraseFile string
+)
+
+func init() {
+	rootCmd.AddCommand(keygenCmd)
+
+	keygenCmd.Flags().StringVarP(&keygenSigner, "signer", "s", "ECDSA", "signer the key used for, RSA/ECDSA/ED25519")
+	keygenCmd.Flags().StringVarP(&keygenOutputDir, "output", "o", "./", "key files output folder")
+	keygenCmd.Flags().StringVarP(&keygenKeystore, "keystore", "k", "", "keystore folder, store encrypted key in it instead of writing PEM files")
+	keygenCmd.Flags().StringVarP(&keygenPassphraseFile, "passphraseFile", "p", "", "file containing the keystore passphrase")
+}
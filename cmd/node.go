@@ -0,0 +1,122 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/hex"
+	"os"
+	"os/signal"
+	"syscall"
+
+	topalogging "github.com/mintzhao/topachain/common/logging"
+	"github.com/mintzhao/topachain/config"
+	"github.com/mintzhao/topachain/node"
+	"github.com/spf13/cobra"
+)
+
+// nodeCmd represents the node command
+var nodeCmd = &cobra.Command{
+	Use:   "node",
+	Short: "Operate a consensus node",
+	Long:  `Operate a consensus node, which serves applications and produces blocks`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// nodeStartCmd represents the node start command
+var nodeStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start a full consensus node",
+	Long:  `Start a full consensus node based on config file and application genesis blocks, serving until SIGINT/SIGTERM`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// step 1: validate config
+		logger.Info("validate config")
+		if err := conf.Validate(); err != nil {
+			logger.Errorf("validate config error: %s", err)
+			os.Exit(-1)
+		}
+
+		// step 2: create node from config
+		logger.Info("create node")
+		n, err := node.New(conf)
+		if err != nil {
+			logger.Errorf("create node error: %s", err)
+			os.Exit(-1)
+		}
+
+		// step 3: restore fresh application from a peer's snapshot if requested
+		if stateSyncPeer != "" {
+			trustHash, err := hex.DecodeString(stateSyncTrustHash)
+			if err != nil || len(trustHash) == 0 {
+				logger.Errorf("invalid trust hash %q", stateSyncTrustHash)
+				os.Exit(-1)
+			}
+			trustAppHash, err := hex.DecodeString(stateSyncTrustAppHash)
+			if err != nil || len(trustAppHash) == 0 {
+				logger.Errorf("invalid trust app hash %q", stateSyncTrustAppHash)
+				os.Exit(-1)
+			}
+			n.StateSync(stateSyncApp, stateSyncPeer, trustHash, trustAppHash)
+		}
+
+		// step 4: apply logging levels on the fly when config file changes
+		if cfgFile != "" {
+			if err := config.Watch(cfgFile, func(c *config.Config) {
+				topalogging.SetLevels(c.Logging)
+			}); err != nil {
+				logger.Errorf("watch config error: %s", err)
+				os.Exit(-1)
+			}
+		}
+
+		// step 5: shutdown gracefully on signals
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			sig := <-sigs
+			logger.Infof("receive signal %s, stopping node", sig)
+			n.Stop()
+		}()
+
+		// step 6: start node, blocks until stopped
+		logger.Info("start node")
+		if err := n.Start(nodeGenesisBlocks...); err != nil {
+			logger.Errorf("start node error: %s", err)
+			os.Exit(-1)
+		}
+
+		logger.Info("node stopped")
+	},
+}
+
+var (
+	nodeGenesisBlocks     []string
+	stateSyncApp          string
+	stateSyncPeer         string
+	stateSyncTrustHash    string
+	stateSyncTrustAppHash string
+)
+
+func init() {
+	rootCmd.AddCommand(nodeCmd)
+	nodeCmd.AddCommand(nodeStartCmd)
+
+	nodeStartCmd.Flags().StringSliceVarP(&nodeGenesisBlocks, "genesisBlock", "g", nil, "application genesis block files, an application hosted for each")
+	nodeStartCmd.MarkFlagRequired("genesisBlock")
+	nodeStartCmd.Flags().StringVarP(&stateSyncApp, "stateSyncApp", "", "", "application to state sync, default is the only hosted one")
+	nodeStartCmd.Flags().StringVarP(&stateSyncPeer, "stateSyncPeer", "", "", "peer node address to restore fresh application state from")
+	nodeStartCmd.Flags().StringVarP(&stateSyncTrustHash, "trustHash", "", "", "hex header hash of the trusted block to state sync to, required by stateSyncPeer")
+	nodeStartCmd.Flags().StringVarP(&stateSyncTrustAppHash, "trustAppHash", "", "", "hex app hash of the trusted snapshot at that block, required by stateSyncPeer, as listed by admin GET /snapshots of a trusted node")
+}
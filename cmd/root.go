@@ -15,18 +15,24 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 
+	"github.com/mintzhao/topachain/common/crypto"
+	topalogging "github.com/mintzhao/topachain/common/logging"
+	"github.com/mintzhao/topachain/config"
 	"github.com/mitchellh/go-homedir"
 	"github.com/op/go-logging"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "topa",
 	Short: "Another Enterprise-Level Consortium Blackchain",
 	Long:  `Topa is Another Enterprise-Level Consortium Blackchain.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initCrypto()
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		cmd.Help()
 	},
@@ -47,19 +53,18 @@ func Execute() {
 var (
 	cfgFile string
 	Version string
+
+	// conf is the config loaded from cfgFile and environment, shared by all commands
+	conf *config.Config
 )
 
 func init() {
-	cobra.OnInitialize(initLog)
-	//rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.topa.yaml)")
+	cobra.OnInitialize(initConfig, initLog)
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.topa.yaml if exists)")
 }
 
 func initConfig() {
-	// Don't forget to read config either from cfgFile or from home directory!
-	if cfgFile != "" {
-		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
-	} else {
+	if cfgFile == "" {
 		// Find home directory.
 		home, err := homedir.Dir()
 		if err != nil {
@@ -67,20 +72,18 @@ func initConfig() {
 			os.Exit(1)
 		}
 
-		// Search config in home directory with name ".topa" (without extension).
-		viper.AddConfigPath(home)
-		viper.SetConfigName(".topa")
+		// Use config file ".topa.yaml" in home directory if exists.
+		if f := filepath.Join(home, ".topa.yaml"); fileExists(f) {
+			cfgFile = f
+		}
 	}
 
-	viper.SetEnvPrefix("TOPA")
-	viper.AutomaticEnv()
-
-	if err := viper.ReadInConfig(); err != nil {
+	var err error
+	conf, err = config.Load(cfgFile)
+	if err != nil {
 		fmt.Println("Can't read config:", err)
 		os.Exit(1)
 	}
-
-	viper.Debug()
 }
 
 var (
@@ -88,21 +91,19 @@ var (
 )
 
 func initLog() {
-	loggings := viper.GetStringMap("logging")
-	for module, levelInterface := range loggings {
-		levelstring, ok := levelInterface.(string)
-		if !ok {
-			continue
-		}
+	topalogging.SetLevels(conf.Logging)
 
-		level, err := logging.LogLevel(levelstring)
-		if err != nil {
-			logger.Warningf("invalid log level: %s", levelstring)
-			continue
-		}
+	logger.Info("inited log")
+}
 
-		logging.SetLevel(level, module)
+func initCrypto() {
+	if err := crypto.Init(conf.Common.Crypto); err != nil {
+		logger.Errorf("init crypto error: %s", err)
+		os.Exit(1)
 	}
+}
 
-	logger.Info("inited log")
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
 }
@@ -0,0 +1,225 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package comm
+
+import (
+	"context"
+	"time"
+
+	"github.com/mintzhao/topachain/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// defaultMaxMsgSize is the gRPC default receive limit, messages are never limited below it
+	defaultMaxMsgSize = 4 << 20
+
+	// msgOverhead is the room left beside a block in a message, for its envelope and the other fields
+	msgOverhead = 1 << 20
+
+	// minKeepaliveTime is the most frequent ping servers permit, gRPC clients never ping more often
+	minKeepaliveTime = 10 * time.Second
+
+	// defaultDialTimeout bounds the blocking dial of clients not configuring one
+	defaultDialTimeout = 3 * time.Second
+)
+
+// MaxMsgSize returns the message size limit fitting blocks of maxBlockSize bytes, 0 to keep gRPC defaults
+func MaxMsgSize(maxBlockSize uint64) int {
+	if maxBlockSize == 0 {
+		return 0
+	}
+
+	size := maxBlockSize + msgOverhead
+	if size < defaultMaxMsgSize {
+		return defaultMaxMsgSize
+	}
+	if maxInt := uint64(^uint(0) >> 1); size > maxInt {
+		return int(maxInt)
+	}
+
+	return int(size)
+}
+
+// BlockSizeLimit returns the size blocks are limited to, so that they fit the message size limit derived from maxBlockSize.
+// Blocks fit the gRPC default limit if maxBlockSize is 0.
+func BlockSizeLimit(maxBlockSize uint64) uint64 {
+	if maxBlockSize == 0 {
+		return defaultMaxMsgSize - msgOverhead
+	}
+
+	return maxBlockSize
+}
+
+// ServerBuilder builds grpc.Servers tuned by config.GRPC, with interceptors chained in the order added
+type ServerBuilder struct {
+	conf   *config.GRPC
+	tls    *config.TLS
+	unary  []grpc.UnaryServerInterceptor
+	stream []grpc.StreamServerInterceptor
+	opts   []grpc.ServerOption
+}
+
+// NewServerBuilder returns a ServerBuilder tuned by conf, gRPC defaults are kept if conf is nil
+func NewServerBuilder(conf *config.GRPC) *ServerBuilder {
+	if conf == nil {
+		conf = &config.GRPC{}
+	}
+
+	return &ServerBuilder{conf: conf}
+}
+
+// WithTLS secures the server by conf, insecure if conf is nil or disabled
+func (b *ServerBuilder) WithTLS(conf *config.TLS) *ServerBuilder {
+	b.tls = conf
+	return b
+}
+
+// WithUnaryInterceptors appends interceptors to the unary chain, the first added is outermost
+func (b *ServerBuilder) WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) *ServerBuilder {
+	b.unary = append(b.unary, interceptors...)
+	return b
+}
+
+// WithStreamInterceptors appends interceptors to the stream chain, the first added is outermost
+func (b *ServerBuilder) WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) *ServerBuilder {
+	b.stream = append(b.stream, interceptors...)
+	return b
+}
+
+// WithOptions appends raw grpc.ServerOptions, applied after the configured ones
+func (b *ServerBuilder) WithOptions(opts ...grpc.ServerOption) *ServerBuilder {
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+// Build returns a new grpc.Server
+func (b *ServerBuilder) Build() (*grpc.Server, error) {
+	var opts []grpc.ServerOption
+	if b.conf.KeepaliveTime > 0 {
+		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    b.conf.KeepaliveTime,
+			Timeout: b.conf.KeepaliveTimeout,
+		}))
+	}
+	// clients of any keepalive config are permitted to ping, even without active streams
+	opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             minKeepaliveTime,
+		PermitWithoutStream: true,
+	}))
+	if size := MaxMsgSize(b.conf.MaxBlockSize); size > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(size), grpc.MaxSendMsgSize(size))
+	}
+	if len(b.unary) > 0 {
+		opts = append(opts, grpc.UnaryInterceptor(chainUnaryServer(b.unary)))
+	}
+	if len(b.stream) > 0 {
+		opts = append(opts, grpc.StreamInterceptor(chainStreamServer(b.stream)))
+	}
+
+	return NewgRPCServer(b.tls, append(opts, b.opts...)...)
+}
+
+// ClientBuilder dials grpc.ClientConns tuned by config.GRPC, with interceptors chained in the order added
+type ClientBuilder struct {
+	conf   *config.GRPC
+	tls    *config.TLS
+	block  bool
+	unary  []grpc.UnaryClientInterceptor
+	stream []grpc.StreamClientInterceptor
+	opts   []grpc.DialOption
+}
+
+// NewClientBuilder returns a ClientBuilder tuned by conf, dials block for conf.DialTimeout.
+// gRPC defaults are kept if conf is nil, dials block for 3 seconds.
+func NewClientBuilder(conf *config.GRPC) *ClientBuilder {
+	if conf == nil {
+		conf = &config.GRPC{}
+	}
+
+	return &ClientBuilder{conf: conf, block: true}
+}
+
+// WithTLS secures the connections by conf, insecure if conf is nil or disabled
+func (b *ClientBuilder) WithTLS(conf *config.TLS) *ClientBuilder {
+	b.tls = conf
+	return b
+}
+
+// WithNonBlocking has Dial return at once, connecting in background
+func (b *ClientBuilder) WithNonBlocking() *ClientBuilder {
+	b.block = false
+	return b
+}
+
+// WithUnaryInterceptors appends interceptors to the unary chain, the first added is outermost
+func (b *ClientBuilder) WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) *ClientBuilder {
+	b.unary = append(b.unary, interceptors...)
+	return b
+}
+
+// WithStreamInterceptors appends interceptors to the stream chain, the first added is outermost
+func (b *ClientBuilder) WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) *ClientBuilder {
+	b.stream = append(b.stream, interceptors...)
+	return b
+}
+
+// WithOptions appends raw grpc.DialOptions, applied after the configured ones
+func (b *ClientBuilder) WithOptions(opts ...grpc.DialOption) *ClientBuilder {
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+// Dial returns a new grpc.ClientConn to address
+func (b *ClientBuilder) Dial(address string) (*grpc.ClientConn, error) {
+	security, err := DialSecurity(b.tls)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{security}
+	if b.conf.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                b.conf.KeepaliveTime,
+			Timeout:             b.conf.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+	if size := MaxMsgSize(b.conf.MaxBlockSize); size > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(size), grpc.MaxCallSendMsgSize(size)))
+	}
+	if b.conf.MaxBackoff > 0 {
+		opts = append(opts, grpc.WithBackoffMaxDelay(b.conf.MaxBackoff))
+	}
+	if len(b.unary) > 0 {
+		opts = append(opts, grpc.WithUnaryInterceptor(chainUnaryClient(b.unary)))
+	}
+	if len(b.stream) > 0 {
+		opts = append(opts, grpc.WithStreamInterceptor(chainStreamClient(b.stream)))
+	}
+	opts = append(opts, b.opts...)
+
+	if !b.block {
+		return grpc.Dial(address, opts...)
+	}
+
+	timeout := b.conf.DialTimeout
+	if timeout <= 0 {
+		timeout = defaultDialTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return grpc.DialContext(ctx, address, append(opts, grpc.WithBlock())...)
+}
@@ -0,0 +1,155 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package comm
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mintzhao/topachain/config"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// registrar is an ApplicationServer answering Register by its register func
+type registrar struct {
+	register func(ctx context.Context, meta *types.AppMetadata) error
+}
+
+func (r *registrar) Register(ctx context.Context, meta *types.AppMetadata) (*types.Empty, error) {
+	if err := r.register(ctx, meta); err != nil {
+		return nil, err
+	}
+
+	return &types.Empty{}, nil
+}
+
+func (r *registrar) AppStream(stream types.Application_AppStreamServer) error {
+	return nil
+}
+
+// serveBuilder serves r by the server b builds at a free address
+func serveBuilder(t *testing.T, b *ServerBuilder, r *registrar) (*grpc.Server, string) {
+	server, err := b.Build()
+	assert.NoError(t, err)
+	types.RegisterApplicationServer(server, r)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go server.Serve(lis)
+
+	return server, lis.Addr().String()
+}
+
+func TestMaxMsgSize(t *testing.T) {
+	assert.Equal(t, 0, MaxMsgSize(0))
+	assert.Equal(t, defaultMaxMsgSize, MaxMsgSize(1024))
+	assert.Equal(t, 16<<20+msgOverhead, MaxMsgSize(16<<20))
+
+	// blocks always fit the message size limit
+	assert.Equal(t, uint64(defaultMaxMsgSize-msgOverhead), BlockSizeLimit(0))
+	assert.Equal(t, uint64(16<<20), BlockSizeLimit(16<<20))
+}
+
+func TestBuilder_Interceptors(t *testing.T) {
+	var order []string
+	trace := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			order = append(order, name)
+			return handler(ctx, req)
+		}
+	}
+
+	requestIDs := make(chan string, 1)
+	b := NewServerBuilder(config.Defaults().Node.GRPC).
+		WithUnaryInterceptors(RecoveryUnaryServer, RequestIDUnaryServer, LoggingUnaryServer, trace("first"), trace("second")).
+		WithUnaryInterceptors(AuthUnaryServer(func(ctx context.Context, method string) error {
+			if RequestID(ctx) == "denied" {
+				return errors.New("denied")
+			}
+			return nil
+		}))
+	server, address := serveBuilder(t, b, &registrar{register: func(ctx context.Context, meta *types.AppMetadata) error {
+		requestIDs <- RequestID(ctx)
+		if meta.GetName() == "panic" {
+			panic("boom")
+		}
+		return nil
+	}})
+	defer server.Stop()
+
+	conn, err := NewClientBuilder(config.Defaults().Node.GRPC).WithUnaryInterceptors(RequestIDUnaryClient).Dial(address)
+	assert.NoError(t, err)
+	defer conn.Close()
+	cli := types.NewApplicationClient(conn)
+
+	// interceptors run in the order added, request ID of client context is carried to the handler
+	_, err = cli.Register(WithRequestID(context.Background(), "req-1"), &types.AppMetadata{Name: "kvset"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, order)
+	assert.Equal(t, "req-1", <-requestIDs)
+
+	// request ID is generated if none
+	_, err = cli.Register(context.Background(), &types.AppMetadata{Name: "kvset"})
+	assert.NoError(t, err)
+	assert.Len(t, <-requestIDs, 16)
+
+	// panic of handler is recovered
+	_, err = cli.Register(context.Background(), &types.AppMetadata{Name: "panic"})
+	<-requestIDs
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// call refused by auth never reaches handler
+	_, err = cli.Register(WithRequestID(context.Background(), "denied"), &types.AppMetadata{Name: "kvset"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Empty(t, requestIDs)
+}
+
+func TestBuilder_MaxMsgSize(t *testing.T) {
+	conf := &config.GRPC{MaxBlockSize: 1024}
+	server, address := serveBuilder(t, NewServerBuilder(conf), &registrar{register: func(ctx context.Context, meta *types.AppMetadata) error {
+		return nil
+	}})
+	defer server.Stop()
+
+	conn, err := NewClientBuilder(nil).WithNonBlocking().Dial(address)
+	assert.NoError(t, err)
+	defer conn.Close()
+	cli := types.NewApplicationClient(conn)
+
+	_, err = cli.Register(context.Background(), &types.AppMetadata{Name: strings.Repeat("a", defaultMaxMsgSize/2)})
+	assert.NoError(t, err)
+
+	// message exceeding the limit derived from block size is refused
+	_, err = cli.Register(context.Background(), &types.AppMetadata{Name: strings.Repeat("a", defaultMaxMsgSize+1)})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestBuilder_DialTimeout(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := lis.Addr().String()
+	lis.Close()
+
+	start := time.Now()
+	_, err = NewClientBuilder(&config.GRPC{DialTimeout: 100 * time.Millisecond}).Dial(address)
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
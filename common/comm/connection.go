@@ -13,24 +13,44 @@
 package comm
 
 import (
-	"context"
-	"time"
-
+	"github.com/mintzhao/topachain/config"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // NewgRPCClient Returns a new grpc.ClientConn.
 func NewgRPCClient(address string) (*grpc.ClientConn, error) {
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
-	opts = append(opts, grpc.WithBlock())
+	return NewgRPCClientTLS(address, nil)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
-	defer cancel()
+// NewgRPCClientTLS returns a new grpc.ClientConn secured by conf, insecure if conf is nil or disabled
+func NewgRPCClientTLS(address string, conf *config.TLS) (*grpc.ClientConn, error) {
+	return NewClientBuilder(nil).WithTLS(conf).Dial(address)
+}
+
+// DialSecurity returns the dial option securing connections by conf, insecure if conf is nil or disabled
+func DialSecurity(conf *config.TLS) (grpc.DialOption, error) {
+	if conf == nil || !conf.Enabled {
+		return grpc.WithInsecure(), nil
+	}
 
-	conn, err := grpc.DialContext(ctx, address, opts...)
+	tlsConfig, err := NewClientTLSConfig(conf)
 	if err != nil {
 		return nil, err
 	}
-	return conn, err
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
+
+// NewgRPCServer returns a new grpc.Server secured by conf, insecure if conf is nil or disabled
+func NewgRPCServer(conf *config.TLS, opts ...grpc.ServerOption) (*grpc.Server, error) {
+	if conf != nil && conf.Enabled {
+		tlsConfig, err := NewServerTLSConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	return grpc.NewServer(opts...), nil
 }
@@ -0,0 +1,256 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package comm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"runtime/debug"
+	"time"
+
+	"github.com/op/go-logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RequestIDKey is the metadata key request IDs carried under
+	RequestIDKey = "x-request-id"
+)
+
+var (
+	// logger
+	logger = logging.MustGetLogger("comm")
+)
+
+// requestIDKey is the context key of request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying request ID id, sent by RequestIDUnaryClient and RequestIDStreamClient
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID ctx carries, empty if none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// newRequestID returns a random request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// incomingRequestID returns ctx carrying the request ID client sent, a new one if none
+func incomingRequestID(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 && ids[0] != "" {
+			return WithRequestID(ctx, ids[0])
+		}
+	}
+
+	return WithRequestID(ctx, newRequestID())
+}
+
+// outgoingRequestID returns ctx sending its request ID, a new one if none
+func outgoingRequestID(ctx context.Context) context.Context {
+	id := RequestID(ctx)
+	if id == "" {
+		id = newRequestID()
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+}
+
+// serverStream is a grpc.ServerStream of a replaced context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *serverStream) Context() context.Context {
+	return ss.ctx
+}
+
+// RequestIDUnaryServer tags the context of unary calls with the request ID client sent, or a new one
+func RequestIDUnaryServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(incomingRequestID(ctx), req)
+}
+
+// RequestIDStreamServer tags the context of streams with the request ID client sent, or a new one
+func RequestIDStreamServer(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: incomingRequestID(ss.Context())})
+}
+
+// RequestIDUnaryClient sends the request ID of ctx with unary calls, a new one if none
+func RequestIDUnaryClient(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+}
+
+// RequestIDStreamClient sends the request ID of ctx with streams, a new one if none
+func RequestIDStreamClient(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+}
+
+// LoggingUnaryServer logs unary calls with their duration, failed ones as warnings
+func LoggingUnaryServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(ctx, info.FullMethod, start, err)
+
+	return resp, err
+}
+
+// LoggingStreamServer logs streams with their duration once ended, failed ones as warnings
+func LoggingStreamServer(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logCall(ss.Context(), info.FullMethod, start, err)
+
+	return err
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	if err != nil {
+		logger.Warningf("%s request %s failed in %s: %s", method, RequestID(ctx), time.Since(start), err)
+		return
+	}
+
+	logger.Debugf("%s request %s finished in %s", method, RequestID(ctx), time.Since(start))
+}
+
+// RecoveryUnaryServer turns panics of unary handlers into Internal errors
+func RecoveryUnaryServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(info.FullMethod, r)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// RecoveryStreamServer turns panics of stream handlers into Internal errors
+func RecoveryStreamServer(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(info.FullMethod, r)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func recovered(method string, r interface{}) error {
+	logger.Errorf("%s panic: %v\n%s", method, r, debug.Stack())
+	return status.Errorf(codes.Internal, "%s panic: %v", method, r)
+}
+
+// AuthFunc authorizes the call of method, e.g. by PeerCertificate or metadata of ctx
+type AuthFunc func(ctx context.Context, method string) error
+
+// AuthUnaryServer rejects the unary calls auth refuses, errors without a gRPC status are PermissionDenied
+func AuthUnaryServer(auth AuthFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := auth(ctx, info.FullMethod); err != nil {
+			return nil, permissionDenied(err)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamServer rejects the streams auth refuses, errors without a gRPC status are PermissionDenied
+func AuthStreamServer(auth AuthFunc) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := auth(ss.Context(), info.FullMethod); err != nil {
+			return permissionDenied(err)
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func permissionDenied(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
+// chainUnaryServer chains interceptors into one, the first is outermost
+func chainUnaryServer(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+
+		return next(ctx, req)
+	}
+}
+
+// chainStreamServer chains interceptors into one, the first is outermost
+func chainStreamServer(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+
+		return next(srv, ss)
+	}
+}
+
+// chainUnaryClient chains interceptors into one, the first is outermost
+func chainUnaryClient(interceptors []grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		next := invoker
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, inner, opts...)
+			}
+		}
+
+		return next(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// chainStreamClient chains interceptors into one, the first is outermost
+func chainStreamClient(interceptors []grpc.StreamClientInterceptor) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		next := streamer
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return interceptor(ctx, desc, cc, method, inner, opts...)
+			}
+		}
+
+		return next(ctx, desc, cc, method, opts...)
+	}
+}
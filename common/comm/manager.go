@@ -0,0 +1,431 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package comm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultHealthCheckInterval is how often connections are pinged if not configured
+	defaultHealthCheckInterval = 10 * time.Second
+
+	// maxPingFailures is how many pings in a row may fail before a connection is torn down and redialed
+	maxPingFailures = 3
+)
+
+var (
+	// ErrConnManagerClosed means the connection manager is closed
+	ErrConnManagerClosed = errors.New("connection manager closed")
+)
+
+// ConnState reports the state of a managed connection
+type ConnState struct {
+	Address string
+	State   connectivity.State
+
+	// Healthy tells whether the last Ping was answered
+	Healthy bool
+}
+
+// ConnManager shares one grpc.ClientConn per address among its users. Connections are pinged periodically,
+// redialed at once when found unhealthy, replaced by new ones after maxPingFailures failed pings,
+// and closed once unused for config.GRPC.IdleTimeout.
+type ConnManager struct {
+	builder  *ClientBuilder
+	interval time.Duration
+	idle     time.Duration
+
+	mutex    sync.Mutex
+	conns    map[string]*managedConn
+	retired  map[*managedConn]struct{}
+	watchers []func(ConnState)
+	closed   bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// managedConn is a connection and its users
+type managedConn struct {
+	address  string
+	conn     *grpc.ClientConn
+	refs     int
+	lastUsed time.Time
+	healthy  bool
+	cancel   context.CancelFunc
+
+	// replaced tells a new connection to address took over
+	replaced bool
+}
+
+// Conn is a connection got from ConnManager.Get, released by ConnManager.Release
+type Conn struct {
+	*grpc.ClientConn
+
+	mc       *managedConn
+	released bool
+}
+
+// NewConnManager returns a ConnManager dialing by builder, a default ClientBuilder if nil.
+// Health check interval and idle timeout are taken from the config of builder, connections are never closed as idle if no idle timeout.
+func NewConnManager(builder *ClientBuilder) *ConnManager {
+	if builder == nil {
+		builder = NewClientBuilder(nil)
+	}
+
+	cm := &ConnManager{
+		builder:  builder,
+		interval: builder.conf.HealthCheckInterval,
+		idle:     builder.conf.IdleTimeout,
+		conns:    make(map[string]*managedConn),
+		retired:  make(map[*managedConn]struct{}),
+		done:     make(chan struct{}),
+	}
+	if cm.interval <= 0 {
+		cm.interval = defaultHealthCheckInterval
+	}
+
+	if cm.idle > 0 {
+		cm.wg.Add(1)
+		go func() {
+			defer cm.wg.Done()
+			cm.closeIdle()
+		}()
+	}
+
+	return cm
+}
+
+// Get returns the connection to address, dialing it if none. Caller must Release it once done.
+func (cm *ConnManager) Get(address string) (*Conn, error) {
+	if c, err := cm.acquire(address); c != nil || err != nil {
+		return c, err
+	}
+
+	conn, err := cm.builder.Dial(address)
+	if err != nil {
+		return nil, err
+	}
+
+	cm.mutex.Lock()
+	if cm.closed {
+		cm.mutex.Unlock()
+		conn.Close()
+		return nil, ErrConnManagerClosed
+	}
+	// dialed concurrently by another user
+	if mc, ok := cm.conns[address]; ok {
+		mc.refs++
+		mc.lastUsed = time.Now()
+		cm.mutex.Unlock()
+		conn.Close()
+		return &Conn{ClientConn: mc.conn, mc: mc}, nil
+	}
+
+	mc := cm.manage(address, conn)
+	mc.refs = 1
+	cm.mutex.Unlock()
+
+	logger.Debugf("connection to %s opened", address)
+	return &Conn{ClientConn: conn, mc: mc}, nil
+}
+
+// manage adds conn to address to cm.conns and starts watching it, cm.mutex must be held
+func (cm *ConnManager) manage(address string, conn *grpc.ClientConn) *managedConn {
+	ctx, cancel := context.WithCancel(context.Background())
+	mc := &managedConn{
+		address:  address,
+		conn:     conn,
+		lastUsed: time.Now(),
+		healthy:  true,
+		cancel:   cancel,
+	}
+	cm.conns[address] = mc
+
+	cm.wg.Add(2)
+	go func() {
+		defer cm.wg.Done()
+		cm.watchState(mc)
+	}()
+	go func() {
+		defer cm.wg.Done()
+		cm.checkHealth(ctx, mc)
+	}()
+
+	return mc
+}
+
+// acquire returns the existing connection to address, redialing it at once if not ready, nil if none
+func (cm *ConnManager) acquire(address string) (*Conn, error) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	if cm.closed {
+		return nil, ErrConnManagerClosed
+	}
+
+	mc, ok := cm.conns[address]
+	if !ok {
+		return nil, nil
+	}
+
+	mc.refs++
+	mc.lastUsed = time.Now()
+	if mc.conn.GetState() != connectivity.Ready {
+		mc.conn.ResetConnectBackoff()
+	}
+
+	return &Conn{ClientConn: mc.conn, mc: mc}, nil
+}
+
+// Release tells conn got by Get is no longer used by caller. Releasing it again is a no-op,
+// so is releasing it after it's been replaced, except the replaced one is closed once released by all its users.
+func (cm *ConnManager) Release(conn *Conn) {
+	cm.mutex.Lock()
+	if conn.released {
+		cm.mutex.Unlock()
+		return
+	}
+	conn.released = true
+
+	mc := conn.mc
+	mc.refs--
+	mc.lastUsed = time.Now()
+
+	_, retired := cm.retired[mc]
+	closing := retired && mc.refs == 0
+	if closing {
+		delete(cm.retired, mc)
+	}
+	cm.mutex.Unlock()
+
+	if closing {
+		cm.closeConn(mc)
+	}
+}
+
+// Watch has fn called on every state change of the managed connections, including health changes
+func (cm *ConnManager) Watch(fn func(ConnState)) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	cm.watchers = append(cm.watchers, fn)
+}
+
+// State returns the state of the connection to address, false if not managed
+func (cm *ConnManager) State(address string) (ConnState, bool) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	mc, ok := cm.conns[address]
+	if !ok {
+		return ConnState{}, false
+	}
+
+	return ConnState{Address: address, State: mc.conn.GetState(), Healthy: mc.healthy}, true
+}
+
+// Close closes all the connections, Get fails afterwards
+func (cm *ConnManager) Close() {
+	cm.mutex.Lock()
+	if cm.closed {
+		cm.mutex.Unlock()
+		return
+	}
+	cm.closed = true
+	close(cm.done)
+
+	conns := cm.conns
+	cm.conns = make(map[string]*managedConn)
+	retired := cm.retired
+	cm.retired = make(map[*managedConn]struct{})
+	cm.mutex.Unlock()
+
+	for _, mc := range conns {
+		cm.closeConn(mc)
+	}
+	for mc := range retired {
+		cm.closeConn(mc)
+	}
+	cm.wg.Wait()
+}
+
+// closeConn closes mc removed from cm.conns
+func (cm *ConnManager) closeConn(mc *managedConn) {
+	mc.cancel()
+	if err := mc.conn.Close(); err != nil {
+		logger.Warningf("close connection to %s error: %s", mc.address, err)
+	}
+
+	logger.Debugf("connection to %s closed", mc.address)
+}
+
+// report calls the watchers with the state of mc, unless it's been replaced
+func (cm *ConnManager) report(mc *managedConn, state connectivity.State) {
+	cm.mutex.Lock()
+	if mc.replaced {
+		cm.mutex.Unlock()
+		return
+	}
+	cs := ConnState{Address: mc.address, State: state, Healthy: mc.healthy}
+	watchers := cm.watchers
+	cm.mutex.Unlock()
+
+	for _, fn := range watchers {
+		fn(cs)
+	}
+}
+
+// watchState reports the connectivity changes of mc until it's closed
+func (cm *ConnManager) watchState(mc *managedConn) {
+	for {
+		state := mc.conn.GetState()
+		cm.report(mc, state)
+		if state == connectivity.Shutdown {
+			return
+		}
+
+		mc.conn.WaitForStateChange(context.Background(), state)
+	}
+}
+
+// checkHealth pings mc every interval until ctx done, redials it at once if the ping fails,
+// replaces it by a new connection after maxPingFailures failures in a row
+func (cm *ConnManager) checkHealth(ctx context.Context, mc *managedConn) {
+	cli := types.NewHealthClient(mc.conn)
+
+	ticker := time.NewTicker(cm.interval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, cm.interval)
+		_, err := cli.Ping(pingCtx, &types.Empty{})
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		// servers without Health service answered all the same
+		healthy := err == nil || status.Code(err) == codes.Unimplemented
+		if healthy {
+			failures = 0
+		} else {
+			logger.Warningf("ping %s error: %s", mc.address, err)
+			mc.conn.ResetConnectBackoff()
+			failures++
+		}
+
+		cm.mutex.Lock()
+		changed := mc.healthy != healthy
+		mc.healthy = healthy
+		cm.mutex.Unlock()
+
+		if changed {
+			cm.report(mc, mc.conn.GetState())
+		}
+
+		if failures >= maxPingFailures {
+			cm.redial(mc)
+			return
+		}
+	}
+}
+
+// redial replaces mc in cm.conns by a new connection to its address, mc is closed once released by all its users
+func (cm *ConnManager) redial(mc *managedConn) {
+	logger.Warningf("connection to %s unhealthy, redialing", mc.address)
+	conn, err := cm.builder.Dial(mc.address)
+
+	cm.mutex.Lock()
+	if cm.closed || cm.conns[mc.address] != mc {
+		cm.mutex.Unlock()
+		if err == nil {
+			conn.Close()
+		}
+		return
+	}
+
+	delete(cm.conns, mc.address)
+	mc.replaced = true
+	if err != nil {
+		logger.Warningf("redial %s error: %s", mc.address, err)
+	} else {
+		cm.manage(mc.address, conn)
+	}
+
+	idle := mc.refs == 0
+	if !idle {
+		cm.retired[mc] = struct{}{}
+	}
+	cm.mutex.Unlock()
+
+	if idle {
+		cm.closeConn(mc)
+	}
+}
+
+// closeIdle closes the connections unused for cm.idle, until cm closed
+func (cm *ConnManager) closeIdle() {
+	ticker := time.NewTicker(cm.idle / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-cm.done:
+			return
+		}
+
+		var idle []*managedConn
+		cm.mutex.Lock()
+		for address, mc := range cm.conns {
+			if mc.refs == 0 && time.Since(mc.lastUsed) >= cm.idle {
+				delete(cm.conns, address)
+				idle = append(idle, mc)
+			}
+		}
+		cm.mutex.Unlock()
+
+		for _, mc := range idle {
+			cm.closeConn(mc)
+		}
+	}
+}
+
+type healthapi struct{}
+
+// NewHealthServer returns a types.HealthServer answering pings of ConnManagers
+func NewHealthServer() types.HealthServer {
+	return &healthapi{}
+}
+
+func (api *healthapi) Ping(ctx context.Context, req *types.Empty) (*types.Empty, error) {
+	return &types.Empty{}, nil
+}
@@ -0,0 +1,185 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package comm
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mintzhao/topachain/config"
+	"github.com/mintzhao/topachain/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// serveHealth serves a Health server at address, a free one if empty
+func serveHealth(t *testing.T, address string) (*grpc.Server, string) {
+	if address == "" {
+		address = "127.0.0.1:0"
+	}
+
+	server := grpc.NewServer()
+	types.RegisterHealthServer(server, NewHealthServer())
+
+	lis, err := net.Listen("tcp", address)
+	assert.NoError(t, err)
+	go server.Serve(lis)
+
+	return server, lis.Addr().String()
+}
+
+// waitState waits the state of connection to address reported through states satisfies ok
+func waitState(t *testing.T, states <-chan ConnState, address string, ok func(ConnState) bool) {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case cs := <-states:
+			if cs.Address == address && ok(cs) {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("connection to %s not in expected state", address)
+		}
+	}
+}
+
+func TestConnManager_Shared(t *testing.T) {
+	server, address := serveHealth(t, "")
+	defer server.Stop()
+
+	cm := NewConnManager(nil)
+	conn1, err := cm.Get(address)
+	assert.NoError(t, err)
+	conn2, err := cm.Get(address)
+	assert.NoError(t, err)
+	assert.True(t, conn1.ClientConn == conn2.ClientConn)
+
+	cs, ok := cm.State(address)
+	assert.True(t, ok)
+	assert.Equal(t, connectivity.Ready, cs.State)
+	assert.True(t, cs.Healthy)
+
+	_, ok = cm.State("127.0.0.1:1")
+	assert.False(t, ok)
+
+	// connections are closed with manager
+	cm.Close()
+	assert.Equal(t, connectivity.Shutdown, conn1.GetState())
+	_, err = cm.Get(address)
+	assert.Equal(t, ErrConnManagerClosed, err)
+}
+
+func TestConnManager_HealthCheck(t *testing.T) {
+	server, address := serveHealth(t, "")
+
+	cm := NewConnManager(NewClientBuilder(&config.GRPC{HealthCheckInterval: 50 * time.Millisecond, MaxBackoff: time.Minute}))
+	defer cm.Close()
+	states := make(chan ConnState, 64)
+	cm.Watch(func(cs ConnState) {
+		select {
+		case states <- cs:
+		default:
+		}
+	})
+
+	_, err := cm.Get(address)
+	assert.NoError(t, err)
+
+	// lost node is reported unhealthy
+	server.Stop()
+	waitState(t, states, address, func(cs ConnState) bool { return !cs.Healthy })
+
+	// restarted node is redialed at once, despite the long backoff
+	server, _ = serveHealth(t, address)
+	defer server.Stop()
+	waitState(t, states, address, func(cs ConnState) bool { return cs.Healthy && cs.State == connectivity.Ready })
+}
+
+func TestConnManager_CloseIdle(t *testing.T) {
+	server, address := serveHealth(t, "")
+	defer server.Stop()
+
+	cm := NewConnManager(NewClientBuilder(&config.GRPC{IdleTimeout: 100 * time.Millisecond}))
+	defer cm.Close()
+	states := make(chan ConnState, 64)
+	cm.Watch(func(cs ConnState) {
+		select {
+		case states <- cs:
+		default:
+		}
+	})
+
+	conn, err := cm.Get(address)
+	assert.NoError(t, err)
+
+	// used connection is kept
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, connectivity.Ready, conn.GetState())
+
+	// released one is closed once idle, next Get dials a new one
+	cm.Release(conn)
+	waitState(t, states, address, func(cs ConnState) bool { return cs.State == connectivity.Shutdown })
+	_, ok := cm.State(address)
+	assert.False(t, ok)
+
+	conn2, err := cm.Get(address)
+	assert.NoError(t, err)
+	assert.False(t, conn.ClientConn == conn2.ClientConn)
+	assert.Equal(t, connectivity.Ready, conn2.GetState())
+}
+
+// managed returns the connection cm manages to address, nil if none
+func managed(cm *ConnManager, address string) *managedConn {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	return cm.conns[address]
+}
+
+func TestConnManager_Redial(t *testing.T) {
+	server, address := serveHealth(t, "")
+
+	cm := NewConnManager(NewClientBuilder(&config.GRPC{HealthCheckInterval: 50 * time.Millisecond}))
+	defer cm.Close()
+
+	conn1, err := cm.Get(address)
+	assert.NoError(t, err)
+
+	// connection failing pings in a row is replaced, the old one kept for its user
+	server.Stop()
+	for i := 0; i < 100 && managed(cm, address) == conn1.mc; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.False(t, managed(cm, address) == conn1.mc)
+	assert.NotEqual(t, connectivity.Shutdown, conn1.GetState())
+
+	server, _ = serveHealth(t, address)
+	defer server.Stop()
+	conn2, err := cm.Get(address)
+	assert.NoError(t, err)
+	assert.False(t, conn1.ClientConn == conn2.ClientConn)
+
+	// late release closes the old connection only, releasing twice is a no-op
+	cm.Release(conn1)
+	cm.Release(conn1)
+	assert.Equal(t, connectivity.Shutdown, conn1.GetState())
+
+	mc := managed(cm, address)
+	assert.True(t, mc == conn2.mc)
+	cm.mutex.Lock()
+	assert.Equal(t, 1, mc.refs)
+	cm.mutex.Unlock()
+	assert.NotEqual(t, connectivity.Shutdown, conn2.GetState())
+}
@@ -0,0 +1,180 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package comm
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"io/ioutil"
+	"strings"
+
+	"github.com/mintzhao/topachain/config"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+var (
+	// ErrNoCertificate means a CA bundle holds no certificate
+	ErrNoCertificate = errors.New("no certificate found")
+
+	// ErrCertificateNotPinned means peer certificate isn't one of the pinned ones
+	ErrCertificateNotPinned = errors.New("certificate not pinned")
+
+	// ErrNoPeerCertificate means peer of the connection presented no certificate
+	ErrNoPeerCertificate = errors.New("no peer certificate")
+
+	// ErrPinnedWithoutClientAuth means server pins client certificates it doesn't request
+	ErrPinnedWithoutClientAuth = errors.New("pinned certificates require client auth")
+)
+
+// NewServerTLSConfig returns the tls.Config of a gRPC server configured by conf.
+// Clients must present a certificate signed by conf.ClientRootCAs if conf.ClientAuth,
+// and one of conf.PinnedCerts if given, which requires conf.ClientAuth.
+func NewServerTLSConfig(conf *config.TLS) (*tls.Config, error) {
+	// clients present no certificate to check against the pinned ones unless requested
+	if len(conf.PinnedCerts) > 0 && !conf.ClientAuth {
+		return nil, ErrPinnedWithoutClientAuth
+	}
+
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "load server certificate error")
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if conf.ClientAuth {
+		if tlsConfig.ClientCAs, err = loadCertPool(conf.ClientRootCAs); err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	if len(conf.PinnedCerts) > 0 {
+		if tlsConfig.VerifyPeerCertificate, err = verifyPinned(conf.PinnedCerts); err != nil {
+			return nil, err
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// NewClientTLSConfig returns the tls.Config of a gRPC client configured by conf,
+// the client certificate is presented only if conf.CertFile given
+func NewClientTLSConfig(conf *config.TLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: conf.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if conf.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load client certificate error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	var err error
+	if len(conf.RootCAs) > 0 {
+		if tlsConfig.RootCAs, err = loadCertPool(conf.RootCAs); err != nil {
+			return nil, err
+		}
+	}
+	if len(conf.PinnedCerts) > 0 {
+		if tlsConfig.VerifyPeerCertificate, err = verifyPinned(conf.PinnedCerts); err != nil {
+			return nil, err
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// PeerCertificate returns the verified certificate the peer of a gRPC call presented,
+// ErrNoPeerCertificate if the connection isn't TLS or peer presented none
+func PeerCertificate(ctx context.Context) (*x509.Certificate, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, ErrNoPeerCertificate
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, ErrNoPeerCertificate
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0], nil
+}
+
+// CertificateNames returns the names certificate is issued for, its common name followed by DNS names
+func CertificateNames(cert *x509.Certificate) []string {
+	names := make([]string, 0, len(cert.DNSNames)+1)
+	if cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+
+	return append(names, cert.DNSNames...)
+}
+
+// Fingerprint returns the hex encoded SHA-256 of the DER encoded cert, as pinned in config
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// loadCertPool loads the PEM encoded CA bundles of files into a cert pool
+func loadCertPool(files []string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	for _, file := range files {
+		pemBytes, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "read CA bundle error")
+		}
+
+		if !pool.AppendCertsFromPEM(pemBytes) {
+			return nil, errors.Wrapf(ErrNoCertificate, "CA bundle %s", file)
+		}
+	}
+
+	return pool, nil
+}
+
+// verifyPinned returns a tls.Config.VerifyPeerCertificate accepting only the leaf certificates of fingerprints
+func verifyPinned(fingerprints []string) (func([][]byte, [][]*x509.Certificate) error, error) {
+	pinned := make(map[string]bool, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		b, err := hex.DecodeString(fingerprint)
+		if err != nil || len(b) != sha256.Size {
+			return nil, errors.Errorf("invalid pinned certificate fingerprint %q", fingerprint)
+		}
+		pinned[strings.ToLower(fingerprint)] = true
+	}
+
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return ErrNoPeerCertificate
+		}
+
+		sum := sha256.Sum256(rawCerts[0])
+		if !pinned[hex.EncodeToString(sum[:])] {
+			return ErrCertificateNotPinned
+		}
+
+		return nil
+	}, nil
+}
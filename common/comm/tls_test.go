@@ -0,0 +1,249 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package comm
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mintzhao/topachain/config"
+	"github.com/mintzhao/topachain/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// testCert is a certificate and its key written as PEM files
+type testCert struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+// newTestCert issues a certificate of cn into dir, signed by parent or self-signed CA if parent is nil
+func newTestCert(t *testing.T, dir, cn string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{cn},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	tc := &testCert{
+		cert:     cert,
+		key:      key,
+		certFile: filepath.Join(dir, cn+".crt"),
+		keyFile:  filepath.Join(dir, cn+".key"),
+	}
+	assert.NoError(t, ioutil.WriteFile(tc.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, ioutil.WriteFile(tc.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	return tc
+}
+
+// peerNames is an ApplicationServer answering Register with the names of the peer certificate
+type peerNames struct {
+	names chan []string
+}
+
+func (pn *peerNames) Register(ctx context.Context, meta *types.AppMetadata) (*types.Empty, error) {
+	cert, err := PeerCertificate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pn.names <- CertificateNames(cert)
+	return &types.Empty{}, nil
+}
+
+func (pn *peerNames) AppStream(stream types.Application_AppStreamServer) error {
+	return nil
+}
+
+// serveTLS serves a peerNames secured by conf at a free address
+func serveTLS(t *testing.T, conf *config.TLS) (*grpc.Server, *peerNames, string) {
+	server, err := NewgRPCServer(conf)
+	assert.NoError(t, err)
+	pn := &peerNames{names: make(chan []string, 1)}
+	types.RegisterApplicationServer(server, pn)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go server.Serve(lis)
+
+	return server, pn, lis.Addr().String()
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "topatls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCert(t, dir, "ca", nil)
+	serverCert := newTestCert(t, dir, "node", ca)
+	clientCert := newTestCert(t, dir, "kvset", ca)
+	otherCA := newTestCert(t, dir, "otherca", nil)
+	strangerCert := newTestCert(t, dir, "stranger", otherCA)
+
+	server, pn, address := serveTLS(t, &config.TLS{
+		Enabled:       true,
+		CertFile:      serverCert.certFile,
+		KeyFile:       serverCert.keyFile,
+		ClientAuth:    true,
+		ClientRootCAs: []string{ca.certFile},
+	})
+	defer server.Stop()
+
+	// client certificate is exposed to handlers
+	conn, err := NewgRPCClientTLS(address, &config.TLS{
+		Enabled:  true,
+		CertFile: clientCert.certFile,
+		KeyFile:  clientCert.keyFile,
+		RootCAs:  []string{ca.certFile},
+	})
+	assert.NoError(t, err)
+	defer conn.Close()
+	_, err = types.NewApplicationClient(conn).Register(context.Background(), &types.AppMetadata{Name: "kvset"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kvset", "kvset"}, <-pn.names)
+
+	// client certificate of another CA is refused, server may verify it after client handshake completes
+	register := func(conn *grpc.ClientConn, err error) error {
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err = types.NewApplicationClient(conn).Register(ctx, &types.AppMetadata{Name: "kvset"})
+		return err
+	}
+	assert.Error(t, register(NewgRPCClientTLS(address, &config.TLS{
+		Enabled:  true,
+		CertFile: strangerCert.certFile,
+		KeyFile:  strangerCert.keyFile,
+		RootCAs:  []string{ca.certFile},
+	})))
+
+	// server certificate of an untrusted CA is refused
+	_, err = NewgRPCClientTLS(address, &config.TLS{
+		Enabled:  true,
+		CertFile: clientCert.certFile,
+		KeyFile:  clientCert.keyFile,
+		RootCAs:  []string{otherCA.certFile},
+	})
+	assert.Error(t, err)
+
+	// insecure client is refused
+	assert.Error(t, register(NewgRPCClient(address)))
+	assert.Empty(t, pn.names)
+}
+
+func TestPinnedCerts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "topatls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCert(t, dir, "ca", nil)
+	serverCert := newTestCert(t, dir, "node", ca)
+	otherCert := newTestCert(t, dir, "other", ca)
+
+	server, _, address := serveTLS(t, &config.TLS{
+		Enabled:  true,
+		CertFile: serverCert.certFile,
+		KeyFile:  serverCert.keyFile,
+	})
+	defer server.Stop()
+
+	// pinned server certificate is accepted
+	conn, err := NewgRPCClientTLS(address, &config.TLS{
+		Enabled:     true,
+		RootCAs:     []string{ca.certFile},
+		PinnedCerts: []string{Fingerprint(serverCert.cert)},
+	})
+	assert.NoError(t, err)
+	conn.Close()
+
+	// certificate signed by the trusted CA but not pinned is refused
+	_, err = NewgRPCClientTLS(address, &config.TLS{
+		Enabled:     true,
+		RootCAs:     []string{ca.certFile},
+		PinnedCerts: []string{Fingerprint(otherCert.cert)},
+	})
+	assert.Error(t, err)
+
+	_, err = NewClientTLSConfig(&config.TLS{Enabled: true, PinnedCerts: []string{"abcd"}})
+	assert.Error(t, err)
+
+	// server can't check pinned certificates of clients it doesn't request
+	_, err = NewServerTLSConfig(&config.TLS{
+		Enabled:     true,
+		CertFile:    serverCert.certFile,
+		KeyFile:     serverCert.keyFile,
+		PinnedCerts: []string{Fingerprint(otherCert.cert)},
+	})
+	assert.Equal(t, ErrPinnedWithoutClientAuth, err)
+}
+
+func TestPeerCertificate(t *testing.T) {
+	server, pn, address := serveTLS(t, nil)
+	defer server.Stop()
+
+	// insecure connection has no peer certificate
+	conn, err := NewgRPCClient(address)
+	assert.NoError(t, err)
+	defer conn.Close()
+	_, err = types.NewApplicationClient(conn).Register(context.Background(), &types.AppMetadata{Name: "kvset"})
+	assert.Error(t, err)
+	assert.Empty(t, pn.names)
+
+	_, err = PeerCertificate(context.Background())
+	assert.Equal(t, ErrNoPeerCertificate, err)
+}
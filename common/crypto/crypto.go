@@ -14,13 +14,15 @@ package crypto
 
 import (
 	"crypto"
+	"hash"
+	"io"
 	"sync"
 
 	"github.com/mintzhao/topachain/common/crypto/hasher"
 	"github.com/mintzhao/topachain/common/crypto/signer"
 	_ "github.com/mintzhao/topachain/common/logging"
+	"github.com/mintzhao/topachain/config"
 	"github.com/op/go-logging"
-	"github.com/spf13/viper"
 )
 
 var logger = logging.MustGetLogger("crypto")
@@ -38,14 +40,14 @@ type cryptoImpl struct {
 }
 
 // NewCrypto return a CryptoInterface instance based on configuration
-func NewCrypto() (CryptoInterface, error) {
-	hashName := getHasherName()
+func NewCrypto(conf *config.Crypto) (CryptoInterface, error) {
+	hashName := getHasherName(conf)
 	her, err := hasher.GetHasher(hashName)
 	if err != nil {
 		return nil, err
 	}
 
-	signerName := getSignerName()
+	signerName := getSignerName(conf)
 	sger, err := signer.GetSigner(signerName)
 	if err != nil {
 		return nil, err
@@ -71,6 +73,26 @@ func Hash(msg []byte, hashName ...string) ([]byte, error) {
 	return getInstance().Hash(msg)
 }
 
+// NewHash is a global function returning a hash.Hash to hash messages piece by piece, e.g. large blocks.
+func NewHash(hashName ...string) (hash.Hash, error) {
+	her, err := getHasher(hashName...)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.NewHash(her)
+}
+
+// HashReader is a global function to hash everything read from r, without holding it in memory if the hasher streams.
+func HashReader(r io.Reader, hashName ...string) ([]byte, error) {
+	her, err := getHasher(hashName...)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.HashReader(her, r)
+}
+
 // Hash hashes messages msg
 func (impl *cryptoImpl) Hash(msg []byte) ([]byte, error) {
 	return impl.her.Hash(msg)
@@ -116,39 +138,68 @@ func (impl *cryptoImpl) Verify(k crypto.PublicKey, signature, msg []byte, opts c
 
 var (
 	instance CryptoInterface
-	once     sync.Once
+	mutex    sync.RWMutex
 )
 
+// Init sets up the instance used by global functions from conf,
+// if never called, the default SHA256 hasher and ECDSA signer are used.
+func Init(conf *config.Crypto) error {
+	impl, err := NewCrypto(conf)
+	if err != nil {
+		return err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	instance = impl
+
+	return nil
+}
+
 func getInstance() CryptoInterface {
-	once.Do(func() {
+	mutex.RLock()
+	impl := instance
+	mutex.RUnlock()
+	if impl != nil {
+		return impl
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if instance == nil {
 		var err error
-		instance, err = NewCrypto()
+		instance, err = NewCrypto(nil)
 		if err != nil {
 			logger.Panic(err)
 		}
-	})
+	}
 
 	return instance
 }
 
-// getHasherName returns customize hash function, default is SHA256
-func getHasherName() string {
-	hasherName := viper.GetString("common.crypto.hash")
+// getHasher returns the registered hasher hashName, the one of the instance if not given
+func getHasher(hashName ...string) (hasher.Hasher, error) {
+	if len(hashName) != 0 {
+		return hasher.GetHasher(hashName[0])
+	}
 
-	if hasherName == "" {
-		hasherName = "SHA256"
+	return getInstance().(*cryptoImpl).her, nil
+}
+
+// getHasherName returns customize hash function, default is SHA256
+func getHasherName(conf *config.Crypto) string {
+	if conf == nil || conf.Hash == "" {
+		return "SHA256"
 	}
 
-	return hasherName
+	return conf.Hash
 }
 
 // getSignerName returns customize signer, default is ECDSA
-func getSignerName() string {
-	signerName := viper.GetString("common.crypto.sign")
-
-	if signerName == "" {
-		signerName = "ECDSA"
+func getSignerName(conf *config.Crypto) string {
+	if conf == nil || conf.Sign == "" {
+		return "ECDSA"
 	}
 
-	return signerName
+	return conf.Sign
 }
@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/mintzhao/topachain/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,6 +43,24 @@ func TestHash(t *testing.T) {
 	assert.EqualValues(t, md5digest, hex.EncodeToString(md5retHash))
 }
 
+func TestHashReader(t *testing.T) {
+	msg := bytes.NewBufferString("this is used for sha256 test").Bytes()
+	digest := "069c725da1725e638c8f1d901d4c4245d8b68cf571bbe445cb7be8709e5b59a2"
+
+	retHash, err := HashReader(bytes.NewReader(msg))
+	assert.NoError(t, err)
+	assert.EqualValues(t, digest, hex.EncodeToString(retHash))
+
+	hf, err := NewHash("md5")
+	assert.NoError(t, err)
+	hf.Write([]byte("this is used "))
+	hf.Write([]byte("for md5 test"))
+	assert.EqualValues(t, "14c4063d61bd57528837784838ea5a79", hex.EncodeToString(hf.Sum(nil)))
+
+	_, err = NewHash("unknown")
+	assert.Error(t, err)
+}
+
 func TestSignVerify(t *testing.T) {
 	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	assert.Nil(t, err)
@@ -77,3 +96,15 @@ func TestSignVerify(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, true, rsaok)
 }
+
+func TestInit(t *testing.T) {
+	assert.Error(t, Init(&config.Crypto{Hash: "unknown"}))
+
+	assert.NoError(t, Init(&config.Crypto{Hash: "MD5"}))
+	md5retHash, err := Hash(bytes.NewBufferString("this is used for md5 test").Bytes())
+	assert.NoError(t, err)
+	assert.EqualValues(t, "14c4063d61bd57528837784838ea5a79", hex.EncodeToString(md5retHash))
+
+	// restore default
+	assert.NoError(t, Init(nil))
+}
@@ -0,0 +1,42 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hasher
+
+import (
+	hash2 "hash"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	blake2b256Pool = newPool(newBLAKE2b256)
+)
+
+// BLAKE2b256Hasher hashes by unkeyed BLAKE2b-256
+type BLAKE2b256Hasher struct {
+}
+
+func (h *BLAKE2b256Hasher) Hash(msg []byte) ([]byte, error) {
+	return blake2b256Pool.hash(msg)
+}
+
+// New returns a hash.Hash streaming messages hashed by h
+func (h *BLAKE2b256Hasher) New() hash2.Hash {
+	return newBLAKE2b256()
+}
+
+// newBLAKE2b256 returns an unkeyed BLAKE2b-256 hash.Hash, which never fails without key
+func newBLAKE2b256() hash2.Hash {
+	hf, _ := blake2b.New256(nil)
+	return hf
+}
@@ -0,0 +1,42 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hasher
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlake2b256Hasher_Hash(t *testing.T) {
+	msg := bytes.NewBufferString("this is used for blake2b test").Bytes()
+	digest := "4f0ed02b916687cbf7fcb5f289cd75a05dbe5f7dae6d257a262bab967b18a0bb"
+
+	rethash, err := (&BLAKE2b256Hasher{}).Hash(msg)
+	assert.NoError(t, err)
+
+	t.Logf("ret hash %x", rethash)
+
+	assert.EqualValues(t, digest, hex.EncodeToString(rethash))
+}
+
+func BenchmarkBlake2b256Hasher_Hash(b *testing.B) {
+	msg := bytes.NewBufferString("this is used for blake2b test").Bytes()
+	hasher := &BLAKE2b256Hasher{}
+
+	for i := 0; i < b.N; i++ {
+		hasher.Hash(msg)
+	}
+}
@@ -14,6 +14,8 @@ package hasher
 
 import (
 	hash2 "hash"
+	"io"
+	"io/ioutil"
 	"strings"
 	"sync"
 
@@ -34,13 +36,32 @@ func init() {
 	// register default hash func
 	RegisterHasher("MD5", &MD5Hasher{})
 	RegisterHasher("SHA256", &SHA256Hasher{})
+	RegisterHasher("SHA512", &SHA512Hasher{})
+	RegisterHasher("SHA3-256", &SHA3256Hasher{})
+	RegisterHasher("KECCAK256", &Keccak256Hasher{})
+	RegisterHasher("BLAKE2B-256", &BLAKE2b256Hasher{})
+	RegisterHasher("SM3", &SM3Hasher{})
 }
 
-// hash hashes messages msg using hf
-func hash(hf hash2.Hash, msg []byte) ([]byte, error) {
-	hf.Reset()
+// pool reuses the hash.Hash instances of one hash function, sparing an allocation per Hash call
+type pool struct {
+	sync.Pool
+}
+
+// newPool returns a pool of hash.Hash created by newHash
+func newPool(newHash func() hash2.Hash) *pool {
+	return &pool{sync.Pool{New: func() interface{} { return newHash() }}}
+}
+
+// hash hashes messages msg using a pooled hash.Hash
+func (p *pool) hash(msg []byte) ([]byte, error) {
+	hf := p.Get().(hash2.Hash)
 	hf.Write(msg)
-	return hf.Sum(nil), nil
+	digest := hf.Sum(nil)
+	hf.Reset()
+	p.Put(hf)
+
+	return digest, nil
 }
 
 // hasher contains hash related functions
@@ -50,6 +71,44 @@ type Hasher interface {
 	Hash(msg []byte) ([]byte, error)
 }
 
+// StreamHasher is a Hasher able to hash messages written piece by piece, e.g. large blocks and snapshot chunks
+type StreamHasher interface {
+	Hasher
+
+	// New returns a hash.Hash giving the same digest as Hash of everything written to it
+	New() hash2.Hash
+}
+
+// NewHash returns a hash.Hash of h, error if h can't stream
+func NewHash(h Hasher) (hash2.Hash, error) {
+	sh, ok := h.(StreamHasher)
+	if !ok {
+		return nil, ErrStreamUnsupported
+	}
+
+	return sh.New(), nil
+}
+
+// HashReader hashes everything read from r by h, streaming if h is a StreamHasher, read into memory otherwise
+func HashReader(h Hasher, r io.Reader) ([]byte, error) {
+	sh, ok := h.(StreamHasher)
+	if !ok {
+		msg, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return h.Hash(msg)
+	}
+
+	hf := sh.New()
+	if _, err := io.Copy(hf, r); err != nil {
+		return nil, err
+	}
+
+	return hf.Sum(nil), nil
+}
+
 // RegisterHasher stores hash function into hashes, if hashName is already registered, return error
 func RegisterHasher(hasherName string, hasher Hasher) error {
 	_, loaded := hashes.LoadOrStore(hasherNameFmt(hasherName), hasher)
@@ -90,4 +149,7 @@ var (
 
 	// ErrHasherAlreadyRegistered is returned when hasher already registered
 	ErrHasherAlreadyRegistered = errors.New("hasher already registered")
+
+	// ErrStreamUnsupported is returned when streaming by a hasher not implementing StreamHasher
+	ErrStreamUnsupported = errors.New("hasher does not support streaming")
 )
@@ -34,4 +34,10 @@ func TestGetHasher(t *testing.T) {
 
 	_, err = GetHasher("sha256")
 	assert.NoError(t, err)
+
+	// names AppConfig.hash and common.crypto.hash select
+	for _, name := range []string{"SHA512", "sha3-256", "Keccak256", "BLAKE2b-256", "sm3"} {
+		_, err = GetHasher(name)
+		assert.NoError(t, err)
+	}
 }
@@ -12,11 +12,23 @@
 // limitations under the License.
 package hasher
 
-import "crypto/md5"
+import (
+	"crypto/md5"
+	hash2 "hash"
+)
+
+var (
+	md5Pool = newPool(md5.New)
+)
 
 type MD5Hasher struct {
 }
 
 func (h *MD5Hasher) Hash(msg []byte) ([]byte, error) {
-	return hash(md5.New(), msg)
+	return md5Pool.hash(msg)
+}
+
+// New returns a hash.Hash streaming messages hashed by h
+func (h *MD5Hasher) New() hash2.Hash {
+	return md5.New()
 }
@@ -12,11 +12,23 @@
 // limitations under the License.
 package hasher
 
-import "crypto/sha256"
+import (
+	"crypto/sha256"
+	hash2 "hash"
+)
+
+var (
+	sha256Pool = newPool(sha256.New)
+)
 
 type SHA256Hasher struct {
 }
 
 func (h *SHA256Hasher) Hash(msg []byte) ([]byte, error) {
-	return hash(sha256.New(), msg)
+	return sha256Pool.hash(msg)
+}
+
+// New returns a hash.Hash streaming messages hashed by h
+func (h *SHA256Hasher) New() hash2.Hash {
+	return sha256.New()
 }
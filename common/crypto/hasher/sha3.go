@@ -0,0 +1,50 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hasher
+
+import (
+	hash2 "hash"
+
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	sha3256Pool   = newPool(sha3.New256)
+	keccak256Pool = newPool(sha3.NewLegacyKeccak256)
+)
+
+// SHA3256Hasher hashes by SHA3-256 of FIPS 202
+type SHA3256Hasher struct {
+}
+
+func (h *SHA3256Hasher) Hash(msg []byte) ([]byte, error) {
+	return sha3256Pool.hash(msg)
+}
+
+// New returns a hash.Hash streaming messages hashed by h
+func (h *SHA3256Hasher) New() hash2.Hash {
+	return sha3.New256()
+}
+
+// Keccak256Hasher hashes by the original Keccak-256, padded differently from SHA3-256, as used by Ethereum
+type Keccak256Hasher struct {
+}
+
+func (h *Keccak256Hasher) Hash(msg []byte) ([]byte, error) {
+	return keccak256Pool.hash(msg)
+}
+
+// New returns a hash.Hash streaming messages hashed by h
+func (h *Keccak256Hasher) New() hash2.Hash {
+	return sha3.NewLegacyKeccak256()
+}
@@ -0,0 +1,58 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hasher
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSha3256Hasher_Hash(t *testing.T) {
+	msg := bytes.NewBufferString("this is used for sha3-256 test").Bytes()
+	digest := "a4ab88f490ac0988c2c0dbfff0f04f43ecd8f22865259eb392d3de53c832a70e"
+
+	rethash, err := (&SHA3256Hasher{}).Hash(msg)
+	assert.NoError(t, err)
+
+	t.Logf("ret hash %x", rethash)
+
+	assert.EqualValues(t, digest, hex.EncodeToString(rethash))
+}
+
+func TestKeccak256Hasher_Hash(t *testing.T) {
+	// Keccak-256("") differs from SHA3-256("") = a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a
+	digest := "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"
+
+	rethash, err := (&Keccak256Hasher{}).Hash(nil)
+	assert.NoError(t, err)
+
+	t.Logf("ret hash %x", rethash)
+
+	assert.EqualValues(t, digest, hex.EncodeToString(rethash))
+
+	sha3hash, err := (&SHA3256Hasher{}).Hash(nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a", hex.EncodeToString(sha3hash))
+}
+
+func BenchmarkSha3256Hasher_Hash(b *testing.B) {
+	msg := bytes.NewBufferString("this is used for sha3-256 test").Bytes()
+	hasher := &SHA3256Hasher{}
+
+	for i := 0; i < b.N; i++ {
+		hasher.Hash(msg)
+	}
+}
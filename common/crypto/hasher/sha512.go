@@ -0,0 +1,34 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hasher
+
+import (
+	"crypto/sha512"
+	hash2 "hash"
+)
+
+var (
+	sha512Pool = newPool(sha512.New)
+)
+
+type SHA512Hasher struct {
+}
+
+func (h *SHA512Hasher) Hash(msg []byte) ([]byte, error) {
+	return sha512Pool.hash(msg)
+}
+
+// New returns a hash.Hash streaming messages hashed by h
+func (h *SHA512Hasher) New() hash2.Hash {
+	return sha512.New()
+}
@@ -0,0 +1,42 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hasher
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSha512Hasher_Hash(t *testing.T) {
+	msg := bytes.NewBufferString("this is used for sha512 test").Bytes()
+	digest := "5485ac99620988feac29af3e6e0e0d10433f7b11849cd4a858dcc21f0054e4be6d029dd41184f7cb3a1eef296e509c4a74e0e966b8e7b2b53ef80c2a82070476"
+
+	rethash, err := (&SHA512Hasher{}).Hash(msg)
+	assert.NoError(t, err)
+
+	t.Logf("ret hash %x", rethash)
+
+	assert.EqualValues(t, digest, hex.EncodeToString(rethash))
+}
+
+func BenchmarkSha512Hasher_Hash(b *testing.B) {
+	msg := bytes.NewBufferString("this is used for sha512 test").Bytes()
+	hasher := &SHA512Hasher{}
+
+	for i := 0; i < b.N; i++ {
+		hasher.Hash(msg)
+	}
+}
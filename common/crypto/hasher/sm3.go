@@ -0,0 +1,36 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hasher
+
+import (
+	hash2 "hash"
+
+	"github.com/mintzhao/topachain/common/crypto/sm3"
+)
+
+var (
+	sm3Pool = newPool(sm3.New)
+)
+
+// SM3Hasher hashes by SM3 of GB/T 32905-2016
+type SM3Hasher struct {
+}
+
+func (h *SM3Hasher) Hash(msg []byte) ([]byte, error) {
+	return sm3Pool.hash(msg)
+}
+
+// New returns a hash.Hash streaming messages hashed by h
+func (h *SM3Hasher) New() hash2.Hash {
+	return sm3.New()
+}
@@ -0,0 +1,42 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hasher
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSM3Hasher_Hash(t *testing.T) {
+	msg := bytes.NewBufferString("this is used for sm3 test").Bytes()
+	digest := "de1849d17b2680208e35f3aad3c4d79fd62d6c0bcb5dbd88e9940900fd3ceeee"
+
+	rethash, err := (&SM3Hasher{}).Hash(msg)
+	assert.NoError(t, err)
+
+	t.Logf("ret hash %x", rethash)
+
+	assert.EqualValues(t, digest, hex.EncodeToString(rethash))
+}
+
+func BenchmarkSM3Hasher_Hash(b *testing.B) {
+	msg := bytes.NewBufferString("this is used for sm3 test").Bytes()
+	hasher := &SM3Hasher{}
+
+	for i := 0; i < b.N; i++ {
+		hasher.Hash(msg)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hasher
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"hash"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memHasher is a Hasher unable to stream
+type memHasher struct {
+}
+
+func (h *memHasher) Hash(msg []byte) ([]byte, error) {
+	return (&SHA256Hasher{}).Hash(msg)
+}
+
+func TestStreamHasher(t *testing.T) {
+	msg := make([]byte, 1<<20+7)
+	rand.Read(msg)
+
+	for _, name := range []string{"MD5", "SHA256", "SHA512", "SHA3-256", "KECCAK256", "BLAKE2B-256", "SM3"} {
+		h, err := GetHasher(name)
+		assert.NoError(t, err)
+
+		digest, err := h.Hash(msg)
+		assert.NoError(t, err)
+
+		// written piece by piece
+		hf, err := NewHash(h)
+		assert.NoError(t, err)
+		for i := 0; i < len(msg); i += 1000 {
+			end := i + 1000
+			if end > len(msg) {
+				end = len(msg)
+			}
+			hf.Write(msg[i:end])
+		}
+		assert.Equal(t, digest, hf.Sum(nil), name)
+
+		retHash, err := HashReader(h, bytes.NewReader(msg))
+		assert.NoError(t, err)
+		assert.Equal(t, digest, retHash, name)
+	}
+
+	// hasher not streaming reads into memory
+	_, err := NewHash(&memHasher{})
+	assert.EqualError(t, err, ErrStreamUnsupported.Error())
+	retHash, err := HashReader(&memHasher{}, bytes.NewReader(msg))
+	assert.NoError(t, err)
+	digest := sha256.Sum256(msg)
+	assert.Equal(t, digest[:], retHash)
+}
+
+func TestPooledHash(t *testing.T) {
+	h := &SHA256Hasher{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			msg := []byte{byte(i)}
+			digest := sha256.Sum256(msg)
+			for j := 0; j < 100; j++ {
+				retHash, err := h.Hash(msg)
+				assert.NoError(t, err)
+				assert.Equal(t, digest[:], retHash)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// merkle tree nodes hash two digests
+var nodeMsg = make([]byte, 2*sha256.Size)
+
+func BenchmarkHash_Pooled(b *testing.B) {
+	h := &SHA256Hasher{}
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		h.Hash(nodeMsg)
+	}
+}
+
+// unpooledHash hashes msg by a new hash.Hash per call, as Hash did before pooling
+func unpooledHash(newHash func() hash.Hash, msg []byte) []byte {
+	hf := newHash()
+	hf.Write(msg)
+	return hf.Sum(nil)
+}
+
+func BenchmarkHash_Unpooled(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		unpooledHash(sha256.New, nodeMsg)
+	}
+}
+
+// snapshot chunks, or large blocks
+var chunk = make([]byte, 16<<20)
+
+func BenchmarkHashReader_Stream(b *testing.B) {
+	h := &SHA256Hasher{}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(chunk)))
+
+	for i := 0; i < b.N; i++ {
+		HashReader(h, bytes.NewReader(chunk))
+	}
+}
+
+func BenchmarkHashReader_Memory(b *testing.B) {
+	h := &memHasher{}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(chunk)))
+
+	for i := 0; i < b.N; i++ {
+		HashReader(h, bytes.NewReader(chunk))
+	}
+}
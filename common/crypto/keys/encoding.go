@@ -0,0 +1,322 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+
+	topacrypto "github.com/mintzhao/topachain/common/crypto"
+	"github.com/mintzhao/topachain/common/crypto/sm2"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+)
+
+const (
+	// PEM block types
+	privateKeyPEMType = "PRIVATE KEY"
+	publicKeyPEMType  = "PUBLIC KEY"
+)
+
+var (
+	// oidEd25519 is the algorithm identifier of ed25519 keys, RFC 8410
+	oidEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+	// oidECPublicKey is the algorithm identifier of elliptic curve keys, RFC 5480
+	oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+	// oidSM2P256 is the named curve sm2p256v1 of SM2 keys, GM/T 0006-2012
+	oidSM2P256 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+)
+
+// ecPrivateKey reflects an ASN.1, SEC 1 ECPrivateKey
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// pkcs8 reflects an ASN.1, PKCS#8 PrivateKeyInfo
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// pkixPublicKey reflects an ASN.1, PKIX SubjectPublicKeyInfo
+type pkixPublicKey struct {
+	Algo      pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// MarshalPrivateKey converts private key k to PKCS#8 DER form
+func MarshalPrivateKey(k crypto.PrivateKey) ([]byte, error) {
+	switch key := k.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		return x509.MarshalPKCS8PrivateKey(key)
+	case ed25519.PrivateKey:
+		seed, err := asn1.Marshal(key[:32])
+		if err != nil {
+			return nil, err
+		}
+
+		return asn1.Marshal(pkcs8{
+			Algo:       pkix.AlgorithmIdentifier{Algorithm: oidEd25519},
+			PrivateKey: seed,
+		})
+	case *sm2.PrivateKey:
+		ecKey, err := asn1.Marshal(ecPrivateKey{
+			Version:    1,
+			PrivateKey: sm2FieldBytes(key.D),
+			PublicKey:  sm2PointBits(&key.PublicKey),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return asn1.Marshal(pkcs8{
+			Algo:       sm2Algorithm(),
+			PrivateKey: ecKey,
+		})
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// UnmarshalPrivateKey parses a PKCS#8 DER form private key
+func UnmarshalPrivateKey(der []byte) (crypto.PrivateKey, error) {
+	var p pkcs8
+	if _, err := asn1.Unmarshal(der, &p); err != nil {
+		return nil, errors.Wrap(err, "unmarshal pkcs8 private key error")
+	}
+
+	if isSM2(p.Algo) {
+		var ecKey ecPrivateKey
+		if _, err := asn1.Unmarshal(p.PrivateKey, &ecKey); err != nil {
+			return nil, errors.Wrap(err, "unmarshal sm2 private key error")
+		}
+
+		return sm2.NewPrivateKey(new(big.Int).SetBytes(ecKey.PrivateKey))
+	}
+	if !p.Algo.Algorithm.Equal(oidEd25519) {
+		return x509.ParsePKCS8PrivateKey(der)
+	}
+
+	var seed []byte
+	if _, err := asn1.Unmarshal(p.PrivateKey, &seed); err != nil {
+		return nil, errors.Wrap(err, "unmarshal ed25519 private key error")
+	}
+	if len(seed) != 32 {
+		return nil, ErrUnsupportedKey
+	}
+
+	return ed25519PrivateKeyFromSeed(seed), nil
+}
+
+// MarshalPublicKey converts public key k to PKIX DER form
+func MarshalPublicKey(k crypto.PublicKey) ([]byte, error) {
+	switch key := k.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return x509.MarshalPKIXPublicKey(key)
+	case ed25519.PublicKey:
+		return asn1.Marshal(pkixPublicKey{
+			Algo:      pkix.AlgorithmIdentifier{Algorithm: oidEd25519},
+			PublicKey: asn1.BitString{Bytes: key, BitLength: 8 * len(key)},
+		})
+	case *sm2.PublicKey:
+		return asn1.Marshal(pkixPublicKey{
+			Algo:      sm2Algorithm(),
+			PublicKey: sm2PointBits(key),
+		})
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// UnmarshalPublicKey parses a PKIX DER form public key
+func UnmarshalPublicKey(der []byte) (crypto.PublicKey, error) {
+	var p pkixPublicKey
+	if _, err := asn1.Unmarshal(der, &p); err != nil {
+		return nil, errors.Wrap(err, "unmarshal pkix public key error")
+	}
+
+	if isSM2(p.Algo) {
+		x, y := elliptic.Unmarshal(sm2.P256(), p.PublicKey.Bytes)
+		if x == nil {
+			return nil, ErrUnsupportedKey
+		}
+
+		return sm2.NewPublicKey(x, y)
+	}
+	if !p.Algo.Algorithm.Equal(oidEd25519) {
+		return x509.ParsePKIXPublicKey(der)
+	}
+
+	if len(p.PublicKey.Bytes) != ed25519.PublicKeySize {
+		return nil, ErrUnsupportedKey
+	}
+
+	return ed25519.PublicKey(p.PublicKey.Bytes), nil
+}
+
+// EncodePrivateKeyPEM converts private key k to PKCS#8 PEM form
+func EncodePrivateKeyPEM(k crypto.PrivateKey) ([]byte, error) {
+	der, err := MarshalPrivateKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: privateKeyPEMType, Bytes: der}), nil
+}
+
+// DecodePrivateKeyPEM parses a PKCS#8 PEM form private key
+func DecodePrivateKeyPEM(data []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != privateKeyPEMType {
+		return nil, ErrInvalidPEM
+	}
+
+	return UnmarshalPrivateKey(block.Bytes)
+}
+
+// EncodePublicKeyPEM converts public key k to PKIX PEM form
+func EncodePublicKeyPEM(k crypto.PublicKey) ([]byte, error) {
+	der, err := MarshalPublicKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: publicKeyPEMType, Bytes: der}), nil
+}
+
+// DecodePublicKeyPEM parses a PKIX PEM form public key
+func DecodePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != publicKeyPEMType {
+		return nil, ErrInvalidPEM
+	}
+
+	return UnmarshalPublicKey(block.Bytes)
+}
+
+// WritePrivateKeyFile writes private key k into file in PEM form, only owner can read it
+func WritePrivateKeyFile(file string, k crypto.PrivateKey) error {
+	data, err := EncodePrivateKeyPEM(k)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+// ReadPrivateKeyFile reads PEM form private key from file
+func ReadPrivateKeyFile(file string) (crypto.PrivateKey, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodePrivateKeyPEM(data)
+}
+
+// WritePublicKeyFile writes public key k into file in PEM form
+func WritePublicKeyFile(file string, k crypto.PublicKey) error {
+	data, err := EncodePublicKeyPEM(k)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+// ReadPublicKeyFile reads PEM form public key from file
+func ReadPublicKeyFile(file string) (crypto.PublicKey, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodePublicKeyPEM(data)
+}
+
+// KeyID derives a stable identifier of public key k, which is the hash of its PKIX DER form.
+// Hash function is the configured one unless hashName given.
+func KeyID(k crypto.PublicKey, hashName ...string) ([]byte, error) {
+	der, err := MarshalPublicKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return topacrypto.Hash(der, hashName...)
+}
+
+// sm2Algorithm returns the algorithm identifier of SM2 keys, elliptic curve keys on sm2p256v1
+func sm2Algorithm() pkix.AlgorithmIdentifier {
+	params, _ := asn1.Marshal(oidSM2P256)
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  oidECPublicKey,
+		Parameters: asn1.RawValue{FullBytes: params},
+	}
+}
+
+// isSM2 tells whether algo identifies SM2 keys
+func isSM2(algo pkix.AlgorithmIdentifier) bool {
+	if !algo.Algorithm.Equal(oidECPublicKey) {
+		return false
+	}
+
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(algo.Parameters.FullBytes, &curve); err != nil {
+		return false
+	}
+
+	return curve.Equal(oidSM2P256)
+}
+
+// sm2FieldBytes returns v as 32 bytes big endian
+func sm2FieldBytes(v *big.Int) []byte {
+	b := make([]byte, 32)
+	vb := v.Bytes()
+	copy(b[32-len(vb):], vb)
+	return b
+}
+
+// sm2PointBits returns the uncompressed point of k as bit string
+func sm2PointBits(k *sm2.PublicKey) asn1.BitString {
+	point := elliptic.Marshal(sm2.P256(), k.X, k.Y)
+	return asn1.BitString{Bytes: point, BitLength: 8 * len(point)}
+}
+
+// ed25519PrivateKeyFromSeed calculates ed25519 private key from its 32 bytes seed
+func ed25519PrivateKeyFromSeed(seed []byte) ed25519.PrivateKey {
+	_, k, _ := ed25519.GenerateKey(&seedReader{seed: seed})
+	return k
+}
+
+// seedReader feeds ed25519.GenerateKey a fixed seed
+type seedReader struct {
+	seed []byte
+}
+
+func (r *seedReader) Read(p []byte) (int, error) {
+	return copy(p, r.seed), nil
+}
@@ -0,0 +1,161 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"sync"
+
+	"github.com/mintzhao/topachain/common/crypto/sm2"
+	_ "github.com/mintzhao/topachain/common/logging"
+	"github.com/op/go-logging"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+)
+
+var (
+	// supported key generators, named after signers
+	generators sync.Map
+
+	// logger
+	logger = logging.MustGetLogger("crypto/keys")
+)
+
+func init() {
+	// register default key generators, one for each default signer
+	RegisterKeyGenerator("RSA", &rsaKeyGenerator{bits: 2048})
+	RegisterKeyGenerator("ECDSA", &ecdsaKeyGenerator{curve: elliptic.P256()})
+	RegisterKeyGenerator("ED25519", &ed25519KeyGenerator{})
+	RegisterKeyGenerator("SM2", &sm2KeyGenerator{})
+}
+
+// KeyGenerator generates private key which can be used by the signer of the same name
+type KeyGenerator interface {
+
+	// GenerateKey generates a new private key
+	GenerateKey() (crypto.PrivateKey, error)
+}
+
+// RegisterKeyGenerator stores key generator into generators, if signerName is already registered, return error
+func RegisterKeyGenerator(signerName string, g KeyGenerator) error {
+	_, loaded := generators.LoadOrStore(signerNameFmt(signerName), g)
+	if loaded {
+		// already registered
+		logger.Warningf("key generator %s already registered", signerName)
+		return ErrKeyGeneratorAlreadyRegistered
+	}
+
+	logger.Debugf("key generator %s registered", signerName)
+	return nil
+}
+
+// DeRegisterKeyGenerator delete key generator from generators, SHOULD ONLY USED IN TEST
+func DeRegisterKeyGenerator(signerName string) {
+	generators.Delete(signerNameFmt(signerName))
+}
+
+// GetKeyGenerator return a key generator that already registered in generators, if not return error
+func GetKeyGenerator(signerName string) (KeyGenerator, error) {
+	g, ok := generators.Load(signerNameFmt(signerName))
+	if !ok {
+		// not found
+		logger.Warningf("key generator %s not found", signerName)
+		return nil, ErrKeyGeneratorNotFound
+	}
+
+	return g.(KeyGenerator), nil
+}
+
+// GenerateKey generates a private key for signer signerName
+func GenerateKey(signerName string) (crypto.PrivateKey, error) {
+	g, err := GetKeyGenerator(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.GenerateKey()
+}
+
+// PublicKey returns the public key corresponding to private key k
+func PublicKey(k crypto.PrivateKey) (crypto.PublicKey, error) {
+	pk, ok := k.(interface {
+		Public() crypto.PublicKey
+	})
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+
+	return pk.Public(), nil
+}
+
+func signerNameFmt(signerName string) string {
+	return strings.ToUpper(strings.TrimSpace(signerName))
+}
+
+type rsaKeyGenerator struct {
+	bits int
+}
+
+// GenerateKey generates a new rsa private key
+func (g *rsaKeyGenerator) GenerateKey() (crypto.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, g.bits)
+}
+
+type ecdsaKeyGenerator struct {
+	curve elliptic.Curve
+}
+
+// GenerateKey generates a new ecdsa private key
+func (g *ecdsaKeyGenerator) GenerateKey() (crypto.PrivateKey, error) {
+	return ecdsa.GenerateKey(g.curve, rand.Reader)
+}
+
+type ed25519KeyGenerator struct {
+}
+
+// GenerateKey generates a new ed25519 private key
+func (g *ed25519KeyGenerator) GenerateKey() (crypto.PrivateKey, error) {
+	_, k, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+type sm2KeyGenerator struct {
+}
+
+// GenerateKey generates a new sm2 private key
+func (g *sm2KeyGenerator) GenerateKey() (crypto.PrivateKey, error) {
+	return sm2.GenerateKey(rand.Reader)
+}
+
+var (
+	// ErrKeyGeneratorAlreadyRegistered indicated a key generator already registered in to generators
+	ErrKeyGeneratorAlreadyRegistered = errors.New("key generator already registered")
+
+	// ErrKeyGeneratorNotFound indicated a key generator can not found in generators
+	ErrKeyGeneratorNotFound = errors.New("key generator not found")
+
+	// ErrUnsupportedKey indicated key type isn't supported
+	ErrUnsupportedKey = errors.New("unsupported key type")
+
+	// ErrInvalidPEM indicated data isn't a valid pem block of expected type
+	ErrInvalidPEM = errors.New("invalid pem block")
+)
@@ -0,0 +1,239 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package keystore
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+
+	"github.com/mintzhao/topachain/common/crypto/keys"
+	"github.com/mintzhao/topachain/common/crypto/sm4"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	cipherAESGCM = "aes-256-gcm"
+	cipherSM4GCM = "sm4-gcm"
+	kdfScrypt    = "scrypt"
+
+	scryptR = 8
+	saltLen = 32
+
+	// maxScryptN and maxScryptWork bound the memory and CPU time the scrypt parameters of key files take,
+	// 1GB and about 8 times of StandardScryptN
+	maxScryptN    = 1 << 20
+	maxScryptWork = 1 << 21
+)
+
+var (
+	// ErrInvalidKDFParams means the scrypt parameters of a key file are out of the bounds
+	ErrInvalidKDFParams = errors.New("invalid kdf params")
+
+	// ciphers maps the cipher names of config to the ones stored in key files
+	ciphers = map[string]string{
+		"AES": cipherAESGCM,
+		"SM4": cipherSM4GCM,
+	}
+
+	// cipherKeyLens are the key lengths derived by scrypt for stored ciphers
+	cipherKeyLens = map[string]int{
+		cipherAESGCM: 32,
+		cipherSM4GCM: sm4.KeySize,
+	}
+)
+
+// encryptedKey is the on-disk form of a stored key
+type encryptedKey struct {
+	ID        string     `json:"id"`
+	Signer    string     `json:"signer"`
+	PublicKey string     `json:"publicKey"`
+	Crypto    cryptoJSON `json:"crypto"`
+}
+
+type cryptoJSON struct {
+	Cipher     string     `json:"cipher"`
+	CipherText string     `json:"ciphertext"`
+	Nonce      string     `json:"nonce"`
+	KDF        string     `json:"kdf"`
+	KDFParams  scryptJSON `json:"kdfparams"`
+}
+
+type scryptJSON struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// encryptKey encrypts PKCS#8 form of k with GCM of cipherName, whose key is derived from passphrase by scrypt.
+// Key id and signer name are authenticated as additional data, binding ciphertext to them.
+func encryptKey(id, signerName string, k crypto.PrivateKey, passphrase, cipherName string, scryptN, scryptP int) (*encryptedKey, error) {
+	dkLen, ok := cipherKeyLens[cipherName]
+	if !ok {
+		return nil, errors.Errorf("unsupported cipher %s", cipherName)
+	}
+	// keys are never stored with parameters they can't be read with
+	if err := checkScryptParams(scryptJSON{N: scryptN, R: scryptR, P: scryptP, DKLen: dkLen}, dkLen); err != nil {
+		return nil, err
+	}
+
+	der, err := keys.MarshalPrivateKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := keys.PublicKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	pubPEM, err := keys.EncodePublicKeyPEM(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, dkLen)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newGCM(cipherName, derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return &encryptedKey{
+		ID:        id,
+		Signer:    signerName,
+		PublicKey: string(pubPEM),
+		Crypto: cryptoJSON{
+			Cipher:     cipherName,
+			CipherText: hex.EncodeToString(aead.Seal(nil, nonce, der, additionalData(id, signerName))),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        kdfScrypt,
+			KDFParams: scryptJSON{
+				N:     scryptN,
+				R:     scryptR,
+				P:     scryptP,
+				DKLen: dkLen,
+				Salt:  hex.EncodeToString(salt),
+			},
+		},
+	}, nil
+}
+
+// decryptKey decrypts ek by passphrase, the scrypt parameters read from file are bounded before deriving
+func decryptKey(ek *encryptedKey, passphrase string) (crypto.PrivateKey, error) {
+	dkLen, ok := cipherKeyLens[ek.Crypto.Cipher]
+	if !ok || ek.Crypto.KDF != kdfScrypt {
+		return nil, errors.Errorf("unsupported cipher %s or kdf %s", ek.Crypto.Cipher, ek.Crypto.KDF)
+	}
+
+	params := ek.Crypto.KDFParams
+	if err := checkScryptParams(params, dkLen); err != nil {
+		return nil, err
+	}
+
+	salt, err := hex.DecodeString(ek.Crypto.KDFParams.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := hex.DecodeString(ek.Crypto.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(ek.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newGCM(ek.Crypto.Cipher, derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	der, err := aead.Open(nil, nonce, cipherText, additionalData(ek.ID, ek.Signer))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	return keys.UnmarshalPrivateKey(der)
+}
+
+// checkScryptParams checks scrypt parameters read from a key file are within the bounds, deriving dkLen bytes
+func checkScryptParams(params scryptJSON, dkLen int) error {
+	n, p := params.N, params.P
+	if n < 2 || n > maxScryptN || n&(n-1) != 0 {
+		return errors.Wrapf(ErrInvalidKDFParams, "n %d", n)
+	}
+	if params.R != scryptR {
+		return errors.Wrapf(ErrInvalidKDFParams, "r %d", params.R)
+	}
+	if p < 1 || p > maxScryptWork/n {
+		return errors.Wrapf(ErrInvalidKDFParams, "p %d of n %d", p, n)
+	}
+	if params.DKLen != dkLen {
+		return errors.Wrapf(ErrInvalidKDFParams, "dklen %d", params.DKLen)
+	}
+
+	return nil
+}
+
+// additionalData returns the data authenticated along with the key of id used by signer signerName
+func additionalData(id, signerName string) []byte {
+	// id is hex encoded, the separator never appears in it
+	return []byte(id + "\x00" + signerName)
+}
+
+// newGCM returns GCM of stored cipher cipherName keyed by key
+func newGCM(cipherName string, key []byte) (cipher.AEAD, error) {
+	var block cipher.Block
+	var err error
+	if cipherName == cipherSM4GCM {
+		block, err = sm4.NewCipher(key)
+	} else {
+		block, err = aes.NewCipher(key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,301 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package keystore
+
+import (
+	"crypto"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	topacrypto "github.com/mintzhao/topachain/common/crypto"
+	"github.com/mintzhao/topachain/common/crypto/keys"
+	_ "github.com/mintzhao/topachain/common/logging"
+	"github.com/op/go-logging"
+	"github.com/pkg/errors"
+)
+
+const (
+	// StandardScryptN is the N parameter of scrypt, using 256MB memory and taking approximately 1s CPU time
+	StandardScryptN = 1 << 18
+
+	// StandardScryptP is the P parameter of scrypt, using 256MB memory and taking approximately 1s CPU time
+	StandardScryptP = 1
+
+	// LightScryptN is the N parameter of scrypt, using 4MB memory and taking approximately 100ms CPU time
+	LightScryptN = 1 << 12
+
+	// LightScryptP is the P parameter of scrypt, using 4MB memory and taking approximately 100ms CPU time
+	LightScryptP = 6
+
+	// keyFileExt is the extension of key files
+	keyFileExt = ".json"
+)
+
+var (
+	// logger
+	logger = logging.MustGetLogger("crypto/keystore")
+)
+
+// KeyStore stores private keys in a directory, each encrypted by a passphrase.
+// Unlocked keys are only kept in memory and are used to sign on behalf of callers.
+type KeyStore struct {
+	dir     string
+	scryptN int
+	scryptP int
+	cipher  string
+
+	mutex    sync.RWMutex
+	unlocked map[string]*unlockedKey
+}
+
+type unlockedKey struct {
+	signerName string
+	key        crypto.PrivateKey
+}
+
+// New returns a KeyStore storing keys in dir, keys are encrypted by AES with scrypt parameters scryptN and scryptP
+func New(dir string, scryptN, scryptP int) (*KeyStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "create keystore dir error")
+	}
+
+	return &KeyStore{
+		dir:      dir,
+		scryptN:  scryptN,
+		scryptP:  scryptP,
+		cipher:   cipherAESGCM,
+		unlocked: make(map[string]*unlockedKey),
+	}, nil
+}
+
+// SetCipher sets the cipher encrypting keys stored afterwards, AES or SM4 of config common.crypto.cipher.
+// Stored keys are decrypted by the cipher they were encrypted with.
+func (ks *KeyStore) SetCipher(name string) error {
+	c, ok := ciphers[strings.ToUpper(strings.TrimSpace(name))]
+	if !ok {
+		return errors.Errorf("unsupported cipher %s", name)
+	}
+
+	ks.cipher = c
+	return nil
+}
+
+// Generate generates a private key for signer signerName and stores it, returns key id
+func (ks *KeyStore) Generate(signerName, passphrase string) (string, error) {
+	k, err := keys.GenerateKey(signerName)
+	if err != nil {
+		return "", err
+	}
+
+	return ks.Import(signerName, k, passphrase)
+}
+
+// Import stores private key k used by signer signerName, returns key id
+func (ks *KeyStore) Import(signerName string, k crypto.PrivateKey, passphrase string) (string, error) {
+	pubKey, err := keys.PublicKey(k)
+	if err != nil {
+		return "", err
+	}
+
+	keyID, err := keys.KeyID(pubKey)
+	if err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(keyID)
+
+	if _, err := os.Stat(ks.keyFile(id)); err == nil {
+		return "", ErrKeyExists
+	}
+
+	ek, err := encryptKey(id, signerName, k, passphrase, ks.cipher, ks.scryptN, ks.scryptP)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(ek, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(ks.keyFile(id), data, 0600); err != nil {
+		return "", errors.Wrap(err, "write key file error")
+	}
+
+	logger.Infof("key %s imported", id)
+	return id, nil
+}
+
+// Export returns PKCS#8 PEM form of private key id decrypted by passphrase
+func (ks *KeyStore) Export(id, passphrase string) ([]byte, error) {
+	_, k, err := ks.decrypt(id, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys.EncodePrivateKeyPEM(k)
+}
+
+// List returns ids of all the stored keys
+func (ks *KeyStore) List() ([]string, error) {
+	files, err := ioutil.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0)
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != keyFileExt {
+			continue
+		}
+
+		ids = append(ids, strings.TrimSuffix(f.Name(), keyFileExt))
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// Delete removes key id, passphrase is required to prevent removing by mistake
+func (ks *KeyStore) Delete(id, passphrase string) error {
+	if _, _, err := ks.decrypt(id, passphrase); err != nil {
+		return err
+	}
+
+	ks.Lock(id)
+	if err := os.Remove(ks.keyFile(id)); err != nil {
+		return err
+	}
+
+	logger.Infof("key %s deleted", id)
+	return nil
+}
+
+// PublicKey returns public key of key id, no passphrase needed
+func (ks *KeyStore) PublicKey(id string) (crypto.PublicKey, error) {
+	ek, err := ks.read(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys.DecodePublicKeyPEM([]byte(ek.PublicKey))
+}
+
+// Unlock decrypts key id by passphrase and keeps it in memory, so that Sign can use it
+func (ks *KeyStore) Unlock(id, passphrase string) error {
+	signerName, k, err := ks.decrypt(id, passphrase)
+	if err != nil {
+		return err
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	ks.unlocked[id] = &unlockedKey{signerName: signerName, key: k}
+
+	return nil
+}
+
+// Lock drops unlocked key id from memory
+func (ks *KeyStore) Lock(id string) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	delete(ks.unlocked, id)
+}
+
+// Sign signs msg by unlocked key id, using the signer the key generated for
+func (ks *KeyStore) Sign(id string, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	ks.mutex.RLock()
+	uk, ok := ks.unlocked[id]
+	ks.mutex.RUnlock()
+	if !ok {
+		return nil, ErrKeyLocked
+	}
+
+	return topacrypto.Sign(uk.key, msg, opts, uk.signerName)
+}
+
+// Verify verifies signature against public key of key id
+func (ks *KeyStore) Verify(id string, signature, msg []byte, opts crypto.SignerOpts) (bool, error) {
+	ek, err := ks.read(id)
+	if err != nil {
+		return false, err
+	}
+
+	pubKey, err := keys.DecodePublicKeyPEM([]byte(ek.PublicKey))
+	if err != nil {
+		return false, err
+	}
+
+	return topacrypto.Verify(pubKey, signature, msg, opts, ek.Signer)
+}
+
+// decrypt reads key id and decrypts it by passphrase
+func (ks *KeyStore) decrypt(id, passphrase string) (string, crypto.PrivateKey, error) {
+	ek, err := ks.read(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	k, err := decryptKey(ek, passphrase)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return ek.Signer, k, nil
+}
+
+// read reads encrypted key id
+func (ks *KeyStore) read(id string) (*encryptedKey, error) {
+	data, err := ioutil.ReadFile(ks.keyFile(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrKeyNotFound
+		}
+
+		return nil, err
+	}
+
+	ek := new(encryptedKey)
+	if err := json.Unmarshal(data, ek); err != nil {
+		return nil, errors.Wrap(err, "unmarshal key file error")
+	}
+
+	if ek.ID != id {
+		return nil, errors.Errorf("key file of %s contains key %s", id, ek.ID)
+	}
+
+	return ek, nil
+}
+
+func (ks *KeyStore) keyFile(id string) string {
+	return filepath.Join(ks.dir, filepath.Base(id)+keyFileExt)
+}
+
+var (
+	// ErrKeyNotFound is returned when key id not stored in keystore
+	ErrKeyNotFound = errors.New("key not found")
+
+	// ErrKeyExists is returned when importing a key already stored
+	ErrKeyExists = errors.New("key already exists")
+
+	// ErrKeyLocked is returned when signing by a key not unlocked
+	ErrKeyLocked = errors.New("key locked")
+
+	// ErrDecrypt is returned when key can't be decrypted by given passphrase
+	ErrDecrypt = errors.New("could not decrypt key with given passphrase")
+)
@@ -0,0 +1,169 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package keystore
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mintzhao/topachain/common/crypto/keys"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var testksdir = "./testdata"
+
+func TestKeyStore(t *testing.T) {
+	defer os.RemoveAll(testksdir)
+
+	ks, err := New(testksdir, LightScryptN, LightScryptP)
+	assert.NoError(t, err)
+
+	msg := bytes.NewBufferString("this is a string used for keystore test").Bytes()
+	opts := &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthEqualsHash}
+
+	for _, signerName := range []string{"RSA", "ECDSA", "ED25519"} {
+		id, err := ks.Generate(signerName, "passphrase")
+		assert.NoError(t, err)
+
+		// key file never contains plaintext key
+		data, err := ioutil.ReadFile(ks.keyFile(id))
+		assert.NoError(t, err)
+		assert.False(t, strings.Contains(string(data), "PRIVATE KEY"))
+
+		// sign needs unlock
+		_, err = ks.Sign(id, msg, opts)
+		assert.EqualError(t, err, ErrKeyLocked.Error())
+
+		assert.EqualError(t, ks.Unlock(id, "wrong"), ErrDecrypt.Error())
+		assert.NoError(t, ks.Unlock(id, "passphrase"))
+
+		sig, err := ks.Sign(id, msg, opts)
+		assert.NoError(t, err)
+
+		ok, err := ks.Verify(id, sig, msg, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, true, ok, signerName)
+
+		ks.Lock(id)
+		_, err = ks.Sign(id, msg, opts)
+		assert.EqualError(t, err, ErrKeyLocked.Error())
+	}
+
+	ids, err := ks.List()
+	assert.NoError(t, err)
+	assert.Len(t, ids, 3)
+
+	// export & import
+	pemBytes, err := ks.Export(ids[0], "passphrase")
+	assert.NoError(t, err)
+	k, err := keys.DecodePrivateKeyPEM(pemBytes)
+	assert.NoError(t, err)
+
+	_, err = ks.Import("ECDSA", k, "another")
+	assert.EqualError(t, err, ErrKeyExists.Error())
+
+	// delete
+	assert.EqualError(t, ks.Delete(ids[0], "wrong"), ErrDecrypt.Error())
+	assert.NoError(t, ks.Delete(ids[0], "passphrase"))
+	_, err = ks.PublicKey(ids[0])
+	assert.EqualError(t, err, ErrKeyNotFound.Error())
+
+	ids, err = ks.List()
+	assert.NoError(t, err)
+	assert.Len(t, ids, 2)
+}
+
+func TestKeyStore_SM4(t *testing.T) {
+	defer os.RemoveAll(testksdir)
+
+	ks, err := New(testksdir, LightScryptN, LightScryptP)
+	assert.NoError(t, err)
+	assert.Error(t, ks.SetCipher("DES"))
+
+	// keys stored before switching cipher are still readable
+	aesID, err := ks.Generate("ECDSA", "passphrase")
+	assert.NoError(t, err)
+
+	assert.NoError(t, ks.SetCipher("SM4"))
+	sm4ID, err := ks.Generate("SM2", "passphrase")
+	assert.NoError(t, err)
+
+	ek, err := ks.read(sm4ID)
+	assert.NoError(t, err)
+	assert.Equal(t, cipherSM4GCM, ek.Crypto.Cipher)
+	assert.Equal(t, 16, ek.Crypto.KDFParams.DKLen)
+
+	msg := bytes.NewBufferString("this is a string used for keystore test").Bytes()
+	for _, id := range []string{aesID, sm4ID} {
+		assert.EqualError(t, ks.Unlock(id, "wrong"), ErrDecrypt.Error())
+		assert.NoError(t, ks.Unlock(id, "passphrase"))
+
+		sig, err := ks.Sign(id, msg, nil)
+		assert.NoError(t, err)
+		ok, err := ks.Verify(id, sig, msg, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, true, ok)
+	}
+}
+
+func TestKeyStore_Tampered(t *testing.T) {
+	defer os.RemoveAll(testksdir)
+
+	ks, err := New(testksdir, LightScryptN, LightScryptP)
+	assert.NoError(t, err)
+	id, err := ks.Generate("ECDSA", "passphrase")
+	assert.NoError(t, err)
+
+	tamper := func(fn func(ek *encryptedKey)) {
+		ek, err := ks.read(id)
+		assert.NoError(t, err)
+		fn(ek)
+		data, err := json.Marshal(ek)
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(ks.keyFile(id), data, 0600))
+	}
+
+	// signer name is authenticated with the key
+	tamper(func(ek *encryptedKey) { ek.Signer = "RSA" })
+	assert.EqualError(t, ks.Unlock(id, "passphrase"), ErrDecrypt.Error())
+	tamper(func(ek *encryptedKey) { ek.Signer = "ECDSA" })
+	assert.NoError(t, ks.Unlock(id, "passphrase"))
+
+	// scrypt parameters are bounded before deriving
+	for _, fn := range []func(ek *encryptedKey){
+		func(ek *encryptedKey) { ek.Crypto.KDFParams.N = 1 << 30 },
+		func(ek *encryptedKey) { ek.Crypto.KDFParams.N = 3000 },
+		func(ek *encryptedKey) { ek.Crypto.KDFParams.R = 1 << 20 },
+		func(ek *encryptedKey) { ek.Crypto.KDFParams.P = 1 << 20 },
+		func(ek *encryptedKey) { ek.Crypto.KDFParams.DKLen = 1 << 30 },
+	} {
+		tamper(func(ek *encryptedKey) {
+			ek.Crypto.KDFParams = scryptJSON{N: LightScryptN, R: scryptR, P: LightScryptP, DKLen: 32, Salt: ek.Crypto.KDFParams.Salt}
+			fn(ek)
+		})
+		assert.Equal(t, ErrInvalidKDFParams, errors.Cause(ks.Unlock(id, "passphrase")))
+	}
+
+	// and so are the ones keys stored with
+	ks, err = New(testksdir, maxScryptN<<1, 1)
+	assert.NoError(t, err)
+	_, err = ks.Generate("RSA", "passphrase")
+	assert.Equal(t, ErrInvalidKDFParams, errors.Cause(err))
+}
@@ -35,6 +35,7 @@ func init() {
 	RegisterSigner("RSA", &rsaSigner{})
 	RegisterSigner("ECDSA", &ecdsaSigner{})
 	RegisterSigner("ED25519", &ed25519Signer{})
+	RegisterSigner("SM2", &sm2Signer{})
 }
 
 // Signer contains signing functions
@@ -0,0 +1,74 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package signer
+
+import (
+	"crypto"
+	"crypto/rand"
+	"encoding/asn1"
+
+	"github.com/mintzhao/topachain/common/crypto/sm2"
+	"github.com/pkg/errors"
+)
+
+// SM2SignerOpts identifies the signer whose user ID is hashed into Z-value, sm2.DefaultUID if opts is nil
+type SM2SignerOpts struct {
+	UID []byte
+}
+
+// HashFunc returns 0, msg is hashed by SM3 with Z-value inside SM2 signer
+func (opts *SM2SignerOpts) HashFunc() crypto.Hash {
+	return 0
+}
+
+type sm2Signer struct {
+}
+
+// Sign signs msg using PrivateKey k, the signature is ASN.1 encoded r and s like ecdsa
+func (ss *sm2Signer) Sign(k crypto.PrivateKey, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	priv, ok := k.(*sm2.PrivateKey)
+	if !ok {
+		return nil, errors.Errorf("expect sm2 private key, got %T", k)
+	}
+
+	r, s, err := sm2.Sign(rand.Reader, priv, sm2UID(opts), msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(ecdsaSignature{r, s})
+}
+
+// Verify verifies signature against key k and msg
+func (ss *sm2Signer) Verify(k crypto.PublicKey, signature, msg []byte, opts crypto.SignerOpts) (bool, error) {
+	pub, ok := k.(*sm2.PublicKey)
+	if !ok {
+		return false, errors.Errorf("expect sm2 public key, got %T", k)
+	}
+
+	sig := new(ecdsaSignature)
+	if _, err := asn1.Unmarshal(signature, sig); err != nil {
+		return false, errors.Wrap(err, "unmarshal sm2 signature error")
+	}
+
+	return sm2.Verify(pub, sm2UID(opts), msg, sig.R, sig.S), nil
+}
+
+// sm2UID returns the user ID of opts, nil for the default one
+func sm2UID(opts crypto.SignerOpts) []byte {
+	if o, ok := opts.(*SM2SignerOpts); ok && o != nil {
+		return o.UID
+	}
+
+	return nil
+}
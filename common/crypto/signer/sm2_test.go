@@ -0,0 +1,94 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package signer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/mintzhao/topachain/common/crypto/sm2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSM2Signer_Sign(t *testing.T) {
+	signer := &sm2Signer{}
+
+	privKey, err := sm2.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	assert.NotNil(t, privKey)
+
+	msg := bytes.NewBufferString("this is a string used for test sm2Signer").Bytes()
+
+	// sign
+	sig, err := signer.Sign(privKey, msg, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, sig)
+
+	// verify ok
+	ok, err := signer.Verify(privKey.Public(), sig, msg, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, true, ok)
+
+	// verify not ok
+	notok, err := signer.Verify(privKey.Public(), sig[1:], msg, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, false, notok)
+
+	// wrong key type
+	_, err = signer.Sign("not a key", msg, nil)
+	assert.NotNil(t, err)
+}
+
+func TestSM2Signer_Verify(t *testing.T) {
+	signer := &sm2Signer{}
+
+	privKey, err := sm2.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	msg := bytes.NewBufferString("this is a string used for test sm2Signer").Bytes()
+	opts := &SM2SignerOpts{UID: []byte("alice@topachain")}
+
+	// sign with user ID
+	sig, err := signer.Sign(privKey, msg, opts)
+	assert.Nil(t, err)
+
+	// verify ok
+	ok, err := signer.Verify(privKey.Public(), sig, msg, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, true, ok)
+
+	// verify not ok with the default user ID
+	notok, err := signer.Verify(privKey.Public(), sig, msg, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, false, notok)
+
+	// verify not ok
+	notok, err = signer.Verify(privKey.Public(), sig, bytes.NewBufferString("this is another string").Bytes(), opts)
+	assert.Nil(t, err)
+	assert.Equal(t, false, notok)
+}
+
+func BenchmarkSM2Signer_Sign(b *testing.B) {
+	signer := &sm2Signer{}
+
+	privKey, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		b.FailNow()
+	}
+	msg := bytes.NewBufferString("this is a string used for test sm2Signer").Bytes()
+
+	for i := 0; i < b.N; i++ {
+		signer.Sign(privKey, msg, nil)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sm2
+
+import "math/big"
+
+// limbs is a 256-bit integer of 32-bit little endian limbs. Arithmetic on limbs runs in constant time,
+// it never branches on or indexes memory by the values.
+type limbs [8]uint32
+
+// modulus is an odd 256-bit modulus of Montgomery arithmetic, values are kept in Montgomery form x*R mod m, R = 2^256
+type modulus struct {
+	m limbs
+
+	// mInv is -m^-1 mod 2^32
+	mInv uint32
+
+	// rr is R^2 mod m, one is R mod m
+	rr, one limbs
+
+	// exp is m-2 in big endian bytes, the exponent of inversion
+	exp []byte
+}
+
+func newModulus(m *big.Int) *modulus {
+	mod := &modulus{m: limbsFromBig(m)}
+
+	// Newton iteration doubles the bits of m^-1 mod 2^32 each step
+	inv := uint32(1)
+	for i := 0; i < 5; i++ {
+		inv *= 2 - mod.m[0]*inv
+	}
+	mod.mInv = -inv
+
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	mod.one = limbsFromBig(new(big.Int).Mod(r, m))
+	mod.rr = limbsFromBig(new(big.Int).Mod(new(big.Int).Mul(r, r), m))
+	mod.exp = new(big.Int).Sub(m, big.NewInt(2)).Bytes()
+
+	return mod
+}
+
+// limbsFromBig returns limbs of v, which must be less than 2^256
+func limbsFromBig(v *big.Int) limbs {
+	var b [32]byte
+	vb := v.Bytes()
+	copy(b[32-len(vb):], vb)
+	return limbsFromBytes(b[:])
+}
+
+// limbsFromBytes returns limbs of 32 bytes big endian b
+func limbsFromBytes(b []byte) limbs {
+	var x limbs
+	for i := range x {
+		j := 28 - 4*i
+		x[i] = uint32(b[j])<<24 | uint32(b[j+1])<<16 | uint32(b[j+2])<<8 | uint32(b[j+3])
+	}
+	return x
+}
+
+// bytes returns x as 32 bytes big endian
+func (x *limbs) bytes() []byte {
+	b := make([]byte, 32)
+	for i, l := range x {
+		j := 28 - 4*i
+		b[j], b[j+1], b[j+2], b[j+3] = byte(l>>24), byte(l>>16), byte(l>>8), byte(l)
+	}
+	return b
+}
+
+// big returns x as big.Int
+func (x *limbs) big() *big.Int {
+	return new(big.Int).SetBytes(x.bytes())
+}
+
+// sub sets z = x - y, returns the borrow out
+func (z *limbs) sub(x, y *limbs) uint32 {
+	var borrow uint64
+	for i := range z {
+		v := uint64(x[i]) - uint64(y[i]) - borrow
+		z[i] = uint32(v)
+		borrow = (v >> 32) & 1
+	}
+	return uint32(borrow)
+}
+
+// selectIf sets z = x if mask is all ones, keeps z if it's zero
+func (z *limbs) selectIf(mask uint32, x *limbs) {
+	for i := range z {
+		z[i] ^= mask & (z[i] ^ x[i])
+	}
+}
+
+// isZero returns 1 if x is zero, 0 otherwise
+func (x *limbs) isZero() uint32 {
+	var acc uint32
+	for _, l := range x {
+		acc |= l
+	}
+	return uint32((uint64(acc) - 1) >> 63)
+}
+
+// less returns 1 if x < y, 0 otherwise
+func (x *limbs) less(y *limbs) uint32 {
+	var d limbs
+	return d.sub(x, y)
+}
+
+// eqMask returns all ones if a == b, zero otherwise
+func eqMask(a, b uint32) uint32 {
+	return -uint32((uint64(a^b) - 1) >> 63)
+}
+
+// mul sets z = x * y * R^-1 mod m, x and y less than m
+func (mod *modulus) mul(z, x, y *limbs) {
+	var t [10]uint32
+	for i := 0; i < 8; i++ {
+		// t += x * y[i]
+		var c uint64
+		for j := 0; j < 8; j++ {
+			v := uint64(t[j]) + uint64(x[j])*uint64(y[i]) + c
+			t[j], c = uint32(v), v>>32
+		}
+		v := uint64(t[8]) + c
+		t[8], t[9] = uint32(v), uint32(v>>32)
+
+		// t = (t + q * m) / 2^32, q makes the lowest limb zero
+		q := t[0] * mod.mInv
+		c = (uint64(t[0]) + uint64(q)*uint64(mod.m[0])) >> 32
+		for j := 1; j < 8; j++ {
+			v := uint64(t[j]) + uint64(q)*uint64(mod.m[j]) + c
+			t[j-1], c = uint32(v), v>>32
+		}
+		v = uint64(t[8]) + c
+		t[7] = uint32(v)
+		t[8] = t[9] + uint32(v>>32)
+	}
+
+	// t < 2m, subtract m unless it borrows
+	var r, d limbs
+	copy(r[:], t[:8])
+	borrow := d.sub(&r, &mod.m)
+	r.selectIf(eqMask(borrow, t[8]), &d)
+	*z = r
+}
+
+// add sets z = x + y mod m, x and y less than m
+func (mod *modulus) add(z, x, y *limbs) {
+	var carry uint64
+	var r limbs
+	for i := range r {
+		v := uint64(x[i]) + uint64(y[i]) + carry
+		r[i], carry = uint32(v), v>>32
+	}
+
+	var d limbs
+	borrow := d.sub(&r, &mod.m)
+	// x + y >= m unless the subtraction borrows without carry
+	r.selectIf(^(-(uint32(borrow) &^ uint32(carry))), &d)
+	*z = r
+}
+
+// sub sets z = x - y mod m, x and y less than m
+func (mod *modulus) sub(z, x, y *limbs) {
+	var r limbs
+	borrow := r.sub(x, y)
+
+	var m limbs
+	for i := range m {
+		m[i] = mod.m[i] & -borrow
+	}
+	var carry uint64
+	for i := range r {
+		v := uint64(r[i]) + uint64(m[i]) + carry
+		r[i], carry = uint32(v), v>>32
+	}
+	*z = r
+}
+
+// toMont sets z = x * R mod m, x less than 2^256
+func (mod *modulus) toMont(z, x *limbs) {
+	mod.mul(z, x, &mod.rr)
+}
+
+// fromMont sets z = x * R^-1 mod m
+func (mod *modulus) fromMont(z, x *limbs) {
+	mod.mul(z, x, &limbs{1})
+}
+
+// inv sets z = x^-1 mod m by Fermat's little theorem, m is prime. The exponent is public, so is the sequence of operations.
+func (mod *modulus) inv(z, x *limbs) {
+	r := mod.one
+	for _, b := range mod.exp {
+		for i := 7; i >= 0; i-- {
+			mod.mul(&r, &r, &r)
+			if (b>>uint(i))&1 == 1 {
+				mod.mul(&r, &r, x)
+			}
+		}
+	}
+	*z = r
+}
@@ -0,0 +1,201 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sm2
+
+import (
+	"math/big"
+	"sync"
+)
+
+var (
+	arithOnce sync.Once
+
+	// fp and fn are the field and the scalar field of sm2p256v1
+	fp, fn *modulus
+
+	// curveB is b, generator is G, both in Montgomery form of fp
+	curveB    limbs
+	generator point
+)
+
+func initArith() {
+	params := P256().Params()
+	fp = newModulus(params.P)
+	fn = newModulus(params.N)
+
+	b := limbsFromBig(params.B)
+	fp.toMont(&curveB, &b)
+	generator = newPoint(params.Gx, params.Gy)
+}
+
+// point is a point of sm2p256v1 in homogeneous projective coordinates (X:Y:Z) of Montgomery form,
+// x = X/Z and y = Y/Z. The point at infinity is (0:1:0).
+type point struct {
+	x, y, z limbs
+}
+
+// newPoint returns the point of affine coordinates x, y
+func newPoint(x, y *big.Int) point {
+	var p point
+	xl, yl := limbsFromBig(x), limbsFromBig(y)
+	fp.toMont(&p.x, &xl)
+	fp.toMont(&p.y, &yl)
+	p.z = fp.one
+	return p
+}
+
+// infinity returns the point at infinity
+func infinity() point {
+	return point{y: fp.one}
+}
+
+// affine returns the affine coordinates of p, which isn't the point at infinity
+func (p *point) affine() (x, y *big.Int) {
+	var zinv, xl, yl limbs
+	fp.inv(&zinv, &p.z)
+	fp.mul(&xl, &p.x, &zinv)
+	fp.mul(&yl, &p.y, &zinv)
+	fp.fromMont(&xl, &xl)
+	fp.fromMont(&yl, &yl)
+	return xl.big(), yl.big()
+}
+
+// add sets q = p1 + p2 by the complete addition formula for a = -3 of Renes, Costello and Batina,
+// "Complete addition formulas for prime order elliptic curves", algorithm 4. It holds for any input points.
+func (q *point) add(p1, p2 *point) {
+	var t0, t1, t2, t3, t4, x3, y3, z3 limbs
+	fp.mul(&t0, &p1.x, &p2.x)
+	fp.mul(&t1, &p1.y, &p2.y)
+	fp.mul(&t2, &p1.z, &p2.z)
+	fp.add(&t3, &p1.x, &p1.y)
+	fp.add(&t4, &p2.x, &p2.y)
+	fp.mul(&t3, &t3, &t4)
+	fp.add(&t4, &t0, &t1)
+	fp.sub(&t3, &t3, &t4)
+	fp.add(&t4, &p1.y, &p1.z)
+	fp.add(&x3, &p2.y, &p2.z)
+	fp.mul(&t4, &t4, &x3)
+	fp.add(&x3, &t1, &t2)
+	fp.sub(&t4, &t4, &x3)
+	fp.add(&x3, &p1.x, &p1.z)
+	fp.add(&y3, &p2.x, &p2.z)
+	fp.mul(&x3, &x3, &y3)
+	fp.add(&y3, &t0, &t2)
+	fp.sub(&y3, &x3, &y3)
+	fp.mul(&z3, &curveB, &t2)
+	fp.sub(&x3, &y3, &z3)
+	fp.add(&z3, &x3, &x3)
+	fp.add(&x3, &x3, &z3)
+	fp.sub(&z3, &t1, &x3)
+	fp.add(&x3, &t1, &x3)
+	fp.mul(&y3, &curveB, &y3)
+	fp.add(&t1, &t2, &t2)
+	fp.add(&t2, &t1, &t2)
+	fp.sub(&y3, &y3, &t2)
+	fp.sub(&y3, &y3, &t0)
+	fp.add(&t1, &y3, &y3)
+	fp.add(&y3, &t1, &y3)
+	fp.add(&t1, &t0, &t0)
+	fp.add(&t0, &t1, &t0)
+	fp.sub(&t0, &t0, &t2)
+	fp.mul(&t1, &t4, &y3)
+	fp.mul(&t2, &t0, &y3)
+	fp.mul(&y3, &x3, &z3)
+	fp.add(&y3, &y3, &t2)
+	fp.mul(&x3, &t3, &x3)
+	fp.sub(&x3, &x3, &t1)
+	fp.mul(&z3, &t4, &z3)
+	fp.mul(&t1, &t3, &t0)
+	fp.add(&z3, &z3, &t1)
+	q.x, q.y, q.z = x3, y3, z3
+}
+
+// double sets q = 2p by the complete doubling formula for a = -3 of the same paper, algorithm 6
+func (q *point) double(p *point) {
+	var t0, t1, t2, t3, x3, y3, z3 limbs
+	fp.mul(&t0, &p.x, &p.x)
+	fp.mul(&t1, &p.y, &p.y)
+	fp.mul(&t2, &p.z, &p.z)
+	fp.mul(&t3, &p.x, &p.y)
+	fp.add(&t3, &t3, &t3)
+	fp.mul(&z3, &p.x, &p.z)
+	fp.add(&z3, &z3, &z3)
+	fp.mul(&y3, &curveB, &t2)
+	fp.sub(&y3, &y3, &z3)
+	fp.add(&x3, &y3, &y3)
+	fp.add(&y3, &x3, &y3)
+	fp.sub(&x3, &t1, &y3)
+	fp.add(&y3, &t1, &y3)
+	fp.mul(&y3, &x3, &y3)
+	fp.mul(&x3, &x3, &t3)
+	fp.add(&t3, &t2, &t2)
+	fp.add(&t2, &t2, &t3)
+	fp.mul(&z3, &curveB, &z3)
+	fp.sub(&z3, &z3, &t2)
+	fp.sub(&z3, &z3, &t0)
+	fp.add(&t3, &z3, &z3)
+	fp.add(&z3, &z3, &t3)
+	fp.add(&t3, &t0, &t0)
+	fp.add(&t0, &t3, &t0)
+	fp.sub(&t0, &t0, &t2)
+	fp.mul(&t0, &t0, &z3)
+	fp.add(&y3, &y3, &t0)
+	fp.mul(&t0, &p.y, &p.z)
+	fp.add(&t0, &t0, &t0)
+	fp.mul(&z3, &t0, &z3)
+	fp.sub(&x3, &x3, &z3)
+	fp.mul(&z3, &t0, &t1)
+	fp.add(&z3, &z3, &z3)
+	fp.add(&z3, &z3, &z3)
+	q.x, q.y, q.z = x3, y3, z3
+}
+
+// scalarMult sets q = k * p in constant time, k is 32 bytes big endian.
+// Multiples of p are looked up from a table of 4-bit windows, touching every entry.
+func (q *point) scalarMult(p *point, k []byte) {
+	var table [16]point
+	table[0] = infinity()
+	table[1] = *p
+	for i := 2; i < 16; i++ {
+		table[i].add(&table[i-1], p)
+	}
+
+	r := infinity()
+	for _, b := range k {
+		for _, w := range []uint32{uint32(b >> 4), uint32(b & 0xf)} {
+			for i := 0; i < 4; i++ {
+				r.double(&r)
+			}
+
+			var sel point
+			for i := range table {
+				mask := eqMask(uint32(i), w)
+				sel.x.selectIf(mask, &table[i].x)
+				sel.y.selectIf(mask, &table[i].y)
+				sel.z.selectIf(mask, &table[i].z)
+			}
+			r.add(&r, &sel)
+		}
+	}
+	*q = r
+}
+
+// scalarBaseMult returns the affine coordinates of k * G, computed in constant time.
+// k is a secret scalar in [1, n) of 32 bytes big endian.
+func scalarBaseMult(k []byte) (x, y *big.Int) {
+	arithOnce.Do(initArith)
+
+	var p point
+	p.scalarMult(&generator, k)
+	return p.affine()
+}
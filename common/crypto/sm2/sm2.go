@@ -0,0 +1,262 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sm2 implements the SM2 digital signature algorithm of GB/T 32918-2016 over the recommended sm2p256v1 curve.
+// Key generation and signing handle secrets in constant time by the field, scalar and curve arithmetic of this package.
+// Verification handles public values only, it uses the generic elliptic.CurveParams implementation.
+package sm2
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/mintzhao/topachain/common/crypto/sm3"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultUID is the user ID hashed into Z-value if signer has none, as recommended by GM/T 0009-2012
+	DefaultUID = "1234567812345678"
+)
+
+var (
+	// ErrInvalidUID means user ID is too long to hash into Z-value
+	ErrInvalidUID = errors.New("sm2: user id too long")
+
+	// ErrInvalidKey means private key is out of range
+	ErrInvalidKey = errors.New("sm2: invalid private key")
+
+	// ErrInvalidPublicKey means public key isn't a point on sm2p256v1
+	ErrInvalidPublicKey = errors.New("sm2: invalid public key")
+
+	one = big.NewInt(1)
+
+	initonce sync.Once
+	p256     *elliptic.CurveParams
+)
+
+func initP256() {
+	p256 = &elliptic.CurveParams{Name: "SM2-P-256", BitSize: 256}
+	p256.P, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF00000000FFFFFFFFFFFFFFFF", 16)
+	p256.N, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF7203DF6B21C6052B53BBF40939D54123", 16)
+	p256.B, _ = new(big.Int).SetString("28E9FA9E9D9F5E344D5A9E4BCF6509A7F39789F515AB8F92DDBCBD414D940E93", 16)
+	p256.Gx, _ = new(big.Int).SetString("32C4AE2C1F1981195F9904466A39C9948FE30BBFF2660BE1715A4589334C74C7", 16)
+	p256.Gy, _ = new(big.Int).SetString("BC3736A2F4F6779C59BDCEE36B692153D0A9877CC62A474002DF32E52139F0A0", 16)
+}
+
+// P256 returns the sm2p256v1 curve, whose a is -3 like the NIST curves, as elliptic.CurveParams assumes
+func P256() elliptic.Curve {
+	initonce.Do(initP256)
+	return p256
+}
+
+// PublicKey is a SM2 public key
+type PublicKey struct {
+	elliptic.Curve
+	X, Y *big.Int
+}
+
+// PrivateKey is a SM2 private key
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// Public returns the public key of priv
+func (priv *PrivateKey) Public() crypto.PublicKey {
+	return &priv.PublicKey
+}
+
+// GenerateKey generates a private key, d is in [1, n-2] so that 1+d is invertible
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	arithOnce.Do(initArith)
+
+	max := limbsFromBig(new(big.Int).Sub(P256().Params().N, one))
+	d, err := randScalar(rand, &max)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrivateKey(d.big())
+}
+
+// NewPrivateKey returns the private key of scalar d
+func NewPrivateKey(d *big.Int) (*PrivateKey, error) {
+	c := P256()
+	if d.Sign() <= 0 || d.Cmp(new(big.Int).Sub(c.Params().N, one)) >= 0 {
+		return nil, ErrInvalidKey
+	}
+
+	priv := &PrivateKey{D: new(big.Int).Set(d)}
+	priv.Curve = c
+	priv.X, priv.Y = scalarBaseMult(fieldBytes(d))
+	return priv, nil
+}
+
+// NewPublicKey returns the public key of point (x, y)
+func NewPublicKey(x, y *big.Int) (*PublicKey, error) {
+	c := P256()
+	if !c.IsOnCurve(x, y) {
+		return nil, ErrInvalidPublicKey
+	}
+
+	return &PublicKey{Curve: c, X: x, Y: y}, nil
+}
+
+// randScalar returns a random scalar in [1, max), max is close to 2^256.
+// Values out of range are rejected, which reveals nothing of the one returned.
+func randScalar(rand io.Reader, max *limbs) (limbs, error) {
+	b := make([]byte, 32)
+	for {
+		if _, err := io.ReadFull(rand, b); err != nil {
+			return limbs{}, err
+		}
+
+		k := limbsFromBytes(b)
+		if (k.isZero()^1)&k.less(max) == 1 {
+			return k, nil
+		}
+	}
+}
+
+// ZA returns the Z-value of signer of pub identified by uid,
+// SM3(ENTL || uid || a || b || xG || yG || xA || yA), DefaultUID is used if uid is nil
+func ZA(pub *PublicKey, uid []byte) ([]byte, error) {
+	if uid == nil {
+		uid = []byte(DefaultUID)
+	}
+	if len(uid) >= 1<<13 {
+		return nil, ErrInvalidUID
+	}
+
+	params := P256().Params()
+	a := new(big.Int).Sub(params.P, big.NewInt(3))
+
+	h := sm3.New()
+	var entl [2]byte
+	binary.BigEndian.PutUint16(entl[:], uint16(len(uid)*8))
+	h.Write(entl[:])
+	h.Write(uid)
+	for _, v := range []*big.Int{a, params.B, params.Gx, params.Gy, pub.X, pub.Y} {
+		h.Write(fieldBytes(v))
+	}
+
+	return h.Sum(nil), nil
+}
+
+// fieldBytes returns v as 32 bytes big endian
+func fieldBytes(v *big.Int) []byte {
+	b := make([]byte, 32)
+	vb := v.Bytes()
+	copy(b[32-len(vb):], vb)
+	return b
+}
+
+// hashMsg returns e = SM3(ZA || msg) as integer
+func hashMsg(pub *PublicKey, uid, msg []byte) (*big.Int, error) {
+	za, err := ZA(pub, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sm3.New()
+	h.Write(za)
+	h.Write(msg)
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
+
+// Sign signs msg by priv as signer identified by uid, DefaultUID if nil. Msg is hashed with Z-value by SM3 inside.
+func Sign(rand io.Reader, priv *PrivateKey, uid, msg []byte) (r, s *big.Int, err error) {
+	e, err := hashMsg(&priv.PublicKey, uid, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	arithOnce.Do(initArith)
+	n := P256().Params().N
+	if priv.D.Sign() <= 0 || priv.D.Cmp(new(big.Int).Sub(n, one)) >= 0 {
+		return nil, nil, ErrInvalidKey
+	}
+
+	// (1+d)^-1 in Montgomery form of n
+	var d, dInv limbs
+	dl := limbsFromBig(priv.D)
+	fn.toMont(&d, &dl)
+	fn.add(&dInv, &d, &fn.one)
+	fn.inv(&dInv, &dInv)
+
+	for {
+		k, err := randScalar(rand, &fn.m)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// r = e + x1 mod n is public, k stays secret
+		x1, _ := scalarBaseMult(k.bytes())
+		r = new(big.Int).Add(e, x1)
+		r.Mod(r, n)
+		rl := limbsFromBig(r)
+		var rk limbs
+		fn.add(&rk, &rl, &k)
+		if r.Sign() == 0 || rk.isZero() == 1 {
+			continue
+		}
+
+		// s = (1+d)^-1 * (k - r*d) mod n
+		var sl, rm, km limbs
+		fn.toMont(&rm, &rl)
+		fn.toMont(&km, &k)
+		fn.mul(&sl, &rm, &d)
+		fn.sub(&sl, &km, &sl)
+		fn.mul(&sl, &sl, &dInv)
+		fn.fromMont(&sl, &sl)
+		if sl.isZero() == 0 {
+			return r, sl.big(), nil
+		}
+	}
+}
+
+// Verify verifies signature r, s of msg by signer of pub identified by uid, DefaultUID if nil
+func Verify(pub *PublicKey, uid, msg []byte, r, s *big.Int) bool {
+	c := P256()
+	n := c.Params().N
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(n) >= 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+	if !c.IsOnCurve(pub.X, pub.Y) {
+		return false
+	}
+
+	e, err := hashMsg(pub, uid, msg)
+	if err != nil {
+		return false
+	}
+
+	t := new(big.Int).Add(r, s)
+	t.Mod(t, n)
+	if t.Sign() == 0 {
+		return false
+	}
+
+	x1, y1 := c.ScalarBaseMult(s.Bytes())
+	x2, y2 := c.ScalarMult(pub.X, pub.Y, t.Bytes())
+	x, _ := c.Add(x1, y1, x2, y2)
+
+	v := new(big.Int).Add(e, x)
+	v.Mod(v, n)
+	return v.Cmp(r) == 0
+}
@@ -0,0 +1,157 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sm2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignVerify(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	msg := bytes.NewBufferString("this is used for sm2 test").Bytes()
+
+	r, s, err := Sign(rand.Reader, priv, nil, msg)
+	assert.NoError(t, err)
+	assert.True(t, Verify(&priv.PublicKey, nil, msg, r, s))
+	assert.True(t, Verify(&priv.PublicKey, []byte(DefaultUID), msg, r, s))
+
+	// user ID is part of the signed content
+	assert.False(t, Verify(&priv.PublicKey, []byte("alice@topachain"), msg, r, s))
+	r, s, err = Sign(rand.Reader, priv, []byte("alice@topachain"), msg)
+	assert.NoError(t, err)
+	assert.True(t, Verify(&priv.PublicKey, []byte("alice@topachain"), msg, r, s))
+
+	assert.False(t, Verify(&priv.PublicKey, []byte("alice@topachain"), []byte("another msg"), r, s))
+	assert.False(t, Verify(&priv.PublicKey, []byte("alice@topachain"), msg, s, r))
+
+	_, _, err = Sign(rand.Reader, priv, make([]byte, 8192), msg)
+	assert.Equal(t, ErrInvalidUID, err)
+}
+
+func TestVerify_OpenSSL(t *testing.T) {
+	// key and signatures generated by openssl 3.0
+	d, _ := new(big.Int).SetString("11f99208bd2b26f1b38dffd1b64465bba3462451c5698dcd773daf8fa1ae4b56", 16)
+	priv, err := NewPrivateKey(d)
+	assert.NoError(t, err)
+	assert.Equal(t, "31ee2302d4f2f3d22bac02f13db9963d31ec1f3833aa0db2a7427899f315a1ff", hex.EncodeToString(priv.X.Bytes()))
+	assert.Equal(t, "201f166dd64db3789a5497494420eee68a09739d3b99728668edefd622f18b80", hex.EncodeToString(priv.Y.Bytes()))
+
+	pub, err := NewPublicKey(priv.X, priv.Y)
+	assert.NoError(t, err)
+	msg := []byte("this is used for sm2 test")
+
+	// openssl dgst -sm3 -sigopt distid:1234567812345678 -sign
+	r, _ := new(big.Int).SetString("bfce0cf331c1319353abc7f988ad553225e2459d2f26ebe7e3a6c6336cda1daf", 16)
+	s, _ := new(big.Int).SetString("53a00d51ab713f9d0f0573d4ce9efdafa299c19a2e4a9306a37f2b0d28dc7144", 16)
+	assert.True(t, Verify(pub, nil, msg, r, s))
+
+	// openssl dgst -sm3 -sign, openssl 3.0 defaults to an empty user ID
+	r, _ = new(big.Int).SetString("49f3e582cc490d154fc8d69a7cd35e94ff9f17eb9326aec0775770c04ab9dff5", 16)
+	s, _ = new(big.Int).SetString("ccef7170558fa513693bd39a49f150195e25b1f739cad7c3bdeecd4eb2f8c231", 16)
+	assert.False(t, Verify(pub, nil, msg, r, s))
+	assert.True(t, Verify(pub, []byte{}, msg, r, s))
+}
+
+func TestNewKey(t *testing.T) {
+	_, err := NewPrivateKey(big.NewInt(0))
+	assert.Equal(t, ErrInvalidKey, err)
+	_, err = NewPrivateKey(P256().Params().N)
+	assert.Equal(t, ErrInvalidKey, err)
+
+	_, err = NewPublicKey(big.NewInt(1), big.NewInt(2))
+	assert.Equal(t, ErrInvalidPublicKey, err)
+}
+
+func BenchmarkSign(b *testing.B) {
+	priv, _ := GenerateKey(rand.Reader)
+	msg := bytes.NewBufferString("this is used for sm2 test").Bytes()
+
+	for i := 0; i < b.N; i++ {
+		Sign(rand.Reader, priv, nil, msg)
+	}
+}
+
+func TestScalarBaseMult(t *testing.T) {
+	c := P256()
+	n := c.Params().N
+
+	// constant-time multiplication agrees with the generic one
+	scalars := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(15), big.NewInt(16), new(big.Int).Sub(n, one)}
+	for i := 0; i < 16; i++ {
+		k, err := rand.Int(rand.Reader, n)
+		assert.NoError(t, err)
+		if k.Sign() > 0 {
+			scalars = append(scalars, k)
+		}
+	}
+	for _, k := range scalars {
+		x, y := scalarBaseMult(fieldBytes(k))
+		ex, ey := c.ScalarBaseMult(k.Bytes())
+		assert.Equal(t, ex, x, k.String())
+		assert.Equal(t, ey, y, k.String())
+	}
+}
+
+func TestArith(t *testing.T) {
+	arithOnce.Do(initArith)
+
+	for _, m := range []*modulus{fp, fn} {
+		mod := m.m.big()
+		for i := 0; i < 16; i++ {
+			a, err := rand.Int(rand.Reader, mod)
+			assert.NoError(t, err)
+			b, err := rand.Int(rand.Reader, mod)
+			assert.NoError(t, err)
+
+			al, bl := limbsFromBig(a), limbsFromBig(b)
+			var am, bm, z limbs
+			m.toMont(&am, &al)
+			m.toMont(&bm, &bl)
+
+			m.mul(&z, &am, &bm)
+			m.fromMont(&z, &z)
+			assert.Equal(t, new(big.Int).Mod(new(big.Int).Mul(a, b), mod), z.big())
+
+			m.add(&z, &al, &bl)
+			assert.Equal(t, new(big.Int).Mod(new(big.Int).Add(a, b), mod), z.big())
+
+			m.sub(&z, &al, &bl)
+			assert.Equal(t, new(big.Int).Mod(new(big.Int).Sub(a, b), mod), z.big())
+
+			if a.Sign() > 0 {
+				m.inv(&z, &am)
+				m.fromMont(&z, &z)
+				assert.Equal(t, new(big.Int).ModInverse(a, mod), z.big())
+			}
+		}
+	}
+
+	// the point at infinity is handled by the complete formulas
+	var p point
+	p.add(&generator, &generator)
+	q := infinity()
+	q.add(&q, &generator)
+	q.double(&q)
+	x, y := p.affine()
+	qx, qy := q.affine()
+	assert.Equal(t, x, qx)
+	assert.Equal(t, y, qy)
+}
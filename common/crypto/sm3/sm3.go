@@ -0,0 +1,189 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sm3 implements the SM3 hash algorithm of GB/T 32905-2016
+package sm3
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// Size is the size of SM3 checksum in bytes
+	Size = 32
+
+	// BlockSize is the block size of SM3 in bytes
+	BlockSize = 64
+)
+
+var (
+	// iv is the initial value
+	iv = [8]uint32{0x7380166f, 0x4914b2b9, 0x172442d7, 0xda8a0600, 0xa96f30bc, 0x163138aa, 0xe38dee4d, 0xb0fb0e4e}
+
+	// tj are the round constants rotated by round index
+	tj [64]uint32
+)
+
+func init() {
+	for j := 0; j < 64; j++ {
+		t := uint32(0x79cc4519)
+		if j >= 16 {
+			t = 0x7a879d8a
+		}
+		tj[j] = bits.RotateLeft32(t, j%32)
+	}
+}
+
+type digest struct {
+	h   [8]uint32
+	x   [BlockSize]byte
+	nx  int
+	len uint64
+}
+
+// New returns a new hash.Hash computing SM3 checksum
+func New() hash.Hash {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+// Sum returns the SM3 checksum of data
+func Sum(data []byte) [Size]byte {
+	d := new(digest)
+	d.Reset()
+	d.Write(data)
+
+	var sum [Size]byte
+	d.checkSum(sum[:0])
+	return sum
+}
+
+func (d *digest) Reset() {
+	d.h = iv
+	d.nx = 0
+	d.len = 0
+}
+
+func (d *digest) Size() int {
+	return Size
+}
+
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+		if d.nx < BlockSize {
+			return n, nil
+		}
+		d.block(d.x[:])
+		d.nx = 0
+	}
+	for len(p) >= BlockSize {
+		d.block(p[:BlockSize])
+		p = p[BlockSize:]
+	}
+	d.nx = copy(d.x[:], p)
+
+	return n, nil
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	// sum of a copy, d keeps writable
+	d0 := *d
+	return d0.checkSum(in)
+}
+
+// checkSum pads the message and appends its checksum to in
+func (d *digest) checkSum(in []byte) []byte {
+	msgLen := d.len
+
+	var pad [BlockSize + 8]byte
+	pad[0] = 0x80
+	// 0x80, zeros, then 64 bits message bit length, ending at a block boundary
+	padLen := BlockSize - (msgLen+8)%BlockSize
+	binary.BigEndian.PutUint64(pad[padLen:], msgLen<<3)
+	d.Write(pad[:padLen+8])
+
+	var sum [Size]byte
+	for i, v := range d.h {
+		binary.BigEndian.PutUint32(sum[i*4:], v)
+	}
+
+	return append(in, sum[:]...)
+}
+
+func p0(x uint32) uint32 {
+	return x ^ bits.RotateLeft32(x, 9) ^ bits.RotateLeft32(x, 17)
+}
+
+func p1(x uint32) uint32 {
+	return x ^ bits.RotateLeft32(x, 15) ^ bits.RotateLeft32(x, 23)
+}
+
+// block compresses one 64 bytes block p into d.h
+func (d *digest) block(p []byte) {
+	var w [68]uint32
+	for j := 0; j < 16; j++ {
+		w[j] = binary.BigEndian.Uint32(p[j*4:])
+	}
+	for j := 16; j < 68; j++ {
+		w[j] = p1(w[j-16]^w[j-9]^bits.RotateLeft32(w[j-3], 15)) ^ bits.RotateLeft32(w[j-13], 7) ^ w[j-6]
+	}
+
+	a, b, c, dd, e, f, g, h := d.h[0], d.h[1], d.h[2], d.h[3], d.h[4], d.h[5], d.h[6], d.h[7]
+	for j := 0; j < 64; j++ {
+		a12 := bits.RotateLeft32(a, 12)
+		ss1 := bits.RotateLeft32(a12+e+tj[j], 7)
+		ss2 := ss1 ^ a12
+
+		var ff, gg uint32
+		if j < 16 {
+			ff = a ^ b ^ c
+			gg = e ^ f ^ g
+		} else {
+			ff = (a & b) | (a & c) | (b & c)
+			gg = (e & f) | (^e & g)
+		}
+
+		tt1 := ff + dd + ss2 + (w[j] ^ w[j+4])
+		tt2 := gg + h + ss1 + w[j]
+		dd = c
+		c = bits.RotateLeft32(b, 9)
+		b = a
+		a = tt1
+		h = g
+		g = bits.RotateLeft32(f, 19)
+		f = e
+		e = p0(tt2)
+	}
+
+	d.h[0] ^= a
+	d.h[1] ^= b
+	d.h[2] ^= c
+	d.h[3] ^= dd
+	d.h[4] ^= e
+	d.h[5] ^= f
+	d.h[6] ^= g
+	d.h[7] ^= h
+}
@@ -0,0 +1,74 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sm3
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSum(t *testing.T) {
+	vectors := []struct {
+		msg    string
+		digest string
+	}{
+		// GB/T 32905-2016 appendix A
+		{"abc", "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+		{strings.Repeat("abcd", 16), "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732"},
+		// padding crossing block boundary
+		{"", "1ab21d8355cfa17f8e61194831e81a8f22bec8c728fefb747ed035eb5082aa2b"},
+		{strings.Repeat("a", 55), "288337eef51eec62e7544d7270424c8dbe656254c99852870a73b2453a6a7fb1"},
+		{strings.Repeat("a", 56), "ba00ebedaab54065a5fd4f9f56326016203166bcee3eed44ea868d59d67aa3c8"},
+		{strings.Repeat("a", 64), "616ec433c359e7c2b19f360e2b8f2a1b6e9ed76b8dc1a7d207b31a5341c611e9"},
+	}
+
+	for _, v := range vectors {
+		sum := Sum([]byte(v.msg))
+		assert.Equal(t, v.digest, hex.EncodeToString(sum[:]), v.msg)
+	}
+}
+
+func TestDigest_Write(t *testing.T) {
+	msg := []byte(strings.Repeat("abcd", 40))
+	sum := Sum(msg)
+
+	// written in pieces, summed in the middle
+	d := New()
+	for i := 0; i < len(msg); i += 7 {
+		end := i + 7
+		if end > len(msg) {
+			end = len(msg)
+		}
+		d.Write(msg[i:end])
+		d.Sum(nil)
+	}
+	assert.Equal(t, sum[:], d.Sum(nil))
+	assert.Equal(t, Size, d.Size())
+	assert.Equal(t, BlockSize, d.BlockSize())
+
+	d.Reset()
+	d.Write([]byte("abc"))
+	assert.Equal(t, "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0", hex.EncodeToString(d.Sum(nil)))
+}
+
+func BenchmarkSum(b *testing.B) {
+	msg := make([]byte, 1024)
+	b.SetBytes(int64(len(msg)))
+
+	for i := 0; i < b.N; i++ {
+		Sum(msg)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sm4
+
+import (
+	"crypto/cipher"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustDecode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestCipher(t *testing.T) {
+	// GB/T 32907-2016 appendix A, example 1
+	key := mustDecode("0123456789abcdeffedcba9876543210")
+	plain := mustDecode("0123456789abcdeffedcba9876543210")
+
+	c, err := NewCipher(key)
+	assert.NoError(t, err)
+	assert.Equal(t, BlockSize, c.BlockSize())
+
+	dst := make([]byte, BlockSize)
+	c.Encrypt(dst, plain)
+	assert.Equal(t, "681edf34d206965e86b3e94f536e4246", hex.EncodeToString(dst))
+
+	c.Decrypt(dst, dst)
+	assert.Equal(t, plain, dst)
+
+	// example 2, encrypted 1000000 times
+	if testing.Short() {
+		t.Skip("skip 1000000 times encryption in short mode")
+	}
+	copy(dst, plain)
+	for i := 0; i < 1000000; i++ {
+		c.Encrypt(dst, dst)
+	}
+	assert.Equal(t, "595298c7c6fd271f0402f804c33d3f66", hex.EncodeToString(dst))
+}
+
+func TestCipher_CBC(t *testing.T) {
+	key := mustDecode("000102030405060708090a0b0c0d0e0f")
+	iv := mustDecode("0f0e0d0c0b0a09080706050403020100")
+	plain := make([]byte, 64)
+	for i := range plain {
+		plain[i] = byte(i)
+	}
+
+	c, err := NewCipher(key)
+	assert.NoError(t, err)
+
+	// same as openssl enc -sm4-cbc -nopad
+	encrypted := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(c, iv).CryptBlocks(encrypted, plain)
+	assert.Equal(t, "139eb7bd4afb46b5e7204c5538aafea4d7a82deddcbab6f87bc7b4e3fdf4a319d6633f9214103a42ecdb62f0197810023e66efd8604b7d7c637b0d60a3365b2a", hex.EncodeToString(encrypted))
+
+	decrypted := make([]byte, len(plain))
+	cipher.NewCBCDecrypter(c, iv).CryptBlocks(decrypted, encrypted)
+	assert.Equal(t, plain, decrypted)
+}
+
+func TestNewCipher(t *testing.T) {
+	_, err := NewCipher(make([]byte, 32))
+	assert.Equal(t, KeySizeError(32), err)
+}
+
+func BenchmarkCipher_Encrypt(b *testing.B) {
+	c, _ := NewCipher(make([]byte, KeySize))
+	block := make([]byte, BlockSize)
+	b.SetBytes(BlockSize)
+
+	for i := 0; i < b.N; i++ {
+		c.Encrypt(block, block)
+	}
+}
@@ -118,6 +118,7 @@ func (db *BadgerDB) NewIterator(bucket, prefix string) (database.Iterator, error
 	return &badgerIterator{
 		txn:    txn,
 		it:     it,
+		bucket: bucket,
 		prefix: prefixBytes,
 	}, nil
 }
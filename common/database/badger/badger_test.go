@@ -162,6 +162,26 @@ func TestBadgerDB_Iterator(t *testing.T) {
 		assert.NoError(t, it.Next())
 	}
 	assert.Equal(t, cnt, 3)
+	it.Close()
+
+	// seek skips the keys before, keys not matching prefix are never reached
+	it, err = db.NewIterator("test", "key")
+	assert.NoError(t, err)
+
+	assert.NoError(t, it.Seek("key2"))
+	assert.True(t, it.HasNext())
+	kv, err := it.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "key2", kv.Key)
+
+	assert.NoError(t, it.Seek("a"))
+	kv, err = it.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "key1", kv.Key)
+
+	assert.NoError(t, it.Seek("key4"))
+	assert.False(t, it.HasNext())
+	it.Close()
 
 	db.Close()
 }
@@ -13,6 +13,8 @@
 package badger
 
 import (
+	"bytes"
+
 	"github.com/dgraph-io/badger"
 	"github.com/mintzhao/topachain/common/database"
 )
@@ -20,6 +22,7 @@ import (
 type badgerIterator struct {
 	txn    *badger.Txn
 	it     *badger.Iterator
+	bucket string
 	prefix []byte
 }
 
@@ -51,6 +54,17 @@ func (iter *badgerIterator) Next() error {
 	return nil
 }
 
+// Seek move pointer to the first matched value whose key isn't less than key
+func (iter *badgerIterator) Seek(key string) error {
+	seekKey := constructCompositeKey(iter.bucket, key)
+	if bytes.Compare(seekKey, iter.prefix) < 0 {
+		seekKey = iter.prefix
+	}
+
+	iter.it.Seek(seekKey)
+	return nil
+}
+
 // Close close iterator
 func (iter *badgerIterator) Close() error {
 	iter.it.Close()
@@ -64,6 +64,9 @@ type Iterator interface {
 	// Next move pointer to next matched value
 	Next() error
 
+	// Seek move pointer to the first matched value whose key isn't less than key
+	Seek(key string) error
+
 	// Close close iterator
 	Close() error
 }
@@ -13,17 +13,29 @@
 package genesis
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
 )
 
-// generate genesis block
-func GenesisBlock(name string, config *types.AppConfig) (*types.Block, error) {
+var (
+	// ErrInvalidGenesisBlock indicates the block isn't a well-formed genesis block
+	ErrInvalidGenesisBlock = errors.New("invalid genesis block")
+
+	// ErrTxrootMismatch indicates recomputed txs hash differs from header's txroot
+	ErrTxrootMismatch = errors.New("txroot mismatch")
+)
+
+// generate genesis block, version is the initial application version, nil accepts any version
+func GenesisBlock(name string, config *types.AppConfig, version *types.AppVersion) (*types.Block, error) {
 	gtxp := &types.GenesisTxProposal{
-		Name:   name,
-		Config: config,
+		Name:    name,
+		Config:  config,
+		Version: version,
 	}
 
 	gtxpBytes, err := proto.Marshal(gtxp)
@@ -65,3 +77,52 @@ func WriteGenesisBlock(blk *types.Block, writer io.Writer) error {
 	_, err = writer.Write(blkBytes)
 	return err
 }
+
+// ReadGenesisBlock reads a genesis block written by WriteGenesisBlock
+func ReadGenesisBlock(reader io.Reader) (*types.Block, error) {
+	blkBytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	blk := new(types.Block)
+	if err := proto.Unmarshal(blkBytes, blk); err != nil {
+		return nil, errors.Wrap(err, "unmarshal genesis block error")
+	}
+
+	return blk, nil
+}
+
+// GenesisProposal returns the GenesisTxProposal carried by genesis block blk
+func GenesisProposal(blk *types.Block) (*types.GenesisTxProposal, error) {
+	if blk.GetHeader().GetBlockHeight() != 0 || len(blk.GetTxs().GetTxs()) != 1 {
+		return nil, ErrInvalidGenesisBlock
+	}
+
+	gtxp := new(types.GenesisTxProposal)
+	if err := proto.Unmarshal(blk.Txs.Txs[0].GetPayload(), gtxp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal genesis tx proposal error")
+	}
+
+	return gtxp, nil
+}
+
+// VerifyGenesisBlock recomputes txs hash of blk with the hash algorithm recorded in its AppConfig,
+// and compares it with header's txroot
+func VerifyGenesisBlock(blk *types.Block) error {
+	gtxp, err := GenesisProposal(blk)
+	if err != nil {
+		return err
+	}
+
+	txroot, err := blk.Txs.Hash(gtxp.GetConfig().GetHash())
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(txroot, blk.Header.GetTxroot()) {
+		return ErrTxrootMismatch
+	}
+
+	return nil
+}
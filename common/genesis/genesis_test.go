@@ -0,0 +1,66 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package genesis
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mintzhao/topachain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadGenesisBlock(t *testing.T) {
+	config := &types.AppConfig{
+		BlockInterval: 2000,
+		BlockTxCount:  10,
+		Hash:          "SHA256",
+	}
+
+	version, err := types.NewAppVersion("1.0.0", "1.0.0")
+	assert.NoError(t, err)
+
+	blk, err := GenesisBlock("test", config, version)
+	assert.NoError(t, err)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, WriteGenesisBlock(blk, buf))
+
+	retblk, err := ReadGenesisBlock(buf)
+	assert.NoError(t, err)
+	assert.True(t, blk.Equal(retblk))
+
+	gtxp, err := GenesisProposal(retblk)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", gtxp.GetName())
+	assert.True(t, config.Equal(gtxp.GetConfig()))
+	assert.True(t, version.Equal(gtxp.GetVersion()))
+
+	_, err = ReadGenesisBlock(bytes.NewBufferString("not a block"))
+	assert.Error(t, err)
+
+	_, err = GenesisProposal(&types.Block{})
+	assert.EqualError(t, err, ErrInvalidGenesisBlock.Error())
+}
+
+func TestVerifyGenesisBlock(t *testing.T) {
+	blk, err := GenesisBlock("test", &types.AppConfig{Hash: "MD5"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyGenesisBlock(blk))
+
+	blk.Header.Txroot = []byte("tampered")
+	assert.EqualError(t, VerifyGenesisBlock(blk), ErrTxrootMismatch.Error())
+
+	_, err = GenesisBlock("test", &types.AppConfig{Hash: "unknown"}, nil)
+	assert.Error(t, err)
+}
@@ -14,13 +14,25 @@ package logging
 
 import (
 	"os"
+	"sync"
 
 	"github.com/op/go-logging"
 )
 
+const (
+	// DefaultLevel is the level of modules not configured
+	DefaultLevel = logging.INFO
+)
+
 var (
 	backend   = logging.NewLogBackend(os.Stderr, "", 0)
 	formatter = logging.MustStringFormatter(`%{color}%{time:15:04:05.000} %{shortfunc} ▶ %{level:.4s} %{id:03x}%{color:reset} %{message}`)
+
+	logger = logging.MustGetLogger("logging")
+
+	// configured records modules whose level set by SetLevels
+	configured = map[string]bool{}
+	mutex      sync.Mutex
 )
 
 func init() {
@@ -28,5 +40,71 @@ func init() {
 	logging.SetBackend(backend)
 
 	// set modules logging level
-	logging.SetLevel(logging.INFO, "")
+	logging.SetLevel(DefaultLevel, "")
+}
+
+// SetLevels sets logging level of modules, levels maps module name to level name, e.g. consensus: DEBUG.
+// Invalid levels are skipped with a warning. Modules set by previous call but missing in levels are reset to DefaultLevel,
+// so it can be called again to apply reloaded config on the fly. Each change is logged.
+func SetLevels(levels map[string]string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for module := range configured {
+		if _, ok := levels[module]; !ok {
+			setLevel(module, DefaultLevel)
+			delete(configured, module)
+		}
+	}
+
+	for module, levelstring := range levels {
+		level, err := logging.LogLevel(levelstring)
+		if err != nil {
+			logger.Warningf("invalid log level: %s", levelstring)
+			continue
+		}
+
+		setLevel(module, level)
+		configured[module] = true
+	}
+}
+
+// SetLevel sets logging level of a single module, the change is logged
+func SetLevel(module, levelstring string) error {
+	level, err := logging.LogLevel(levelstring)
+	if err != nil {
+		return err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	setLevel(module, level)
+	configured[module] = true
+
+	return nil
+}
+
+// Levels returns the level names of modules set by SetLevels or SetLevel
+func Levels() map[string]string {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	levels := make(map[string]string, len(configured))
+	for module := range configured {
+		levels[module] = logging.GetLevel(module).String()
+	}
+
+	return levels
+}
+
+// setLevel sets level of module, logs it if changed
+func setLevel(module string, level logging.Level) {
+	old := logging.GetLevel(module)
+	if old == level {
+		return
+	}
+
+	logging.SetLevel(level, module)
+	logger.Infof("logging level of module %q changed from %s to %s", module, old, level)
 }
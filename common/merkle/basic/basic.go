@@ -15,6 +15,7 @@ package basic
 import (
 	"bytes"
 	"fmt"
+	"hash"
 
 	"github.com/mintzhao/topachain/common/crypto/hasher"
 )
@@ -49,30 +50,56 @@ type node struct {
 	isleaf bool
 }
 
-func (n *node) verify(h hasher.Hasher) ([]byte, error) {
+func (n *node) verify(ph *pairHasher) ([]byte, error) {
 	if n.isleaf {
 		return n.value.Hash()
 	}
 
-	rhash, err := n.right.verify(h)
+	rhash, err := n.right.verify(ph)
 	if err != nil {
 		return nil, err
 	}
 
-	lhash, err := n.left.verify(h)
+	lhash, err := n.left.verify(ph)
 	if err != nil {
 		return nil, err
 	}
 
-	return h.Hash(append(lhash, rhash...))
+	return ph.hash(lhash, rhash)
 }
 
-func (n *node) nodeHash(h hasher.Hasher) ([]byte, error) {
+func (n *node) nodeHash(ph *pairHasher) ([]byte, error) {
 	if n.isleaf {
 		return n.value.Hash()
 	}
 
-	return h.Hash(append(n.left.hash, n.right.hash...))
+	return ph.hash(n.left.hash, n.right.hash)
+}
+
+// pairHasher hashes the hashes of left and right children into their parent's,
+// reusing one hash.Hash if the hasher streams. Not safe for concurrent use.
+type pairHasher struct {
+	h  hasher.Hasher
+	hf hash.Hash
+}
+
+func newPairHasher(h hasher.Hasher) *pairHasher {
+	// nil if h can't stream
+	hf, _ := hasher.NewHash(h)
+
+	return &pairHasher{h: h, hf: hf}
+}
+
+func (ph *pairHasher) hash(lhash, rhash []byte) ([]byte, error) {
+	if ph.hf == nil {
+		msg := make([]byte, 0, len(lhash)+len(rhash))
+		return ph.h.Hash(append(append(msg, lhash...), rhash...))
+	}
+
+	ph.hf.Reset()
+	ph.hf.Write(lhash)
+	ph.hf.Write(rhash)
+	return ph.hf.Sum(nil), nil
 }
 
 type BasicMerkletree struct {
@@ -129,15 +156,14 @@ func initValues(vals []Value) ([]*node, error) {
 	return leafs, nil
 }
 
-func constructTree(leafs []*node, h hasher.Hasher) (*node, error) {
+func constructTree(leafs []*node, ph *pairHasher) (*node, error) {
 	nodes := make([]*node, 0)
 	for i := 0; i < len(leafs); i += 2 {
 		left, right := i, i+1
 		if i+1 == len(leafs) {
 			right = i
 		}
-		lrhashBytes := append(leafs[left].hash, leafs[right].hash...)
-		lrhash, err := h.Hash(lrhashBytes)
+		lrhash, err := ph.hash(leafs[left].hash, leafs[right].hash)
 		if err != nil {
 			return nil, err
 		}
@@ -156,7 +182,7 @@ func constructTree(leafs []*node, h hasher.Hasher) (*node, error) {
 		}
 	}
 
-	return constructTree(nodes, h)
+	return constructTree(nodes, ph)
 }
 
 // Root return merkletree's root hash
@@ -186,7 +212,7 @@ func (t *BasicMerkletree) Reconstruct(valsInterface ...interface{}) error {
 		return err
 	}
 
-	root, err := constructTree(leafs, t.h)
+	root, err := constructTree(leafs, newPairHasher(t.h))
 	if err != nil {
 		return err
 	}
@@ -207,7 +233,7 @@ func (t *BasicMerkletree) values() []Value {
 }
 
 func (t *BasicMerkletree) VerifyRoot() bool {
-	calcroot, err := t.root.verify(t.h)
+	calcroot, err := t.root.verify(newPairHasher(t.h))
 	if err != nil {
 		return false
 	}
@@ -225,6 +251,7 @@ func (t *BasicMerkletree) VerifyValue(exceptedRoot []byte, valInterface interfac
 		return false
 	}
 
+	ph := newPairHasher(t.h)
 	for _, l := range t.leafs {
 		if !l.value.Equals(val) {
 			continue
@@ -232,17 +259,17 @@ func (t *BasicMerkletree) VerifyValue(exceptedRoot []byte, valInterface interfac
 
 		parent := l.parent
 		for parent != nil {
-			rhash, err := parent.right.nodeHash(t.h)
+			rhash, err := parent.right.nodeHash(ph)
 			if err != nil {
 				return false
 			}
 
-			lhash, err := parent.left.nodeHash(t.h)
+			lhash, err := parent.left.nodeHash(ph)
 			if err != nil {
 				return false
 			}
 
-			phash, err := t.h.Hash(append(lhash, rhash...))
+			phash, err := ph.hash(lhash, rhash)
 			if err != nil {
 				return false
 			}
@@ -15,6 +15,7 @@ package basic
 import (
 	"bytes"
 	"crypto/sha256"
+	"fmt"
 	"testing"
 
 	"github.com/mintzhao/topachain/common/crypto/hasher"
@@ -67,6 +68,56 @@ func TestBasicMerkletree(t *testing.T) {
 	assert.NotEqual(t, root1, tree.Root())
 }
 
+// memHasher hashes by MD5 without streaming
+type memHasher struct {
+}
+
+func (h *memHasher) Hash(msg []byte) ([]byte, error) {
+	return (&hasher.MD5Hasher{}).Hash(msg)
+}
+
+func TestBasicMerkletree_Stream(t *testing.T) {
+	// same root whether nodes are hashed streaming or not
+	tree, err := New(testValues, &hasher.MD5Hasher{})
+	assert.NoError(t, err)
+	memTree, err := New(testValues, &memHasher{})
+	assert.NoError(t, err)
+	assert.Equal(t, tree.Root(), memTree.Root())
+
+	assert.Equal(t, true, memTree.VerifyRoot())
+	assert.Equal(t, true, memTree.VerifyValue(memTree.Root(), testvalue("2")))
+}
+
+// benchValues are the values of a block of 1024 txs
+func benchValues() []Value {
+	vals := make([]Value, 1024)
+	for i := range vals {
+		vals[i] = testvalue(fmt.Sprintf("tx-%d", i))
+	}
+
+	return vals
+}
+
+func BenchmarkBasicMerkletree_New_Stream(b *testing.B) {
+	vals := benchValues()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		New(vals, &hasher.SHA256Hasher{})
+	}
+}
+
+func BenchmarkBasicMerkletree_New_Memory(b *testing.B) {
+	vals := benchValues()
+	// hides New, nodes are hashed by Hash of concatenated children
+	h := &struct{ hasher.Hasher }{&hasher.SHA256Hasher{}}
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		New(vals, h)
+	}
+}
+
 func BenchmarkBasicMerkletree_Reconstruct(b *testing.B) {
 	tree, err := New(testValues, &hasher.MD5Hasher{})
 	if err != nil {
@@ -13,18 +13,83 @@
 package config
 
 import (
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/op/go-logging"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
 // Config
 type Config struct {
 	Logging map[string]string
+	Node    *Node
 	Common  *Common
 }
 
+// Node
+type Node struct {
+	Address string
+
+	// AdminAddress is the http listen address of admin endpoints, empty to disable
+	AdminAddress string
+
+	// Applications maps application name to its transport, grpc by default or local.
+	// Local applications are linked into the node binary and served in-process, skipping the network and gRPC framing.
+	// Their payloads are still protobuf encoded in AppMessage, the message both transports share.
+	Applications map[string]string
+
+	// TLS secures the gRPC connections of node, disabled by default
+	TLS *TLS
+
+	// GRPC tunes the gRPC connections of node
+	GRPC *GRPC
+}
+
+// GRPC configures keepalive, message size limits and dialing of gRPC servers and clients
+type GRPC struct {
+	// KeepaliveTime is how long a connection idles before it's pinged, KeepaliveTimeout how long the ping is waited
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+
+	// MaxBlockSize limits the encoded txs of blocks consensus cuts, message size limits are derived from it.
+	// Blocks are limited to fit gRPC default message size if 0.
+	MaxBlockSize uint64
+
+	// DialTimeout bounds the blocking dials, MaxBackoff the delay between reconnections
+	DialTimeout time.Duration
+	MaxBackoff  time.Duration
+
+	// HealthCheckInterval is how often managed connections are pinged, IdleTimeout how long unused ones are kept
+	HealthCheckInterval time.Duration
+	IdleTimeout         time.Duration
+}
+
+// TLS configures the certificates of a gRPC server or client, files are PEM encoded
+type TLS struct {
+	Enabled bool
+
+	// CertFile and KeyFile are the certificate presented to peers
+	CertFile string
+	KeyFile  string
+
+	// RootCAs verify the server certificates, system roots are used if empty
+	RootCAs []string
+
+	// ClientAuth requires clients to present a certificate signed by one of ClientRootCAs
+	ClientAuth    bool
+	ClientRootCAs []string
+
+	// PinnedCerts restricts peers to the certificates of these hex encoded SHA-256 fingerprints, any if empty.
+	// Clients check them against server certificates, servers against client certificates, so servers require ClientAuth.
+	PinnedCerts []string
+
+	// ServerName overrides the host name verified against server certificate
+	ServerName string
+}
+
 // Common
 type Common struct {
 	Crypto   *Crypto
@@ -35,6 +100,9 @@ type Common struct {
 type Crypto struct {
 	Hash string
 	Sign string
+
+	// Cipher encrypts keys at rest, AES or SM4
+	Cipher string
 }
 
 // Database
@@ -48,33 +116,55 @@ type Badger struct {
 	Dir string
 }
 
+const (
+	// EnvPrefix is the prefix of environment variables overriding config values,
+	// e.g. TOPA_COMMON_CRYPTO_HASH overrides common.crypto.hash
+	EnvPrefix = "TOPA"
+)
+
 var (
 	// logger
 	logger = logging.MustGetLogger("config")
-
-	defaults = &Config{}
 )
 
-func Load(configfile string) *Config {
-	if configfile == "" {
-		return defaults
+// Load reads config from configfile, then overrides it by TOPA_* environment variables,
+// values missing in both are taken from Defaults. If configfile is empty, only environment variables are used.
+func Load(configfile string) (*Config, error) {
+	v, err := newViper(configfile)
+	if err != nil {
+		return nil, err
 	}
 
+	return unmarshal(v)
+}
+
+// newViper creates a viper instance merging defaults, configfile and environment variables
+func newViper(configfile string) (*viper.Viper, error) {
 	v := viper.New()
-	v.SetEnvPrefix("TOPA")
-	v.AutomaticEnv()
-	v.SetConfigFile(configfile)
-	replacer := strings.NewReplacer(".", "_")
-	v.SetEnvKeyReplacer(replacer)
-
-	if err := v.ReadInConfig(); err != nil {
-		logger.Panicf("reading config error: %s", err)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	setDefaults(v, "", reflect.ValueOf(Defaults()))
+
+	if configfile != "" {
+		v.SetConfigFile(configfile)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, errors.Wrap(err, "reading config error")
+		}
+		logger.Infof("using config file %s", v.ConfigFileUsed())
 	}
 
+	return v, nil
+}
+
+// unmarshal decodes config from viper instance v
+func unmarshal(v *viper.Viper) (*Config, error) {
 	conf := new(Config)
-	if err := v.Unmarshal(conf); err != nil {
-		logger.Panicf("unmarshal config error: %s", err)
+	if err := v.UnmarshalExact(conf); err != nil {
+		return nil, errors.Wrap(err, "unmarshal config error")
+	}
+	if conf.Logging == nil {
+		conf.Logging = map[string]string{}
 	}
 
-	return conf
+	return conf, nil
 }
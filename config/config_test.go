@@ -0,0 +1,223 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testconfig = `
+logging:
+  consensus: DEBUG
+
+node:
+  address: 127.0.0.1:9024
+
+common:
+  crypto:
+    hash: SHA256
+    sign: ECDSA
+  database:
+    type: badger
+    badger:
+      dir: /tmp/topa
+`
+
+func writeTestConfig(t *testing.T, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "topaconfig")
+	assert.NoError(t, err)
+
+	file := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+
+	return file, func() { os.RemoveAll(dir) }
+}
+
+func TestLoad(t *testing.T) {
+	file, clean := writeTestConfig(t, testconfig)
+	defer clean()
+
+	conf, err := Load(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "DEBUG", conf.Logging["consensus"])
+	assert.Equal(t, "127.0.0.1:9024", conf.Node.Address)
+	assert.Equal(t, "SHA256", conf.Common.Crypto.Hash)
+	assert.Equal(t, "/tmp/topa", conf.Common.Database.Badger.Dir)
+
+	_, err = Load("notexists.yaml")
+	assert.Error(t, err)
+
+	// unknown keys are rejected
+	unknownfile, unknownclean := writeTestConfig(t, "common:\n  crypto:\n    hsah: MD5\n")
+	defer unknownclean()
+	_, err = Load(unknownfile)
+	assert.Error(t, err)
+}
+
+func TestLoadEnv(t *testing.T) {
+	file, clean := writeTestConfig(t, testconfig)
+	defer clean()
+
+	os.Setenv("TOPA_COMMON_CRYPTO_HASH", "MD5")
+	os.Setenv("TOPA_NODE_ADDRESS", "0.0.0.0:9025")
+	os.Setenv("TOPA_NODE_GRPC_DIALTIMEOUT", "5s")
+	defer os.Unsetenv("TOPA_COMMON_CRYPTO_HASH")
+	defer os.Unsetenv("TOPA_NODE_ADDRESS")
+	defer os.Unsetenv("TOPA_NODE_GRPC_DIALTIMEOUT")
+
+	conf, err := Load(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "MD5", conf.Common.Crypto.Hash)
+	assert.Equal(t, "ECDSA", conf.Common.Crypto.Sign)
+	assert.Equal(t, "0.0.0.0:9025", conf.Node.Address)
+	assert.Equal(t, 5*time.Second, conf.Node.GRPC.DialTimeout)
+
+	// without config file
+	conf, err = Load("")
+	assert.NoError(t, err)
+	assert.Equal(t, "MD5", conf.Common.Crypto.Hash)
+	assert.Equal(t, "0.0.0.0:9025", conf.Node.Address)
+}
+
+func TestLoadDefaults(t *testing.T) {
+	// missing sections are filled by defaults
+	file, clean := writeTestConfig(t, "node:\n  address: 127.0.0.1:9024\n")
+	defer clean()
+
+	conf, err := Load(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9024", conf.Node.Address)
+	assert.Equal(t, "SHA256", conf.Common.Crypto.Hash)
+	assert.Equal(t, "ECDSA", conf.Common.Crypto.Sign)
+	assert.Equal(t, "badger", conf.Common.Database.Type)
+	assert.Equal(t, Defaults().Common.Database.Badger.Dir, conf.Common.Database.Badger.Dir)
+	assert.Equal(t, time.Minute, conf.Node.GRPC.KeepaliveTime)
+	assert.Equal(t, 3*time.Second, conf.Node.GRPC.DialTimeout)
+	assert.NotNil(t, conf.Logging)
+}
+
+func TestValidate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "topadata")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := Defaults()
+	conf.Common.Database.Badger.Dir = filepath.Join(dir, "data")
+	assert.NoError(t, conf.Validate())
+
+	// every problem is reported with its path
+	conf.Logging["consensus"] = "VERBOSE"
+	conf.Node.Address = "9024"
+	conf.Node.Applications["kvset"] = "udp"
+	conf.Common.Crypto.Hash = "SHA1024"
+	conf.Common.Crypto.Sign = "DSA"
+	conf.Common.Crypto.Cipher = "DES"
+	err = conf.Validate()
+	assert.Error(t, err)
+
+	problems, ok := err.(ValidationError)
+	assert.True(t, ok)
+	paths := make([]string, len(problems))
+	for i, p := range problems {
+		paths[i] = p.Path
+	}
+	assert.Equal(t, []string{"logging.consensus", "node.address", "node.applications.kvset", "common.crypto.hash", "common.crypto.sign", "common.crypto.cipher"}, paths)
+
+	// unknown database type
+	conf = Defaults()
+	conf.Common.Database.Type = "leveldb"
+	err = conf.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "common.database.type", err.(ValidationError)[0].Path)
+
+	// badger dir is a file
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, ioutil.WriteFile(file, nil, 0644))
+	conf = Defaults()
+	conf.Common.Database.Badger.Dir = filepath.Join(file, "data")
+	err = conf.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "common.database.badger.dir", err.(ValidationError)[0].Path)
+
+	// enabled TLS needs readable files and valid fingerprints
+	conf = Defaults()
+	conf.Common.Database.Badger.Dir = filepath.Join(dir, "data")
+	conf.Node.TLS = &TLS{
+		Enabled:     true,
+		CertFile:    file,
+		KeyFile:     filepath.Join(dir, "missing.key"),
+		ClientAuth:  true,
+		PinnedCerts: []string{"abcd"},
+	}
+	err = conf.Validate()
+	assert.Error(t, err)
+	paths = nil
+	for _, p := range err.(ValidationError) {
+		paths = append(paths, p.Path)
+	}
+	assert.Equal(t, []string{"node.tls.keyfile", "node.tls.clientrootcas", "node.tls.pinnedcerts"}, paths)
+
+	// pinned certificates without client auth are valid for clients, servers refuse them when built
+	conf.Node.TLS = &TLS{
+		Enabled:     true,
+		CertFile:    file,
+		KeyFile:     file,
+		PinnedCerts: []string{strings.Repeat("ab", 32)},
+	}
+	assert.NoError(t, conf.Validate())
+
+	// missing sections
+	conf = &Config{}
+	err = conf.Validate()
+	assert.Error(t, err)
+	assert.Len(t, err.(ValidationError), 2)
+}
+
+func TestWatch(t *testing.T) {
+	file, clean := writeTestConfig(t, testconfig)
+	defer clean()
+
+	assert.Error(t, Watch("", func(*Config) {}))
+
+	changes := make(chan *Config, 1)
+	assert.NoError(t, Watch(file, func(conf *Config) {
+		changes <- conf
+	}))
+	// viper starts watching asynchronously
+	time.Sleep(100 * time.Millisecond)
+
+	// invalid config is not applied
+	assert.NoError(t, ioutil.WriteFile(file, []byte("logging:\n  consensus: VERBOSE\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(file, []byte("logging:\n  consensus: WARNING\n"), 0644))
+
+	// file may be seen truncated before written, wait for the final content
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case conf := <-changes:
+			assert.NotEqual(t, "VERBOSE", conf.Logging["consensus"])
+			if conf.Logging["consensus"] == "WARNING" {
+				return
+			}
+		case <-timeout:
+			t.Fatal("config change not watched")
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mintzhao/topachain/common"
+	"github.com/spf13/viper"
+)
+
+// Defaults returns the config used for every value missing in config file and environment
+func Defaults() *Config {
+	return &Config{
+		Logging: map[string]string{},
+		Node: &Node{
+			Address:      "0.0.0.0:9024",
+			AdminAddress: "127.0.0.1:9025",
+			Applications: map[string]string{},
+			TLS:          &TLS{},
+			GRPC: &GRPC{
+				KeepaliveTime:       time.Minute,
+				KeepaliveTimeout:    20 * time.Second,
+				DialTimeout:         3 * time.Second,
+				MaxBackoff:          time.Minute,
+				HealthCheckInterval: 10 * time.Second,
+				IdleTimeout:         5 * time.Minute,
+			},
+		},
+		Common: &Common{
+			Crypto: &Crypto{
+				Hash:   "SHA256",
+				Sign:   "ECDSA",
+				Cipher: "AES",
+			},
+			Database: &Database{
+				Type: "badger",
+				Badger: &Badger{
+					Dir: filepath.Join(common.BASEDIR, "data"),
+				},
+			},
+		},
+	}
+}
+
+// setDefaults registers every scalar value reachable from struct value rv as viper default,
+// and binds it to its environment variable, so that it can be overridden even if missing in config file
+func setDefaults(v *viper.Viper, prefix string, rv reflect.Value) {
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.ToLower(t.Field(i).Name)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Ptr {
+			fv = fv.Elem()
+		}
+
+		switch fv.Kind() {
+		case reflect.Struct:
+			setDefaults(v, key, fv)
+		case reflect.Map, reflect.Slice:
+			// free form sections can only be set in config file
+		default:
+			v.SetDefault(key, fv.Interface())
+			v.BindEnv(key)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mintzhao/topachain/common/crypto/hasher"
+	"github.com/mintzhao/topachain/common/crypto/signer"
+	"github.com/op/go-logging"
+)
+
+var (
+	// supported database types
+	databaseTypes = map[string]bool{
+		"badger": true,
+	}
+
+	// supported ciphers encrypting keys at rest
+	ciphers = map[string]bool{
+		"AES": true,
+		"SM4": true,
+	}
+
+	// supported application transports
+	transports = map[string]bool{
+		"grpc":  true,
+		"local": true,
+	}
+)
+
+// Problem is a single invalid config value
+type Problem struct {
+	// Path is the config key, e.g. common.crypto.hash
+	Path string
+
+	// Message describes what's wrong
+	Message string
+}
+
+func (p *Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+// ValidationError contains every problem found by Validate
+type ValidationError []*Problem
+
+func (err ValidationError) Error() string {
+	msgs := make([]string, len(err))
+	for i, p := range err {
+		msgs[i] = p.String()
+	}
+
+	return fmt.Sprintf("invalid config: %s", strings.Join(msgs, "; "))
+}
+
+// Validate checks every config value, returns a ValidationError reporting all the problems, nil if valid
+func (c *Config) Validate() error {
+	problems := make(ValidationError, 0)
+	report := func(path, format string, args ...interface{}) {
+		problems = append(problems, &Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// logging
+	modules := make([]string, 0, len(c.Logging))
+	for module := range c.Logging {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	for _, module := range modules {
+		if _, err := logging.LogLevel(c.Logging[module]); err != nil {
+			report("logging."+module, "invalid log level %q", c.Logging[module])
+		}
+	}
+
+	// node
+	if c.Node == nil {
+		report("node", "missing section")
+	} else {
+		if _, _, err := net.SplitHostPort(c.Node.Address); err != nil {
+			report("node.address", "invalid address %q: %s", c.Node.Address, err)
+		}
+		if c.Node.AdminAddress != "" {
+			if _, _, err := net.SplitHostPort(c.Node.AdminAddress); err != nil {
+				report("node.adminaddress", "invalid address %q: %s", c.Node.AdminAddress, err)
+			}
+		}
+
+		apps := make([]string, 0, len(c.Node.Applications))
+		for app := range c.Node.Applications {
+			apps = append(apps, app)
+		}
+		sort.Strings(apps)
+		for _, app := range apps {
+			if !transports[c.Node.Applications[app]] {
+				report("node.applications."+app, "unknown transport %q", c.Node.Applications[app])
+			}
+		}
+
+		if c.Node.TLS != nil && c.Node.TLS.Enabled {
+			validateTLS(c.Node.TLS, "node.tls", report)
+		}
+		if c.Node.GRPC != nil {
+			durations := []struct {
+				name  string
+				value time.Duration
+			}{
+				{"keepalivetime", c.Node.GRPC.KeepaliveTime},
+				{"keepalivetimeout", c.Node.GRPC.KeepaliveTimeout},
+				{"dialtimeout", c.Node.GRPC.DialTimeout},
+				{"maxbackoff", c.Node.GRPC.MaxBackoff},
+				{"healthcheckinterval", c.Node.GRPC.HealthCheckInterval},
+				{"idletimeout", c.Node.GRPC.IdleTimeout},
+			}
+			for _, d := range durations {
+				if d.value < 0 {
+					report("node.grpc."+d.name, "negative duration %s", d.value)
+				}
+			}
+		}
+	}
+
+	// common
+	if c.Common == nil {
+		report("common", "missing section")
+		return problems
+	}
+
+	if c.Common.Crypto == nil {
+		report("common.crypto", "missing section")
+	} else {
+		if _, err := hasher.GetHasher(c.Common.Crypto.Hash); err != nil {
+			report("common.crypto.hash", "unknown hasher %q", c.Common.Crypto.Hash)
+		}
+		if _, err := signer.GetSigner(c.Common.Crypto.Sign); err != nil {
+			report("common.crypto.sign", "unknown signer %q", c.Common.Crypto.Sign)
+		}
+		if !ciphers[c.Common.Crypto.Cipher] {
+			report("common.crypto.cipher", "unknown cipher %q", c.Common.Crypto.Cipher)
+		}
+	}
+
+	if c.Common.Database == nil {
+		report("common.database", "missing section")
+	} else if !databaseTypes[c.Common.Database.Type] {
+		report("common.database.type", "unknown database type %q", c.Common.Database.Type)
+	} else if c.Common.Database.Type == "badger" {
+		if c.Common.Database.Badger == nil {
+			report("common.database.badger", "missing section")
+		} else if err := checkWritableDir(c.Common.Database.Badger.Dir); err != nil {
+			report("common.database.badger.dir", "%s", err)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return problems
+}
+
+// validateTLS checks the files and fingerprints of enabled TLS section at path
+func validateTLS(conf *TLS, path string, report func(path, format string, args ...interface{})) {
+	if err := checkReadableFile(conf.CertFile); err != nil {
+		report(path+".certfile", "%s", err)
+	}
+	if err := checkReadableFile(conf.KeyFile); err != nil {
+		report(path+".keyfile", "%s", err)
+	}
+	for _, file := range conf.RootCAs {
+		if err := checkReadableFile(file); err != nil {
+			report(path+".rootcas", "%s", err)
+		}
+	}
+
+	if conf.ClientAuth && len(conf.ClientRootCAs) == 0 {
+		report(path+".clientrootcas", "client auth requires client root CAs")
+	}
+	for _, file := range conf.ClientRootCAs {
+		if err := checkReadableFile(file); err != nil {
+			report(path+".clientrootcas", "%s", err)
+		}
+	}
+
+	// clients check pinned certificates of servers regardless, servers check those of clients only if they request them
+	if len(conf.PinnedCerts) > 0 && !conf.ClientAuth {
+		logger.Warningf("%s.pinnedcerts: pinned certificates without client auth are only checked by clients, servers refuse them", path)
+	}
+	for _, fingerprint := range conf.PinnedCerts {
+		if b, err := hex.DecodeString(fingerprint); err != nil || len(b) != sha256.Size {
+			report(path+".pinnedcerts", "invalid SHA-256 fingerprint %q", fingerprint)
+		}
+	}
+}
+
+// checkReadableFile checks file exists and is readable
+func checkReadableFile(file string) error {
+	if file == "" {
+		return fmt.Errorf("empty file")
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// checkWritableDir checks dir is writable, or can be created if not exists
+func checkWritableDir(dir string) error {
+	if dir == "" {
+		return fmt.Errorf("empty dir")
+	}
+
+	// find the nearest existing ancestor
+	existing := dir
+	for {
+		fi, err := os.Stat(existing)
+		if err == nil {
+			if !fi.IsDir() {
+				return fmt.Errorf("%s is not a directory", existing)
+			}
+			break
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return err
+		}
+		existing = parent
+	}
+
+	f, err := ioutil.TempFile(existing, ".topa-check")
+	if err != nil {
+		return fmt.Errorf("%s is not writable", existing)
+	}
+	f.Close()
+
+	return os.Remove(f.Name())
+}
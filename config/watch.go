@@ -0,0 +1,71 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package config
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+)
+
+// Watch watches configfile, each time it's changed, the config is reloaded the same way as Load and,
+// if valid, passed to onChange. Only settings safe to apply on the fly (e.g. logging levels) should be
+// applied by onChange, changes of other sections are logged as requiring restart.
+func Watch(configfile string, onChange func(*Config)) error {
+	if configfile == "" {
+		return errors.New("no config file to watch")
+	}
+
+	v, err := newViper(configfile)
+	if err != nil {
+		return err
+	}
+
+	current, err := unmarshal(v)
+	if err != nil {
+		return err
+	}
+
+	var mutex sync.Mutex
+	v.OnConfigChange(func(e fsnotify.Event) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		conf, err := unmarshal(v)
+		if err != nil {
+			logger.Errorf("reload config %s error: %s", e.Name, err)
+			return
+		}
+
+		if err := conf.Validate(); err != nil {
+			logger.Errorf("reload config %s error: %s", e.Name, err)
+			return
+		}
+
+		if !reflect.DeepEqual(conf.Node, current.Node) {
+			logger.Warning("node config changed, restart is required to apply it")
+		}
+		if !reflect.DeepEqual(conf.Common, current.Common) {
+			logger.Warning("common config changed, restart is required to apply it")
+		}
+
+		logger.Infof("config %s reloaded", e.Name)
+		current = conf
+		onChange(conf)
+	})
+	v.WatchConfig()
+
+	return nil
+}
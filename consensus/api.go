@@ -13,17 +13,188 @@
 package consensus
 
 import (
+	"io"
+
+	"github.com/mintzhao/topachain/common/comm"
 	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type consensusapi struct {
+	m *Manager
+}
+
+// NewApplicationServer returns a types.ApplicationServer backed by Manager m
+func NewApplicationServer(m *Manager) types.ApplicationServer {
+	return &consensusapi{m: m}
+}
+
+// Register checks application described by meta can be registered
+func (api *consensusapi) Register(ctx context.Context, meta *types.AppMetadata) (*types.Empty, error) {
+	if err := authorize(ctx, meta.GetName()); err != nil {
+		logger.Warningf("application %s register error: %s", meta.GetName(), err)
+		return nil, toStatus(err)
+	}
+	if err := api.m.CheckVersion(meta); err != nil {
+		logger.Warningf("application %s register error: %s", meta.GetName(), err)
+		return nil, toStatus(err)
+	}
+	if err := api.m.checkStopped(meta.GetName()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &types.Empty{}, nil
+}
+
+func (api *consensusapi) AppStream(stream types.Application_AppStreamServer) error {
+	// first message identifies the application
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	req, err := checkRegister(msg)
+	if err != nil {
+		logger.Warningf("application %s register error: %s", req.GetMeta().GetName(), err)
+		return reject(stream, msg, err)
+	}
+	name := req.GetMeta().GetName()
+	if err := authorize(stream.Context(), name); err != nil {
+		logger.Warningf("application %s register error: %s", name, err)
+		return reject(stream, msg, err)
+	}
+
+	h, err := api.m.attach(req.GetMeta(), req.GetLastHeight(), stream)
+	if err != nil {
+		logger.Warningf("application %s register error: %s", name, err)
+		return reject(stream, msg, err)
+	}
+	defer api.m.detach(h)
+
+	height, err := api.m.height(name)
+	if err != nil {
+		return err
+	}
+
+	ack, err := types.NewAppMessage(nil, types.REGISTER_ACK, msg.GetHeader().GetRequestId(), &types.RegisterAck{
+		ProtocolVersion: types.ProtocolVersion,
+		Height:          height,
+	})
+	if err != nil {
+		return err
+	}
+	if err := h.send(ack); err != nil {
+		return err
+	}
+
+	errc := make(chan error, 3)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				errc <- nil
+				return
+			}
+			if err != nil {
+				errc <- err
+				return
+			}
+
+			logger.Debugf("receive %s message of request %d from application %s", msg.GetHeader().GetType(), msg.GetHeader().GetRequestId(), name)
+			if err := h.receive(msg); err != nil {
+				logger.Warningf("application %s: %s", name, err)
+			}
+		}
+	}()
+	go func() {
+		errc <- h.heartbeat()
+	}()
+	// blocks missed by application are replayed before new ones
+	go func() {
+		if err := api.m.resume(h); err != nil {
+			errc <- err
+		}
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-api.m.done:
+		return nil
+	}
 }
 
-func (api *consensusapi) Register(context.Context, *types.Empty) (*types.Empty, error) {
-	return nil, nil
+// checkRegister checks msg is a valid REGISTER message, returns its payload
+func checkRegister(msg *types.AppMessage) (*types.RegisterRequest, error) {
+	if msg.GetHeader().GetType() != types.REGISTER {
+		return nil, errors.Errorf("expect %s message, got %s", types.REGISTER, msg.GetHeader().GetType())
+	}
+
+	req := new(types.RegisterRequest)
+	if err := msg.DecodePayload(req); err != nil {
+		return nil, err
+	}
+
+	if msg.GetHeader().GetProtocolVersion() != types.ProtocolVersion {
+		return req, errors.Errorf("unsupported protocol version %d, expect %d", msg.GetHeader().GetProtocolVersion(), types.ProtocolVersion)
+	}
+
+	return req, nil
 }
 
-func (api *consensusapi) AppStream(types.Application_AppStreamServer) error {
-	return nil
+// authorize checks the certificate the peer of ctx presented is issued for application,
+// by common name or DNS name. Peers of insecure or server-only TLS connections are not checked.
+func authorize(ctx context.Context, application string) error {
+	cert, err := comm.PeerCertificate(ctx)
+	if err == comm.ErrNoPeerCertificate {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, name := range comm.CertificateNames(cert) {
+		if name == application {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(ErrApplicationUnauthorized, "certificate of %q for application %s", cert.Subject.CommonName, application)
+}
+
+// toStatus converts manager errors to gRPC status errors
+func toStatus(err error) error {
+	switch errors.Cause(err) {
+	case ErrApplicationUnknown, ErrSnapshotNotFound, ErrBlockNotFound, ErrTxNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case ErrApplicationIncompatible, ErrApplicationAhead, ErrUpgradeRequired:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case ErrApplicationAlreadyRegistered:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case ErrApplicationStopped:
+		return status.Error(codes.Unavailable, err.Error())
+	case ErrInvalidUpgrade, ErrApplicationNotRunning:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case ErrApplicationUnauthorized:
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// reject answers REGISTER message msg with ERROR, returns err
+func reject(stream types.Application_AppStreamServer, msg *types.AppMessage, err error) error {
+	resp, merr := types.NewAppMessage(nil, types.ERROR, msg.GetHeader().GetRequestId(), &types.AppError{Message: err.Error()})
+	if merr != nil {
+		return merr
+	}
+
+	if serr := stream.Send(resp); serr != nil {
+		logger.Warningf("send register error response error: %s", serr)
+	}
+
+	return err
 }
@@ -0,0 +1,57 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// peerContext returns a context of gRPC call from peer presenting cert, insecure if cert is nil
+func peerContext(cert *x509.Certificate) context.Context {
+	p := &peer.Peer{}
+	if cert != nil {
+		p.AuthInfo = credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}}
+	}
+
+	return peer.NewContext(context.Background(), p)
+}
+
+func TestAuthorize(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "kvset"},
+		DNSNames: []string{"heights"},
+	}
+
+	// certificate issued for application by common name or DNS name
+	assert.NoError(t, authorize(peerContext(cert), "kvset"))
+	assert.NoError(t, authorize(peerContext(cert), "heights"))
+
+	err := authorize(peerContext(cert), "words")
+	assert.Equal(t, ErrApplicationUnauthorized, errors.Cause(err))
+	assert.Equal(t, codes.PermissionDenied, status.Code(toStatus(err)))
+
+	// insecure peers are not checked
+	assert.NoError(t, authorize(peerContext(nil), "words"))
+	assert.NoError(t, authorize(context.Background(), "words"))
+}
@@ -0,0 +1,186 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// heightKey is the key height of the last delivered block stored under in application's bucket
+	heightKey = "height"
+
+	// blockKeyPrefix prefixes the keys delivered blocks stored under in application's bucket
+	blockKeyPrefix = "block/"
+)
+
+var (
+	// ErrBlockHeight means block delivered out of order
+	ErrBlockHeight = errors.New("unexpected block height")
+
+	// ErrBlockNotFound means block of the height isn't stored
+	ErrBlockNotFound = errors.New("block not found")
+)
+
+// blockStore keeps blocks delivered to application so that they can be replayed to it after reconnection,
+// persisted in application's bucket if db given, or in memory
+type blockStore struct {
+	name    string
+	db      database.Database
+	genesis *types.Block
+
+	mutex  sync.RWMutex
+	last   uint64
+	blocks map[uint64]*types.Block
+}
+
+// loadBlockStore loads the delivered height of application name from db, genesis is its block of height 0
+func loadBlockStore(name string, genesis *types.Block, db database.Database) (*blockStore, error) {
+	bs := &blockStore{
+		name:    name,
+		db:      db,
+		genesis: genesis,
+		blocks:  make(map[uint64]*types.Block),
+	}
+	if db == nil {
+		return bs, nil
+	}
+
+	heightBytes, err := db.Get(name, heightKey)
+	switch err {
+	case nil:
+		bs.last = binary.BigEndian.Uint64(heightBytes)
+	case database.ErrKeyNotFound:
+	default:
+		return nil, err
+	}
+
+	return bs, nil
+}
+
+// height returns height of the last stored block, 0 if none
+func (bs *blockStore) height() uint64 {
+	bs.mutex.RLock()
+	defer bs.mutex.RUnlock()
+
+	return bs.last
+}
+
+// put stores blk, which must follow the last stored block
+func (bs *blockStore) put(blk *types.Block) error {
+	bs.mutex.Lock()
+	defer bs.mutex.Unlock()
+
+	height := blk.GetHeader().GetBlockHeight()
+	if height != bs.last+1 {
+		return errors.Wrapf(ErrBlockHeight, "application %s block %d, expect %d", bs.name, height, bs.last+1)
+	}
+
+	return bs.store(blk)
+}
+
+// reset stores blk as the last block of an empty store, blocks before it are never stored,
+// e.g. application state restored from the snapshot at its height
+func (bs *blockStore) reset(blk *types.Block) error {
+	bs.mutex.Lock()
+	defer bs.mutex.Unlock()
+
+	if bs.last != 0 {
+		return errors.Wrapf(ErrBlockHeight, "application %s has block %d stored", bs.name, bs.last)
+	}
+
+	return bs.store(blk)
+}
+
+// store stores blk as the last block, bs.mutex must be held
+func (bs *blockStore) store(blk *types.Block) error {
+	height := blk.GetHeader().GetBlockHeight()
+	if bs.db == nil {
+		bs.blocks[height] = blk
+		bs.last = height
+		return nil
+	}
+
+	blkBytes, err := proto.Marshal(blk)
+	if err != nil {
+		return err
+	}
+
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+
+	batch, err := bs.db.NewBatch()
+	if err != nil {
+		return err
+	}
+	defer batch.Release()
+
+	if err := batch.Set(bs.name, blockKey(height), blkBytes); err != nil {
+		return err
+	}
+	if err := batch.Set(bs.name, heightKey, heightBytes); err != nil {
+		return err
+	}
+	if err := batch.Commit(); err != nil {
+		return err
+	}
+
+	bs.last = height
+	return nil
+}
+
+// get returns the stored block of height, or the genesis block if height is 0
+func (bs *blockStore) get(height uint64) (*types.Block, error) {
+	if height == 0 {
+		return bs.genesis, nil
+	}
+
+	bs.mutex.RLock()
+	defer bs.mutex.RUnlock()
+
+	if bs.db == nil {
+		blk, ok := bs.blocks[height]
+		if !ok {
+			return nil, errors.Wrapf(ErrBlockNotFound, "application %s block %d", bs.name, height)
+		}
+
+		return blk, nil
+	}
+
+	blkBytes, err := bs.db.Get(bs.name, blockKey(height))
+	if err == database.ErrKeyNotFound {
+		return nil, errors.Wrapf(ErrBlockNotFound, "application %s block %d", bs.name, height)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blk := new(types.Block)
+	if err := proto.Unmarshal(blkBytes, blk); err != nil {
+		return nil, errors.Wrap(err, "unmarshal block error")
+	}
+
+	return blk, nil
+}
+
+// blockKey returns the key block of height stored under
+func blockKey(height uint64) string {
+	return fmt.Sprintf("%s%020d", blockKeyPrefix, height)
+}
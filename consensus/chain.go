@@ -0,0 +1,185 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultBlockInterval is the block interval of applications not configuring one
+	defaultBlockInterval = 2 * time.Second
+)
+
+// chain is the consensus instance of an application, it orders the txs application accepted into blocks on its own,
+// cutting a block once AppConfig.BlockTxCount txs or a full block of txs pending, or every AppConfig.BlockInterval milliseconds
+type chain struct {
+	name   string
+	m      *Manager
+	config *types.AppConfig
+
+	mutex   sync.Mutex
+	pending []*types.Transaction
+	size    uint64
+	full    chan struct{}
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newChain(m *Manager, name string, config *types.AppConfig) *chain {
+	return &chain{
+		name:   name,
+		m:      m,
+		config: config,
+		full:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// start cuts blocks until stop
+func (c *chain) start() {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.run()
+	}()
+}
+
+// stop stops cutting blocks, waits the block being cut delivered, pending txs are dropped
+func (c *chain) stop() {
+	close(c.done)
+	c.wg.Wait()
+}
+
+// add appends tx to the next block
+func (c *chain) add(tx *types.Transaction) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.pending = append(c.pending, tx)
+	c.size += txSize(tx)
+	count := c.config.GetBlockTxCount()
+	if (count > 0 && int64(len(c.pending)) >= count) || c.size >= c.m.maxBlockSize {
+		select {
+		case c.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *chain) run() {
+	interval := time.Duration(c.config.GetBlockInterval()) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultBlockInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.full:
+		case <-c.done:
+			return
+		}
+
+		if err := c.cut(); err != nil {
+			logger.Errorf("application %s cut block error: %s", c.name, err)
+		}
+	}
+}
+
+// cut packs at most BlockTxCount pending txs fitting in a block into the next block and delivers it,
+// no block is cut if none pending
+func (c *chain) cut() error {
+	c.mutex.Lock()
+	txs := c.pending
+	if count := c.config.GetBlockTxCount(); count > 0 && int64(len(txs)) > count {
+		txs = txs[:count]
+	}
+	var size uint64
+	for i, tx := range txs {
+		// a tx never exceeds the limit alone, it's checked on receiving
+		if size+txSize(tx) > c.m.maxBlockSize && i > 0 {
+			txs = txs[:i]
+			break
+		}
+		size += txSize(tx)
+	}
+	c.pending = c.pending[len(txs):]
+	c.size -= size
+	c.mutex.Unlock()
+
+	if len(txs) == 0 {
+		return nil
+	}
+
+	blk, err := c.next(txs)
+	if err != nil {
+		return err
+	}
+
+	// block is kept and replayed once application registers, or is upgraded
+	if _, err := c.m.DeliverBlock(c.name, blk); err != nil && err != ErrApplicationUnregistered && errors.Cause(err) != ErrUpgradeRequired {
+		return err
+	}
+
+	logger.Debugf("application %s block %d cut with %d txs", c.name, blk.GetHeader().GetBlockHeight(), len(txs))
+	return nil
+}
+
+// txSize returns the bytes tx takes in the encoded block txs
+func txSize(tx *types.Transaction) uint64 {
+	size := tx.Size()
+	return uint64(1 + proto.SizeVarint(uint64(size)) + size)
+}
+
+// next makes the block of txs following the last delivered one
+func (c *chain) next(txs []*types.Transaction) (*types.Block, error) {
+	app, err := c.m.getApplication(c.name)
+	if err != nil {
+		return nil, err
+	}
+
+	blkTxs := &types.BlockTxs{Txs: txs}
+	txroot, err := blkTxs.Hash(c.config.GetHash())
+	if err != nil {
+		return nil, errors.Wrap(err, "hash block txs error")
+	}
+
+	header := &types.BlockHeader{
+		BlockHeight: app.blocks.height() + 1,
+		Txroot:      txroot,
+	}
+
+	// block 1 follows the genesis block
+	prev, err := app.blocks.get(header.BlockHeight - 1)
+	if err != nil {
+		return nil, err
+	}
+	if header.PreviousBlock, err = prev.GetHeader().Hash(c.config.GetHash()); err != nil {
+		return nil, errors.Wrap(err, "hash previous block error")
+	}
+
+	return &types.Block{
+		Header: header,
+		Txs:    blkTxs,
+	}, nil
+}
@@ -0,0 +1,351 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// eventKeyPrefix prefixes the keys tx events stored under in application's bucket
+	eventKeyPrefix = "event/"
+
+	// txKeyPrefix prefixes the keys indexing heights of txs emitting events in application's bucket,
+	// tx id is the payload hash, so the same payload may be in blocks of different heights
+	txKeyPrefix = "tx/"
+
+	// subscriptionBuffer is how many events buffered for a subscriber
+	subscriptionBuffer = 256
+)
+
+var (
+	// ErrTxNotFound means no events indexed for the tx
+	ErrTxNotFound = errors.New("tx not found")
+
+	// ErrSubscriptionOverflow means subscriber doesn't keep up with the events
+	ErrSubscriptionOverflow = errors.New("subscription overflow")
+)
+
+// eventStore indexes the events emitted by txs of application by block height and tx id,
+// persisted in application's bucket if db given, or in memory
+type eventStore struct {
+	name string
+	db   database.Database
+
+	mutex     sync.RWMutex
+	events    map[uint64][]*types.TxEvent
+	txHeights map[string][]uint64
+}
+
+func newEventStore(name string, db database.Database) *eventStore {
+	return &eventStore{
+		name:      name,
+		db:        db,
+		events:    make(map[uint64][]*types.TxEvent),
+		txHeights: make(map[string][]uint64),
+	}
+}
+
+// put indexes events of the block at height, in tx order
+func (es *eventStore) put(height uint64, events []*types.TxEvent) error {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	if es.db == nil {
+		es.events[height] = events
+		for _, event := range events {
+			heights := es.txHeights[event.GetTxId()]
+			if len(heights) == 0 || heights[len(heights)-1] != height {
+				es.txHeights[event.GetTxId()] = append(heights, height)
+			}
+		}
+		return nil
+	}
+
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+
+	batch, err := es.db.NewBatch()
+	if err != nil {
+		return err
+	}
+	defer batch.Release()
+
+	for i, event := range events {
+		eventBytes, err := proto.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(es.name, eventKey(height, i), eventBytes); err != nil {
+			return err
+		}
+		if err := batch.Set(es.name, txKey(event.GetTxId(), height), heightBytes); err != nil {
+			return err
+		}
+	}
+
+	return batch.Commit()
+}
+
+// block returns events of the block at height in tx order
+func (es *eventStore) block(height uint64) ([]*types.TxEvent, error) {
+	es.mutex.RLock()
+	defer es.mutex.RUnlock()
+
+	if es.db == nil {
+		return es.events[height], nil
+	}
+
+	it, err := es.db.NewIterator(es.name, fmt.Sprintf("%s%020d/", eventKeyPrefix, height))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var events []*types.TxEvent
+	for it.HasNext() {
+		kv, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		event := new(types.TxEvent)
+		if err := proto.Unmarshal(kv.Value, event); err != nil {
+			return nil, errors.Wrap(err, "unmarshal event error")
+		}
+		events = append(events, event)
+
+		if err := it.Next(); err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// tx returns events of tx txID, of all the blocks it's in by height
+func (es *eventStore) tx(txID string) ([]*types.TxEvent, error) {
+	heights, err := es.heights(txID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*types.TxEvent
+	for _, height := range heights {
+		blockEvents, err := es.block(height)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range blockEvents {
+			if event.GetTxId() == txID {
+				events = append(events, event)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// heights returns heights of the blocks tx txID is in, in ascending order
+func (es *eventStore) heights(txID string) ([]uint64, error) {
+	es.mutex.RLock()
+	defer es.mutex.RUnlock()
+
+	var heights []uint64
+	if es.db == nil {
+		heights = append(heights, es.txHeights[txID]...)
+	} else {
+		it, err := es.db.NewIterator(es.name, txKeyPrefix+txID+"/")
+		if err != nil {
+			return nil, err
+		}
+		defer it.Close()
+
+		for it.HasNext() {
+			kv, err := it.Value()
+			if err != nil {
+				return nil, err
+			}
+			heights = append(heights, binary.BigEndian.Uint64(kv.Value))
+
+			if err := it.Next(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(heights) == 0 {
+		return nil, errors.Wrapf(ErrTxNotFound, "application %s tx %s", es.name, txID)
+	}
+
+	return heights, nil
+}
+
+// eventKey returns the key the index one of the events at height stored under
+func eventKey(height uint64, index int) string {
+	return fmt.Sprintf("%s%020d/%010d", eventKeyPrefix, height, index)
+}
+
+// txKey returns the key indexing tx txID is in the block at height
+func txKey(txID string, height uint64) string {
+	return fmt.Sprintf("%s%s/%020d", txKeyPrefix, txID, height)
+}
+
+// Subscription receives the events matching its filter, until cancelled or overflowed
+type Subscription struct {
+	filter *types.EventFilter
+	events chan *types.TxEvent
+	done   chan struct{}
+	once   sync.Once
+	err    error
+	m      *Manager
+}
+
+// Events returns the channel matched events delivered to
+func (s *Subscription) Events() <-chan *types.TxEvent {
+	return s.events
+}
+
+// Done is closed once s ended, Err tells why
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns ErrSubscriptionOverflow if s ended for not keeping up with the events, nil if cancelled
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Cancel ends s
+func (s *Subscription) Cancel() {
+	s.end(nil)
+}
+
+func (s *Subscription) end(err error) {
+	s.once.Do(func() {
+		s.err = err
+		s.m.subscriptions.Delete(s)
+		close(s.done)
+	})
+}
+
+// Subscribe subscribes the events matching filter emitted from now on
+func (m *Manager) Subscribe(filter *types.EventFilter) *Subscription {
+	s := &Subscription{
+		filter: filter,
+		events: make(chan *types.TxEvent, subscriptionBuffer),
+		done:   make(chan struct{}),
+		m:      m,
+	}
+	m.subscriptions.Store(s, struct{}{})
+
+	return s
+}
+
+// publish delivers events to the matching subscribers, subscriber not keeping up is ended
+func (m *Manager) publish(events []*types.TxEvent) {
+	m.subscriptions.Range(func(key, _ interface{}) bool {
+		s := key.(*Subscription)
+		for _, event := range events {
+			if !s.filter.Match(event) {
+				continue
+			}
+
+			select {
+			case s.events <- event:
+			case <-s.done:
+				return true
+			default:
+				logger.Warningf("subscription of %v overflowed", s.filter)
+				s.end(ErrSubscriptionOverflow)
+				return true
+			}
+		}
+		return true
+	})
+}
+
+// Events returns the indexed events of tx txID of application in all blocks it's in, or of its block at height if txID empty
+func (m *Manager) Events(application string, height uint64, txID string) ([]*types.TxEvent, error) {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return nil, err
+	}
+
+	if txID != "" {
+		return app.events.tx(txID)
+	}
+
+	return app.events.block(height)
+}
+
+type eventsapi struct {
+	m *Manager
+}
+
+// NewEventsServer returns a types.EventsServer serving the events of Manager m
+func NewEventsServer(m *Manager) types.EventsServer {
+	return &eventsapi{m: m}
+}
+
+// Subscribe streams the matching events until client leaves or it doesn't keep up
+func (api *eventsapi) Subscribe(filter *types.EventFilter, stream types.Events_SubscribeServer) error {
+	if filter.GetApplication() != "" {
+		if _, err := api.m.getApplication(filter.GetApplication()); err != nil {
+			return toStatus(err)
+		}
+	}
+
+	s := api.m.Subscribe(filter)
+	defer s.Cancel()
+
+	for {
+		select {
+		case event := <-s.Events():
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-s.Done():
+			return status.Error(codes.ResourceExhausted, s.Err().Error())
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-api.m.done:
+			return nil
+		}
+	}
+}
+
+func (api *eventsapi) GetEvents(ctx context.Context, req *types.GetEventsRequest) (*types.GetEventsResponse, error) {
+	events, err := api.m.Events(req.GetApplication(), req.GetHeight(), req.GetTxId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &types.GetEventsResponse{Events: events}, nil
+}
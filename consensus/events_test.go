@@ -0,0 +1,162 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/common/database/badger"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testEvent(height uint64, txID string, txIndex uint32, typ string) *types.TxEvent {
+	return &types.TxEvent{
+		Application: "heights",
+		Height:      height,
+		TxId:        txID,
+		TxIndex:     txIndex,
+		Event:       types.NewEvent(typ, map[string]string{"tx": txID}),
+	}
+}
+
+func TestEventStore(t *testing.T) {
+	os.Mkdir(testdbdir, os.ModePerm)
+	defer os.RemoveAll(testdbdir)
+
+	db, err := badger.New(testdbdir)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	for _, db := range []database.Database{nil, db} {
+		es := newEventStore("heights", db)
+		events := []*types.TxEvent{
+			testEvent(3, "aa", 0, "transfer"),
+			testEvent(3, "aa", 0, "fee"),
+			testEvent(3, "bb", 1, "transfer"),
+		}
+		assert.NoError(t, es.put(3, events))
+
+		blockEvents, err := es.block(3)
+		assert.NoError(t, err)
+		assert.Equal(t, events, blockEvents)
+
+		blockEvents, err = es.block(4)
+		assert.NoError(t, err)
+		assert.Empty(t, blockEvents)
+
+		txEvents, err := es.tx("aa")
+		assert.NoError(t, err)
+		assert.Equal(t, events[:2], txEvents)
+
+		// tx id is the payload hash, the same payload in a later block is indexed as well
+		later := []*types.TxEvent{
+			testEvent(5, "bb", 0, "transfer"),
+			testEvent(5, "aa", 1, "transfer"),
+		}
+		assert.NoError(t, es.put(5, later))
+
+		txEvents, err = es.tx("aa")
+		assert.NoError(t, err)
+		assert.Equal(t, []*types.TxEvent{events[0], events[1], later[1]}, txEvents)
+
+		txEvents, err = es.tx("bb")
+		assert.NoError(t, err)
+		assert.Equal(t, []*types.TxEvent{events[2], later[0]}, txEvents)
+
+		_, err = es.tx("cc")
+		assert.Equal(t, ErrTxNotFound, errors.Cause(err))
+	}
+}
+
+func TestManager_Subscribe(t *testing.T) {
+	m := NewManager()
+	defer m.Stop()
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "heights"}), nil))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := lis.Addr().String()
+	lis.Close()
+
+	server := serveManager(t, m, address)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+	cli := types.NewEventsClient(conn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unknown, err := cli.Subscribe(ctx, &types.EventFilter{Application: "unknown"})
+	assert.NoError(t, err)
+	_, err = unknown.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err := cli.Subscribe(ctx, &types.EventFilter{
+		Application: "heights",
+		Type:        "transfer",
+		Attributes:  []*types.EventAttribute{{Key: "tx", Value: "bb"}},
+	})
+	assert.NoError(t, err)
+
+	// wait the subscription made by server
+	for i := 0; i < 100 && subscriptions(m) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	events := []*types.TxEvent{
+		testEvent(3, "aa", 0, "transfer"),
+		testEvent(3, "bb", 1, "fee"),
+		testEvent(3, "bb", 1, "transfer"),
+	}
+	m.publish(events)
+
+	event, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.True(t, events[2].Equal(event))
+
+	// published events are not indexed, only the delivered ones
+	_, err = cli.GetEvents(ctx, &types.GetEventsRequest{Application: "heights", TxId: "aa"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// subscriber not keeping up is ended
+	s := m.Subscribe(&types.EventFilter{})
+	for i := 0; i <= subscriptionBuffer; i++ {
+		m.publish(events[:1])
+	}
+	<-s.Done()
+	assert.Equal(t, ErrSubscriptionOverflow, s.Err())
+}
+
+// subscriptions returns how many subscriptions m has
+func subscriptions(m *Manager) int {
+	count := 0
+	m.subscriptions.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+
+	return count
+}
@@ -0,0 +1,190 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// heartbeatInterval is the interval of heartbeats sent to application
+	heartbeatInterval = 10 * time.Second
+
+	// heartbeatTimeout is how long to wait for application's heartbeat response
+	heartbeatTimeout = 5 * time.Second
+
+	// responseTimeout is how long to wait for application's response to other requests,
+	// block delivery holding the application mutex never waits a hung application forever
+	responseTimeout = time.Minute
+)
+
+var (
+	// ErrApplicationDetached means application stream closed while waiting its response
+	ErrApplicationDetached = errors.New("application detached")
+
+	// ErrRequestTimeout means application doesn't respond in time
+	ErrRequestTimeout = errors.New("request timeout")
+)
+
+// handler talks with a registered application over its stream,
+// each request is answered by a response of the same type and request id, or ERROR.
+type handler struct {
+	core   ConsensusCore
+	meta   *types.AppMetadata
+	stream types.Application_AppStreamServer
+
+	// height of the last block application committed, guarded by application mutex
+	height uint64
+
+	sendMutex sync.Mutex
+
+	mutex   sync.Mutex
+	lastID  uint64
+	pending map[uint64]chan *types.AppMessage
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newHandler(meta *types.AppMetadata, height uint64, stream types.Application_AppStreamServer) *handler {
+	return &handler{
+		meta:    meta,
+		height:  height,
+		stream:  stream,
+		pending: make(map[uint64]chan *types.AppMessage),
+		done:    make(chan struct{}),
+	}
+}
+
+// request sends a typ request with payload req to application, decodes its response into resp,
+// failing with ErrRequestTimeout if no response in responseTimeout
+func (h *handler) request(typ types.AppMessageType, req, resp proto.Message) error {
+	return h.requestTimeout(typ, req, resp, responseTimeout)
+}
+
+// requestTimeout is request failing with ErrRequestTimeout if no response in timeout, 0 means no timeout
+func (h *handler) requestTimeout(typ types.AppMessageType, req, resp proto.Message, timeout time.Duration) error {
+	id, respc := h.newRequest()
+	defer h.removeRequest(id)
+
+	msg, err := types.NewAppMessage(nil, typ, id, req)
+	if err != nil {
+		return err
+	}
+
+	if err := h.send(msg); err != nil {
+		return err
+	}
+
+	var timeoutc <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutc = timer.C
+	}
+
+	select {
+	case respMsg := <-respc:
+		switch respMsg.GetHeader().GetType() {
+		case typ:
+			return respMsg.DecodePayload(resp)
+		case types.ERROR:
+			appErr := new(types.AppError)
+			if err := respMsg.DecodePayload(appErr); err != nil {
+				return err
+			}
+
+			return errors.New(appErr.GetMessage())
+		default:
+			return errors.Errorf("unexpected %s response to %s request", respMsg.GetHeader().GetType(), typ)
+		}
+	case <-timeoutc:
+		return ErrRequestTimeout
+	case <-h.done:
+		return ErrApplicationDetached
+	}
+}
+
+// send sends msg on stream, which isn't safe for concurrent sends
+func (h *handler) send(msg *types.AppMessage) error {
+	h.sendMutex.Lock()
+	defer h.sendMutex.Unlock()
+
+	return h.stream.Send(msg)
+}
+
+// newRequest allocates a request id and the channel its response delivered to
+func (h *handler) newRequest() (uint64, chan *types.AppMessage) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.lastID++
+	respc := make(chan *types.AppMessage, 1)
+	h.pending[h.lastID] = respc
+
+	return h.lastID, respc
+}
+
+func (h *handler) removeRequest(id uint64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delete(h.pending, id)
+}
+
+// receive passes a message received from application to the pending request of the same id
+func (h *handler) receive(msg *types.AppMessage) error {
+	h.mutex.Lock()
+	respc, ok := h.pending[msg.GetHeader().GetRequestId()]
+	h.mutex.Unlock()
+	if !ok {
+		return errors.Errorf("unexpected %s message of request %d", msg.GetHeader().GetType(), msg.GetHeader().GetRequestId())
+	}
+
+	// a duplicated response is dropped
+	select {
+	case respc <- msg:
+	default:
+	}
+
+	return nil
+}
+
+// heartbeat checks application is alive every heartbeatInterval until closed, returns error if it's not
+func (h *handler) heartbeat() error {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := h.requestTimeout(types.HEARTBEAT, &types.Heartbeat{}, &types.Heartbeat{}, heartbeatTimeout); err != nil {
+				return errors.Wrap(err, "heartbeat error")
+			}
+		case <-h.done:
+			return nil
+		}
+	}
+}
+
+// close fails pending and further requests, it's safe to call more than once
+func (h *handler) close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+}
@@ -0,0 +1,165 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AppIdle is the state of application added but not started, blocks are delivered by caller
+	AppIdle = "idle"
+
+	// AppRunning is the state of application whose consensus instance orders its txs into blocks
+	AppRunning = "running"
+
+	// AppStopped is the state of application stopped, it neither registers nor gets blocks
+	AppStopped = "stopped"
+)
+
+// AppStatus reports the state of an application hosted by manager
+type AppStatus struct {
+	Name       string   `json:"name"`
+	State      string   `json:"state"`
+	Registered bool     `json:"registered"`
+	Version    string   `json:"version,omitempty"`
+	Height     uint64   `json:"height"`
+	Snapshots  []uint64 `json:"snapshots,omitempty"`
+
+	// Upgrade is the latest upgrade scheduled, nil if none
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+}
+
+// StartApplication starts the consensus instance of application, which orders the txs it accepted into blocks.
+// A stopped application is allowed to register and get blocks again.
+func (m *Manager) StartApplication(application string) error {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return err
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	app.stopped = false
+	if app.chain == nil {
+		app.chain = newChain(m, application, app.config)
+		app.chain.start()
+		logger.Infof("application %s started", application)
+	}
+
+	return nil
+}
+
+// StopApplication stops the consensus instance of application and unregisters it,
+// it's rejected to register until started again. Other applications are not affected.
+// It returns once the block being delivered is done.
+func (m *Manager) StopApplication(application string) error {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return err
+	}
+
+	// requests to a hung application fail once detached, releasing app.mutex held by delivery
+	m.detachApplication(application)
+
+	app.mutex.Lock()
+	app.stopped = true
+	c := app.chain
+	app.chain = nil
+	app.mutex.Unlock()
+
+	// registered before stopped
+	m.detachApplication(application)
+
+	// chain delivering a block needs app.mutex
+	if c != nil {
+		c.stop()
+	}
+
+	logger.Infof("application %s stopped", application)
+	return nil
+}
+
+// detachApplication detaches the stream of application if registered
+func (m *Manager) detachApplication(application string) {
+	if h, err := m.getHandler(application); err == nil {
+		m.detach(h)
+	}
+}
+
+// checkStopped returns ErrApplicationStopped if application is stopped
+func (m *Manager) checkStopped(application string) error {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return err
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.stopped {
+		return errors.Wrapf(ErrApplicationStopped, "application %s", application)
+	}
+
+	return nil
+}
+
+// Applications returns names of the added applications in order
+func (m *Manager) Applications() []string {
+	var names []string
+	m.applications.Range(func(name, _ interface{}) bool {
+		names = append(names, name.(string))
+		return true
+	})
+	sort.Strings(names)
+
+	return names
+}
+
+// Status reports the state of application
+func (m *Manager) Status(application string) (*AppStatus, error) {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &AppStatus{Name: application}
+
+	app.mutex.Lock()
+	switch {
+	case app.stopped:
+		status.State = AppStopped
+	case app.chain != nil:
+		status.State = AppRunning
+	default:
+		status.State = AppIdle
+	}
+	status.Height = app.blocks.height()
+	app.mutex.Unlock()
+
+	if h, err := m.getHandler(application); err == nil {
+		status.Registered = true
+		if version := h.meta.GetVersion(); version != nil {
+			status.Version = version.Text()
+		}
+	}
+	for _, snapshot := range app.snapshots.list() {
+		status.Snapshots = append(status.Snapshots, snapshot.GetHeight())
+	}
+	status.Upgrade = upgradeStatus(app, status.Height)
+
+	return status, nil
+}
@@ -0,0 +1,279 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	appsdk "github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/common/crypto"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_MultiApplications(t *testing.T) {
+	m := NewManager()
+	defer m.Stop()
+
+	config := &types.AppConfig{BlockInterval: 50, BlockTxCount: 2}
+	for _, name := range []string{"beta", "alpha"} {
+		assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: name, Config: config}), nil))
+	}
+	assert.Equal(t, []string{"alpha", "beta"}, m.Applications())
+
+	status, err := m.Status("alpha")
+	assert.NoError(t, err)
+	assert.Equal(t, AppIdle, status.State)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	alpha, beta := &heights{name: "alpha"}, &heights{name: "beta"}
+	for _, app := range []*heights{alpha, beta} {
+		assert.NoError(t, m.StartApplication(app.name))
+		go appsdk.RunWith(ctx, app, NewLocalClient(m))
+		waitRegistered(t, m, app.name, true)
+	}
+
+	// each application has its own block sequence, cut by its own consensus instance
+	for _, tx := range []string{"a1", "a2", "a3", "a4"} {
+		_, err := m.ReceiveTxSync("alpha", []byte(tx))
+		assert.NoError(t, err)
+	}
+	_, err = m.ReceiveTxSync("beta", []byte("b1"))
+	assert.NoError(t, err)
+
+	waitHeight(t, m, "alpha", 2)
+	waitHeight(t, m, "beta", 1)
+	assert.Equal(t, []uint64{1, 2}, alpha.heights())
+	assert.Equal(t, []uint64{1}, beta.heights())
+
+	blk, err := m.GetBlock("alpha", 2)
+	assert.NoError(t, err)
+	assert.Len(t, blk.GetTxs().GetTxs(), 2)
+	prevHash, err := m.BlockHash("alpha", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, prevHash, blk.GetHeader().GetPreviousBlock())
+	txroot, err := blk.GetTxs().Hash("")
+	assert.NoError(t, err)
+	assert.Equal(t, txroot, blk.GetHeader().GetTxroot())
+
+	// the first block follows the genesis block
+	blk, err = m.GetBlock("beta", 1)
+	assert.NoError(t, err)
+	genesisHash, err := m.BlockHash("beta", 0)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, genesisHash)
+	assert.Equal(t, genesisHash, blk.GetHeader().GetPreviousBlock())
+
+	status, err = m.Status("alpha")
+	assert.NoError(t, err)
+	assert.Equal(t, &AppStatus{Name: "alpha", State: AppRunning, Registered: true, Height: 2}, status)
+
+	// stopping one application leaves the other running
+	assert.NoError(t, m.StopApplication("beta"))
+	waitRegistered(t, m, "beta", false)
+	status, err = m.Status("beta")
+	assert.NoError(t, err)
+	assert.Equal(t, AppStopped, status.State)
+	assert.False(t, status.Registered)
+
+	_, err = m.DeliverBlock("beta", testBlock(2))
+	assert.Equal(t, ErrApplicationStopped, errors.Cause(err))
+
+	for _, tx := range []string{"a5", "a6"} {
+		_, err := m.ReceiveTxSync("alpha", []byte(tx))
+		assert.NoError(t, err)
+	}
+	waitHeight(t, m, "alpha", 3)
+
+	// restarted application registers again and continues its own sequence
+	assert.NoError(t, m.StartApplication("beta"))
+	waitRegistered(t, m, "beta", true)
+	_, err = m.ReceiveTxSync("beta", []byte("b2"))
+	assert.NoError(t, err)
+	waitHeight(t, m, "beta", 2)
+	assert.Equal(t, []uint64{1, 2}, beta.heights())
+
+	_, err = m.Status("gamma")
+	assert.Equal(t, ErrApplicationUnknown, err)
+}
+
+// hung is an application hanging in DeliverTx until released
+type hung struct {
+	*heights
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (h *hung) DeliverTx(tx *types.Transaction) error {
+	select {
+	case h.entered <- struct{}{}:
+	default:
+	}
+	<-h.release
+	return nil
+}
+
+// startHung starts a hung application, returns once it hangs delivering a block
+func startHung(t *testing.T, ctx context.Context, m *Manager) *hung {
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "hung", Config: &types.AppConfig{BlockInterval: 50}}), nil))
+	assert.NoError(t, m.StartApplication("hung"))
+
+	app := &hung{heights: &heights{name: "hung"}, entered: make(chan struct{}, 1), release: make(chan struct{})}
+	go appsdk.RunWith(ctx, app, NewLocalClient(m))
+	waitRegistered(t, m, "hung", true)
+
+	_, err := m.ReceiveTxSync("hung", []byte("tx"))
+	assert.NoError(t, err)
+	select {
+	case <-app.entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("block not delivered")
+	}
+
+	return app
+}
+
+// returnsIn fails t if fn doesn't return in time
+func returnsIn(t *testing.T, fn func()) {
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not returned in time")
+	}
+}
+
+func TestManager_StopHungApplication(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := NewManager()
+	defer m.Stop()
+	app := startHung(t, ctx, m)
+	defer close(app.release)
+
+	returnsIn(t, func() {
+		assert.NoError(t, m.StopApplication("hung"))
+	})
+	status, err := m.Status("hung")
+	assert.NoError(t, err)
+	assert.Equal(t, AppStopped, status.State)
+	assert.False(t, status.Registered)
+}
+
+func TestManager_Stop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := NewManager()
+	app := startHung(t, ctx, m)
+	defer close(app.release)
+
+	// chains are stopped and deliveries done once Stop returns
+	returnsIn(t, m.Stop)
+	a, err := m.getApplication("hung")
+	assert.NoError(t, err)
+	a.mutex.Lock()
+	assert.Nil(t, a.chain)
+	a.mutex.Unlock()
+
+	_, err = m.DeliverBlock("hung", &types.Block{})
+	assert.Equal(t, ErrApplicationStopped, errors.Cause(err))
+}
+
+func TestManager_MaxBlockSize(t *testing.T) {
+	m := NewManager()
+	defer m.Stop()
+
+	// blocks of two txs of 10 bytes payload at most, cut once full
+	m.SetMaxBlockSize(30)
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "sized", Config: &types.AppConfig{BlockInterval: 60000}}), nil))
+	assert.NoError(t, m.StartApplication("sized"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go appsdk.RunWith(ctx, &heights{name: "sized"}, NewLocalClient(m))
+	waitRegistered(t, m, "sized", true)
+
+	_, err := m.ReceiveTxSync("sized", make([]byte, 40))
+	assert.Equal(t, ErrTxTooLarge, errors.Cause(err))
+
+	for _, tx := range []string{"tx00000001", "tx00000002", "tx00000003"} {
+		_, err := m.ReceiveTxSync("sized", []byte(tx))
+		assert.NoError(t, err)
+	}
+	waitHeight(t, m, "sized", 1)
+
+	// the tx left pending is cut with the next ones
+	for _, tx := range []string{"tx00000004", "tx00000005"} {
+		_, err := m.ReceiveTxSync("sized", []byte(tx))
+		assert.NoError(t, err)
+	}
+	waitHeight(t, m, "sized", 2)
+
+	for _, height := range []uint64{1, 2} {
+		blk, err := m.GetBlock("sized", height)
+		assert.NoError(t, err)
+		assert.Len(t, blk.GetTxs().GetTxs(), 2)
+		assert.True(t, uint64(blk.GetTxs().Size()) <= 30)
+	}
+}
+
+func TestManager_ReceiveTxNotRunning(t *testing.T) {
+	m := NewManager()
+	defer m.Stop()
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "idle"}), nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go appsdk.RunWith(ctx, &heights{name: "idle"}, NewLocalClient(m))
+	waitRegistered(t, m, "idle", true)
+
+	// registered application without consensus instance can't take txs
+	_, err := m.ReceiveTxSync("idle", []byte("tx"))
+	assert.Equal(t, ErrApplicationNotRunning, errors.Cause(err))
+
+	assert.NoError(t, m.StartApplication("idle"))
+	_, err = m.ReceiveTxSync("idle", []byte("tx"))
+	assert.NoError(t, err)
+}
+
+func TestManager_TxID(t *testing.T) {
+	m := NewManager()
+	defer m.Stop()
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "sha3", Config: &types.AppConfig{Hash: "SHA3-256", BlockInterval: 60000}}), nil))
+	assert.NoError(t, m.StartApplication("sha3"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go appsdk.RunWith(ctx, &heights{name: "sha3"}, NewLocalClient(m))
+	waitRegistered(t, m, "sha3", true)
+
+	// tx id is hashed by the hasher of application, as its blocks are
+	resp, err := m.ReceiveTxSync("sha3", []byte("tx"))
+	assert.NoError(t, err)
+	txHash, err := crypto.Hash([]byte("tx"), "SHA3-256")
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(txHash), resp.GetId())
+}
@@ -0,0 +1,21 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import "github.com/mintzhao/topachain/types"
+
+// NewLocalClient returns a types.ApplicationClient for applications linked into the node binary, backed by Manager m.
+// Messages are passed in-process without a network round trip or gRPC framing, payloads are still encoded in AppMessage.
+func NewLocalClient(m *Manager) types.ApplicationClient {
+	return types.NewLocalApplicationClient(NewApplicationServer(m))
+}
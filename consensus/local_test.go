@@ -0,0 +1,81 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	appsdk "github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalClient(t *testing.T) {
+	m := NewManager()
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "heights"}), nil))
+
+	_, err := m.DeliverBlock("heights", testBlock(1))
+	assert.Equal(t, ErrApplicationUnregistered, err)
+
+	app := &heights{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runc := make(chan error, 1)
+	go func() {
+		runc <- appsdk.RunWith(ctx, app, NewLocalClient(m))
+	}()
+
+	waitRegistered(t, m, "heights", true)
+	appHash, err := m.DeliverBlock("heights", testBlock(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2}, appHash)
+	assert.Equal(t, []uint64{1, 2}, app.heights())
+
+	// application stops with ctx
+	cancel()
+	assert.Equal(t, context.Canceled, <-runc)
+	waitRegistered(t, m, "heights", false)
+}
+
+func TestLocalClient_Stream(t *testing.T) {
+	m := NewManager()
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "heights"}), nil))
+	cli := NewLocalClient(m)
+
+	_, err := cli.Register(context.Background(), &types.AppMetadata{Name: "unknown"})
+	assert.Error(t, err)
+
+	// rejected stream is closed with server error
+	stream, err := cli.AppStream(context.Background())
+	assert.NoError(t, err)
+	msg, err := types.NewAppMessage(nil, types.HEARTBEAT, 1, &types.Heartbeat{})
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(msg))
+
+	resp, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, types.ERROR, resp.GetHeader().GetType())
+	_, err = stream.Recv()
+	assert.Error(t, err)
+	assert.NotEqual(t, io.EOF, err)
+
+	// closed send ends stream
+	stream, err = cli.AppStream(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
@@ -13,9 +13,16 @@
 package consensus
 
 import (
+	"encoding/hex"
 	"sync"
 
+	"github.com/mintzhao/topachain/common/comm"
+	"github.com/mintzhao/topachain/common/crypto"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/common/genesis"
+	_ "github.com/mintzhao/topachain/common/logging"
 	"github.com/mintzhao/topachain/types"
+	"github.com/op/go-logging"
 	"github.com/pkg/errors"
 )
 
@@ -23,18 +30,273 @@ var (
 	// ErrNilTx indicate empty tx
 	ErrNilTx = errors.New("nil tx")
 
+	// ErrTxTooLarge means tx doesn't fit in a block
+	ErrTxTooLarge = errors.New("tx too large")
+
 	// ErrApplicationUnregistered means can not load application core
 	ErrApplicationUnregistered = errors.New("application unregistered")
-)
 
-type handler struct {
-	core   ConsensusCore
-	stream types.Application_AppStreamServer
-}
+	// ErrApplicationUnknown means application has no genesis block loaded at this node
+	ErrApplicationUnknown = errors.New("application unknown")
+
+	// ErrApplicationAlreadyRegistered means application already has a stream attached
+	ErrApplicationAlreadyRegistered = errors.New("application already registered")
+
+	// ErrApplicationAhead means application committed blocks this node hasn't delivered
+	ErrApplicationAhead = errors.New("application ahead of node")
+
+	// ErrApplicationStopped means application is stopped at this node, it neither registers nor gets blocks until started
+	ErrApplicationStopped = errors.New("application stopped")
+
+	// ErrApplicationUnauthorized means the certificate application presented isn't issued for it
+	ErrApplicationUnauthorized = errors.New("application unauthorized")
+
+	// logger
+	logger = logging.MustGetLogger("consensus")
+)
 
 // Consensus Manager
 type Manager struct {
-	handlers sync.Map
+	applications  sync.Map
+	handlers      sync.Map
+	subscriptions sync.Map
+	done          chan struct{}
+	stopOnce      sync.Once
+
+	// maxBlockSize limits the encoded txs of blocks cut
+	maxBlockSize uint64
+}
+
+// NewManager returns an empty consensus Manager, cutting blocks fitting gRPC default message size
+func NewManager() *Manager {
+	return &Manager{
+		done:         make(chan struct{}),
+		maxBlockSize: comm.BlockSizeLimit(0),
+	}
+}
+
+// SetMaxBlockSize limits the blocks cut to config.GRPC.MaxBlockSize, the gRPC message size limits are derived from.
+// It must be called before applications start.
+func (m *Manager) SetMaxBlockSize(maxBlockSize uint64) {
+	m.maxBlockSize = comm.BlockSizeLimit(maxBlockSize)
+}
+
+// Stop stops the consensus instances of all the applications and releases their streams.
+// It returns once the blocks being delivered are done, so that the databases can be closed afterwards.
+func (m *Manager) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.done)
+		for _, name := range m.Applications() {
+			m.StopApplication(name)
+		}
+	})
+}
+
+// application is an application known to the manager
+type application struct {
+	config   *types.AppConfig
+	versions *appVersions
+
+	// mutex serializes block delivery, replay and state sync
+	mutex     sync.Mutex
+	blocks    *blockStore
+	snapshots *snapshotStore
+	events    *eventStore
+	upgrade   *upgradeStore
+
+	// stateSync restores application state when it registers fresh, nil if not requested
+	stateSync *stateSync
+
+	// chain orders application's txs into blocks while started, nil if not
+	chain   *chain
+	stopped bool
+}
+
+// AddApplication makes the application of genesis block blk known to the manager,
+// its version history and delivered blocks are persisted in db
+func (m *Manager) AddApplication(blk *types.Block, db database.Database) error {
+	gtxp, err := genesis.GenesisProposal(blk)
+	if err != nil {
+		return err
+	}
+
+	versions, err := loadAppVersions(gtxp.GetName(), gtxp.GetVersion(), db)
+	if err != nil {
+		return err
+	}
+
+	blocks, err := loadBlockStore(gtxp.GetName(), blk, db)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := loadSnapshotStore(gtxp.GetName(), db)
+	if err != nil {
+		return err
+	}
+
+	upgrade, err := loadUpgradeStore(gtxp.GetName(), db)
+	if err != nil {
+		return err
+	}
+
+	if _, loaded := m.applications.LoadOrStore(gtxp.GetName(), &application{
+		config:    gtxp.GetConfig(),
+		versions:  versions,
+		blocks:    blocks,
+		snapshots: snapshots,
+		events:    newEventStore(gtxp.GetName(), db),
+		upgrade:   upgrade,
+	}); loaded {
+		return errors.Errorf("application %s already added", gtxp.GetName())
+	}
+
+	logger.Infof("application %s added", gtxp.GetName())
+	return nil
+}
+
+// getApplication returns the added application
+func (m *Manager) getApplication(name string) (*application, error) {
+	app, ok := m.applications.Load(name)
+	if !ok {
+		return nil, ErrApplicationUnknown
+	}
+
+	return app.(*application), nil
+}
+
+// CheckVersion checks application described by meta is added and its version is compatible with the recorded one.
+// Once the height of a scheduled upgrade reached, the version must be upgraded instead.
+func (m *Manager) CheckVersion(meta *types.AppMetadata) error {
+	app, err := m.getApplication(meta.GetName())
+	if err != nil {
+		return err
+	}
+
+	if proposal := app.upgrade.latest(); proposal != nil && app.blocks.height() >= proposal.GetHeight() {
+		return app.upgrade.allows(meta.GetVersion(), proposal.GetHeight())
+	}
+
+	return app.versions.check(meta.GetVersion())
+}
+
+// VersionHistory returns which version of application processed which block range
+func (m *Manager) VersionHistory(name string) ([]*types.AppVersionRange, error) {
+	app, err := m.getApplication(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.versions.ranges(), nil
+}
+
+// attach binds stream to application committed blocks up to lastHeight, application must be added before
+func (m *Manager) attach(meta *types.AppMetadata, lastHeight uint64, stream types.Application_AppStreamServer) (*handler, error) {
+	if err := m.CheckVersion(meta); err != nil {
+		return nil, err
+	}
+
+	app, err := m.getApplication(meta.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.checkStopped(meta.GetName()); err != nil {
+		return nil, err
+	}
+
+	app.mutex.Lock()
+	height := app.blocks.height()
+	app.mutex.Unlock()
+	if lastHeight > height {
+		return nil, errors.Wrapf(ErrApplicationAhead, "application %s committed block %d, node delivered %d", meta.GetName(), lastHeight, height)
+	}
+
+	h := newHandler(meta, lastHeight, stream)
+	if _, loaded := m.handlers.LoadOrStore(meta.GetName(), h); loaded {
+		return nil, ErrApplicationAlreadyRegistered
+	}
+
+	logger.Infof("application %s version %s registered at height %d", meta.GetName(), meta.GetVersion().Text(), lastHeight)
+	return h, nil
+}
+
+// resume replays the blocks application of h missed while it's detached
+func (m *Manager) resume(h *handler) error {
+	app, err := m.getApplication(h.meta.GetName())
+	if err != nil {
+		return err
+	}
+
+	// the snapshot of a requested state sync is downloaded before locking, only installing it holds app.mutex
+	app.mutex.Lock()
+	ss := app.stateSync
+	fresh := h.height == 0 && app.blocks.height() == 0
+	app.mutex.Unlock()
+
+	var dl *snapshotDownload
+	if ss != nil && fresh {
+		if dl, err = m.download(h.meta.GetName(), ss, app.config.GetHash()); err != nil {
+			return errors.Wrap(err, "state sync error")
+		}
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.stopped {
+		return errors.Wrapf(ErrApplicationStopped, "application %s", h.meta.GetName())
+	}
+
+	if dl != nil && app.stateSync == ss && app.blocks.height() == 0 {
+		if err := m.restore(app, h, dl); err != nil {
+			return errors.Wrap(err, "state sync error")
+		}
+	}
+
+	from := h.height
+	if _, err := m.catchUp(app, h); err != nil {
+		return errors.Wrap(err, "replay blocks error")
+	}
+
+	if h.height > from {
+		logger.Infof("application %s resumed from block %d to %d", h.meta.GetName(), from, h.height)
+	}
+	return nil
+}
+
+// height returns height of the last block delivered to application
+func (m *Manager) height(application string) (uint64, error) {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return 0, err
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	return app.blocks.height(), nil
+}
+
+// detach unbinds stream of h from its application, pending requests to it fail
+func (m *Manager) detach(h *handler) {
+	h.close()
+
+	name := h.meta.GetName()
+	if current, ok := m.handlers.Load(name); ok && current == h {
+		m.handlers.Delete(name)
+		logger.Infof("application %s unregistered", name)
+	}
+}
+
+// getHandler returns the handler of registered application
+func (m *Manager) getHandler(application string) (*handler, error) {
+	h, ok := m.handlers.Load(application)
+	if !ok {
+		return nil, ErrApplicationUnregistered
+	}
+
+	return h.(*handler), nil
 }
 
 // ReceiveTxSync receive tx from application synchronous.
@@ -42,14 +304,207 @@ func (m *Manager) ReceiveTxSync(application string, tx []byte) (*types.TxRespons
 	if len(tx) == 0 {
 		return nil, ErrNilTx
 	}
+	if size := txSize(&types.Transaction{Payload: tx}); size > m.maxBlockSize {
+		return nil, errors.Wrapf(ErrTxTooLarge, "%d bytes, blocks limited to %d", size, m.maxBlockSize)
+	}
 
 	// get specific application consensus handler
-	handlerInterface, ok := m.handlers.Load(application)
-	if !ok {
-		return nil, ErrApplicationUnregistered
+	handler, err := m.getHandler(application)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := m.getApplication(application)
+	if err != nil {
+		return nil, errors.Wrapf(ErrApplicationNotRunning, "application %s: %s", application, err)
+	}
+
+	txid, err := txID(tx, app.config.GetHash())
+	if err != nil {
+		return nil, err
 	}
-	handler := handlerInterface.(*handler)
 
 	// valid tx
-	handler.stream.Send()
+	resp := new(types.CheckTxResponse)
+	if err := handler.request(types.CHECK_TX, &types.CheckTxRequest{Tx: &types.Transaction{Payload: tx}}, resp); err != nil {
+		return nil, errors.Wrap(err, "check tx error")
+	}
+	if resp.GetCode() != 0 {
+		return nil, errors.Errorf("tx rejected by application: %s", resp.GetLog())
+	}
+
+	// accepted tx is ordered into block by the consensus instance of application, none if stopped or halted
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.chain == nil {
+		return nil, errors.Wrapf(ErrApplicationNotRunning, "application %s", application)
+	}
+	app.chain.add(&types.Transaction{Payload: tx})
+
+	return &types.TxResponseSync{
+		Id:     txid,
+		Status: types.TX_OK,
+	}, nil
+}
+
+// DeliverBlock drives application to execute blk, returns the application state hash committed.
+// blk is kept even if application is unregistered, and replayed to it once registered.
+// The version of application processed blk is recorded.
+func (m *Manager) DeliverBlock(application string, blk *types.Block) ([]byte, error) {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return nil, err
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.stopped {
+		return nil, errors.Wrapf(ErrApplicationStopped, "application %s", application)
+	}
+
+	if err := app.blocks.put(blk); err != nil {
+		return nil, err
+	}
+
+	handler, err := m.getHandler(application)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.catchUp(app, handler)
+}
+
+// catchUp delivers the stored blocks after the height application of h committed,
+// returns the state hash of the last one, app.mutex must be held
+func (m *Manager) catchUp(app *application, h *handler) ([]byte, error) {
+	var appHash []byte
+	for h.height < app.blocks.height() {
+		blk, err := app.blocks.get(h.height + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		// delivery halts until application upgraded, the old version is unregistered
+		if err := app.upgrade.allows(h.meta.GetVersion(), blk.GetHeader().GetBlockHeight()); err != nil {
+			logger.Warningf("application %s halted: %s", h.meta.GetName(), err)
+			m.detach(h)
+			return nil, err
+		}
+
+		if appHash, err = m.deliver(app, h, blk); err != nil {
+			return nil, err
+		}
+		h.height = blk.GetHeader().GetBlockHeight()
+
+		// snapshot failure doesn't fail the block
+		if err := m.snapshot(app, h); err != nil {
+			logger.Warningf("application %s snapshot at %d error: %s", h.meta.GetName(), h.height, err)
+		}
+	}
+
+	return appHash, nil
+}
+
+// deliver drives application of h to execute blk
+func (m *Manager) deliver(app *application, h *handler, blk *types.Block) ([]byte, error) {
+	name := h.meta.GetName()
+	if err := h.request(types.BEGIN_BLOCK, &types.BeginBlockRequest{Header: blk.GetHeader()}, &types.BeginBlockResponse{}); err != nil {
+		return nil, errors.Wrap(err, "begin block error")
+	}
+
+	// invalid tx doesn't fail the block
+	height := blk.GetHeader().GetBlockHeight()
+	var events []*types.TxEvent
+	for i, tx := range blk.GetTxs().GetTxs() {
+		if tx.GetType() == types.UPGRADE_TX {
+			m.schedule(app, tx, height)
+			continue
+		}
+
+		resp := new(types.DeliverTxResponse)
+		if err := h.request(types.DELIVER_TX, &types.DeliverTxRequest{Tx: tx}, resp); err != nil {
+			return nil, errors.Wrap(err, "deliver tx error")
+		}
+		if resp.GetCode() != 0 {
+			logger.Warningf("application %s deliver tx %d of block %d error: %s", name, i, height, resp.GetLog())
+			continue
+		}
+
+		if len(resp.GetEvents()) == 0 {
+			continue
+		}
+		txid, err := txID(tx.GetPayload(), app.config.GetHash())
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range resp.GetEvents() {
+			events = append(events, &types.TxEvent{
+				Application: name,
+				Height:      height,
+				TxId:        txid,
+				TxIndex:     uint32(i),
+				Event:       event,
+			})
+		}
+	}
+
+	if err := h.request(types.END_BLOCK, &types.EndBlockRequest{Header: blk.GetHeader()}, &types.EndBlockResponse{}); err != nil {
+		return nil, errors.Wrap(err, "end block error")
+	}
+
+	resp := new(types.CommitResponse)
+	if err := h.request(types.COMMIT, &types.CommitRequest{}, resp); err != nil {
+		return nil, errors.Wrap(err, "commit error")
+	}
+
+	if err := app.versions.record(h.meta.GetVersion(), height); err != nil {
+		return nil, errors.Wrap(err, "record application version error")
+	}
+
+	// block is committed, failing to index its events doesn't fail it
+	if len(events) > 0 {
+		if err := app.events.put(height, events); err != nil {
+			logger.Warningf("application %s index events of block %d error: %s", name, height, err)
+		}
+		m.publish(events)
+	}
+
+	logger.Debugf("application %s committed block %d", name, height)
+	return resp.GetAppHash(), nil
+}
+
+// Query queries state of application
+func (m *Manager) Query(application string, query []byte) ([]byte, error) {
+	handler, err := m.getHandler(application)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(types.QueryResponse)
+	if err := handler.request(types.QUERY, &types.QueryRequest{Data: query}, resp); err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != 0 {
+		return nil, errors.Errorf("query error: %s", resp.GetLog())
+	}
+
+	return resp.GetValue(), nil
+}
+
+// txID returns the id of tx payload, its hex encoded hash by hasher hash of application config, the default one if empty
+func txID(payload []byte, hash string) (string, error) {
+	var txHash []byte
+	var err error
+	if hash == "" {
+		txHash, err = crypto.Hash(payload)
+	} else {
+		txHash, err = crypto.Hash(payload, hash)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(txHash), nil
 }
@@ -0,0 +1,274 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	appsdk "github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/common/database/badger"
+	"github.com/mintzhao/topachain/common/genesis"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// heights records heights of the blocks it committed
+type heights struct {
+	name    string
+	address string
+	version *types.AppVersion
+
+	mutex     sync.Mutex
+	current   uint64
+	committed []uint64
+	snapshots map[uint64][]uint64
+}
+
+func (hs *heights) Metadata() (*types.AppMetadata, error) {
+	if hs.name != "" {
+		return &types.AppMetadata{Name: hs.name, Version: hs.version}, nil
+	}
+
+	return &types.AppMetadata{Name: "heights", Version: hs.version}, nil
+}
+
+func (hs *heights) Config() *types.AppConfig {
+	return &types.AppConfig{MasterAddress: hs.address}
+}
+
+func (hs *heights) CheckTx(tx *types.Transaction) error {
+	return nil
+}
+
+func (hs *heights) BeginBlock(header *types.BlockHeader) error {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	hs.current = header.GetBlockHeight()
+	return nil
+}
+
+func (hs *heights) DeliverTx(tx *types.Transaction) error {
+	return nil
+}
+
+func (hs *heights) EndBlock(header *types.BlockHeader) error {
+	return nil
+}
+
+func (hs *heights) Commit() ([]byte, error) {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	hs.committed = append(hs.committed, hs.current)
+	return []byte{byte(hs.current)}, nil
+}
+
+func (hs *heights) Query(query []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func (hs *heights) LastHeight() (uint64, error) {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	if n := len(hs.committed); n > 0 {
+		return hs.committed[n-1], nil
+	}
+
+	return 0, nil
+}
+
+// TakeSnapshot snapshots the committed heights, a chunk for each
+func (hs *heights) TakeSnapshot(height uint64) (*types.Snapshot, error) {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	if hs.snapshots == nil {
+		hs.snapshots = make(map[uint64][]uint64)
+	}
+	hs.snapshots[height] = append([]uint64{}, hs.committed...)
+
+	chunks := make([][]byte, len(hs.committed))
+	for i, committed := range hs.committed {
+		chunks[i] = make([]byte, 8)
+		binary.BigEndian.PutUint64(chunks[i], committed)
+	}
+
+	return types.NewSnapshot(height, []byte{byte(height)}, chunks, "")
+}
+
+func (hs *heights) LoadSnapshotChunk(height uint64, index uint32) ([]byte, error) {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	committed, ok := hs.snapshots[height]
+	if !ok || int(index) >= len(committed) {
+		return nil, errors.Errorf("no chunk %d of snapshot %d", index, height)
+	}
+
+	chunk := make([]byte, 8)
+	binary.BigEndian.PutUint64(chunk, committed[index])
+	return chunk, nil
+}
+
+func (hs *heights) ApplySnapshotChunk(snapshot *types.Snapshot, index uint32, chunk []byte) ([]byte, error) {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	if index == 0 {
+		hs.committed = nil
+	}
+	hs.committed = append(hs.committed, binary.BigEndian.Uint64(chunk))
+
+	return []byte{byte(hs.committed[len(hs.committed)-1])}, nil
+}
+
+func (hs *heights) heights() []uint64 {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	return append([]uint64{}, hs.committed...)
+}
+
+func testBlock(height uint64) *types.Block {
+	return &types.Block{
+		Header: &types.BlockHeader{BlockHeight: height},
+		Txs:    &types.BlockTxs{Txs: []*types.Transaction{{Payload: []byte("tx")}}},
+	}
+}
+
+// testGenesis returns the genesis block of gtxp
+func testGenesis(t *testing.T, gtxp *types.GenesisTxProposal) *types.Block {
+	blk, err := genesis.GenesisBlock(gtxp.GetName(), gtxp.GetConfig(), gtxp.GetVersion())
+	assert.NoError(t, err)
+
+	return blk
+}
+
+// serveManager serves m at address until the returned server stopped
+func serveManager(t *testing.T, m *Manager, address string) *grpc.Server {
+	lis, err := net.Listen("tcp", address)
+	assert.NoError(t, err)
+
+	server := grpc.NewServer()
+	types.RegisterApplicationServer(server, NewApplicationServer(m))
+	types.RegisterStateSyncServer(server, NewStateSyncServer(m))
+	types.RegisterEventsServer(server, NewEventsServer(m))
+	go server.Serve(lis)
+
+	return server
+}
+
+// waitRegistered waits application name registered to m, or unregistered
+func waitRegistered(t *testing.T, m *Manager, name string, registered bool) {
+	for i := 0; i < 100; i++ {
+		if _, err := m.getHandler(name); (err == nil) == registered {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatalf("application %s registered: %v, expect %v", name, !registered, registered)
+}
+
+func TestManager_Resume(t *testing.T) {
+	os.Mkdir(testdbdir, os.ModePerm)
+	defer os.RemoveAll(testdbdir)
+
+	db, err := badger.New(testdbdir)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	m := NewManager()
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "heights"}), db))
+
+	// blocks delivered before registration are kept
+	for height := uint64(1); height <= 3; height++ {
+		_, err := m.DeliverBlock("heights", testBlock(height))
+		assert.Equal(t, ErrApplicationUnregistered, err)
+	}
+	_, err = m.DeliverBlock("heights", testBlock(5))
+	assert.Equal(t, ErrBlockHeight, errors.Cause(err))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := lis.Addr().String()
+	lis.Close()
+
+	server := serveManager(t, m, address)
+
+	app := &heights{address: address}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runc := make(chan error, 1)
+	go func() {
+		runc <- appsdk.RunContext(ctx, app)
+	}()
+
+	// missed blocks replayed on registration, new block delivered after them
+	waitRegistered(t, m, "heights", true)
+	appHash, err := m.DeliverBlock("heights", testBlock(4))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{4}, appHash)
+	assert.Equal(t, []uint64{1, 2, 3, 4}, app.heights())
+
+	// node restarts, blocks delivered meanwhile replayed after reconnection
+	server.Stop()
+	waitRegistered(t, m, "heights", false)
+	_, err = m.DeliverBlock("heights", testBlock(5))
+	assert.Equal(t, ErrApplicationUnregistered, err)
+
+	server = serveManager(t, m, address)
+	defer server.Stop()
+
+	waitRegistered(t, m, "heights", true)
+	appHash, err = m.DeliverBlock("heights", testBlock(6))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{6}, appHash)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6}, app.heights())
+
+	// delivered blocks survive reload
+	blocks, err := loadBlockStore("heights", testGenesis(t, &types.GenesisTxProposal{Name: "heights"}), db)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), blocks.height())
+	blk, err := blocks.get(5)
+	assert.NoError(t, err)
+	assert.True(t, testBlock(5).Equal(blk))
+
+	ranges, err := m.VersionHistory("heights")
+	assert.NoError(t, err)
+	assert.Len(t, ranges, 1)
+	assert.Equal(t, uint64(1), ranges[0].GetStartHeight())
+	assert.Equal(t, uint64(6), ranges[0].GetEndHeight())
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-runc)
+}
+
+func TestManager_RejectAhead(t *testing.T) {
+	m := NewManager()
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "heights"}), nil))
+
+	_, err := m.attach(&types.AppMetadata{Name: "heights"}, 1, nil)
+	assert.Equal(t, ErrApplicationAhead, errors.Cause(err))
+}
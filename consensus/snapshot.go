@@ -0,0 +1,218 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// snapshotKeyPrefix prefixes the keys snapshots stored under in application's bucket
+	snapshotKeyPrefix = "snapshot/"
+
+	// chunkKeyPrefix prefixes the keys snapshot chunks stored under in application's bucket
+	chunkKeyPrefix = "chunk/"
+
+	// snapshotsKept is how many recent snapshots kept, older ones are pruned
+	snapshotsKept = 2
+)
+
+var (
+	// ErrSnapshotNotFound means no snapshot stored at the height
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+)
+
+// snapshotStore keeps the recent snapshots of application with their chunks,
+// persisted in application's bucket if db given, or in memory
+type snapshotStore struct {
+	name string
+	db   database.Database
+
+	mutex     sync.RWMutex
+	snapshots []*types.Snapshot // in height order
+	chunks    map[string][]byte
+}
+
+// loadSnapshotStore loads the stored snapshots of application name from db
+func loadSnapshotStore(name string, db database.Database) (*snapshotStore, error) {
+	ss := &snapshotStore{
+		name:   name,
+		db:     db,
+		chunks: make(map[string][]byte),
+	}
+	if db == nil {
+		return ss, nil
+	}
+
+	it, err := db.NewIterator(name, snapshotKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	for it.HasNext() {
+		kv, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot := new(types.Snapshot)
+		if err := proto.Unmarshal(kv.Value, snapshot); err != nil {
+			return nil, errors.Wrap(err, "unmarshal snapshot error")
+		}
+		ss.snapshots = append(ss.snapshots, snapshot)
+
+		if err := it.Next(); err != nil {
+			return nil, err
+		}
+	}
+
+	return ss, nil
+}
+
+// list returns the stored snapshots in height order
+func (ss *snapshotStore) list() []*types.Snapshot {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+
+	snapshots := make([]*types.Snapshot, len(ss.snapshots))
+	for i, snapshot := range ss.snapshots {
+		snapshots[i] = proto.Clone(snapshot).(*types.Snapshot)
+	}
+
+	return snapshots
+}
+
+// has returns whether snapshot at height is stored
+func (ss *snapshotStore) has(height uint64) bool {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+
+	for _, snapshot := range ss.snapshots {
+		if snapshot.GetHeight() == height {
+			return true
+		}
+	}
+
+	return false
+}
+
+// put stores snapshot with its chunks, prunes the old ones
+func (ss *snapshotStore) put(snapshot *types.Snapshot, chunks [][]byte) error {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	snapshots := append(ss.snapshots, snapshot)
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].GetHeight() < snapshots[j].GetHeight()
+	})
+
+	var pruned []*types.Snapshot
+	if len(snapshots) > snapshotsKept {
+		pruned = snapshots[:len(snapshots)-snapshotsKept]
+		snapshots = snapshots[len(snapshots)-snapshotsKept:]
+	}
+
+	if ss.db == nil {
+		for i, chunk := range chunks {
+			ss.chunks[chunkKey(snapshot.GetHeight(), uint32(i))] = chunk
+		}
+		for _, old := range pruned {
+			for i := range old.GetChunkHashes() {
+				delete(ss.chunks, chunkKey(old.GetHeight(), uint32(i)))
+			}
+		}
+
+		ss.snapshots = snapshots
+		return nil
+	}
+
+	snapshotBytes, err := proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	batch, err := ss.db.NewBatch()
+	if err != nil {
+		return err
+	}
+	defer batch.Release()
+
+	if err := batch.Set(ss.name, snapshotKey(snapshot.GetHeight()), snapshotBytes); err != nil {
+		return err
+	}
+	for i, chunk := range chunks {
+		if err := batch.Set(ss.name, chunkKey(snapshot.GetHeight(), uint32(i)), chunk); err != nil {
+			return err
+		}
+	}
+	for _, old := range pruned {
+		if err := batch.Delete(ss.name, snapshotKey(old.GetHeight())); err != nil {
+			return err
+		}
+		for i := range old.GetChunkHashes() {
+			if err := batch.Delete(ss.name, chunkKey(old.GetHeight(), uint32(i))); err != nil {
+				return err
+			}
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		return err
+	}
+
+	ss.snapshots = snapshots
+	return nil
+}
+
+// chunk returns chunk index of the snapshot at height
+func (ss *snapshotStore) chunk(height uint64, index uint32) ([]byte, error) {
+	if !ss.has(height) {
+		return nil, errors.Wrapf(ErrSnapshotNotFound, "application %s snapshot at %d", ss.name, height)
+	}
+
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+
+	if ss.db == nil {
+		chunk, ok := ss.chunks[chunkKey(height, index)]
+		if !ok {
+			return nil, errors.Wrapf(ErrSnapshotNotFound, "application %s snapshot at %d chunk %d", ss.name, height, index)
+		}
+
+		return chunk, nil
+	}
+
+	chunk, err := ss.db.Get(ss.name, chunkKey(height, index))
+	if err == database.ErrKeyNotFound {
+		return nil, errors.Wrapf(ErrSnapshotNotFound, "application %s snapshot at %d chunk %d", ss.name, height, index)
+	}
+
+	return chunk, err
+}
+
+// snapshotKey returns the key snapshot at height stored under
+func snapshotKey(height uint64) string {
+	return fmt.Sprintf("%s%020d", snapshotKeyPrefix, height)
+}
+
+// chunkKey returns the key chunk index of snapshot at height stored under
+func chunkKey(height uint64, index uint32) string {
+	return fmt.Sprintf("%s%020d/%010d", chunkKeyPrefix, height, index)
+}
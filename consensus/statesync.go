@@ -0,0 +1,386 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+const (
+	// stateSyncTimeout is how long each call to the source of a state sync may take
+	stateSyncTimeout = 30 * time.Second
+)
+
+var (
+	// ErrApplicationNotFresh means application already has blocks delivered, state sync is for fresh nodes only
+	ErrApplicationNotFresh = errors.New("application not fresh")
+
+	// ErrSnapshotUntrusted means no snapshot matches the trusted block header and app hash
+	ErrSnapshotUntrusted = errors.New("no trusted snapshot")
+
+	// ErrTrustRequired means state sync is requested without the trusted header hash or app hash
+	ErrTrustRequired = errors.New("trusted header hash and app hash required")
+
+	// ErrAppHashMismatch means state restored from snapshot doesn't match the snapshot
+	ErrAppHashMismatch = errors.New("app hash mismatch")
+)
+
+// SnapshotSource serves the snapshots and blocks of applications, usually a peer node.
+// Calls must give up once ctx is done.
+type SnapshotSource interface {
+	// ListSnapshots lists the stored snapshots of application in height order
+	ListSnapshots(ctx context.Context, application string) ([]*types.Snapshot, error)
+
+	// GetSnapshotChunk returns chunk index of the snapshot of application at height
+	GetSnapshotChunk(ctx context.Context, application string, height uint64, index uint32) ([]byte, error)
+
+	// GetBlock returns the block of application at height
+	GetBlock(ctx context.Context, application string, height uint64) (*types.Block, error)
+}
+
+// stateSync is a requested state sync of application
+type stateSync struct {
+	source SnapshotSource
+
+	// trustHash is the hash of the block header at the height to sync to
+	trustHash []byte
+
+	// trustAppHash is the state hash application committed at that block.
+	// Block headers don't commit to state, so it must come from a trusted party as trustHash does.
+	trustAppHash []byte
+}
+
+// snapshotDownload is a trusted snapshot fetched from the source of a state sync, with its block and chunks
+type snapshotDownload struct {
+	snapshot *types.Snapshot
+	blk      *types.Block
+	chunks   [][]byte
+}
+
+// StateSync has fresh application restore its state from a snapshot of source once it registers, instead of replaying all the blocks.
+// Only the snapshot at the block whose header hashes to trustHash, of state hashing to trustAppHash, is accepted,
+// node continues from its height. Both are needed, as the block header doesn't commit to application state.
+func (m *Manager) StateSync(application string, source SnapshotSource, trustHash, trustAppHash []byte) error {
+	if len(trustHash) == 0 || len(trustAppHash) == 0 {
+		return errors.Wrapf(ErrTrustRequired, "application %s", application)
+	}
+
+	app, err := m.getApplication(application)
+	if err != nil {
+		return err
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if height := app.blocks.height(); height != 0 {
+		return errors.Wrapf(ErrApplicationNotFresh, "application %s delivered block %d", application, height)
+	}
+
+	app.stateSync = &stateSync{
+		source:       source,
+		trustHash:    trustHash,
+		trustAppHash: trustAppHash,
+	}
+	return nil
+}
+
+// ListSnapshots lists the stored snapshots of application in height order
+func (m *Manager) ListSnapshots(application string) ([]*types.Snapshot, error) {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.snapshots.list(), nil
+}
+
+// GetSnapshotChunk returns chunk index of the stored snapshot of application at height
+func (m *Manager) GetSnapshotChunk(application string, height uint64, index uint32) ([]byte, error) {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.snapshots.chunk(height, index)
+}
+
+// GetBlock returns the stored block of application at height
+func (m *Manager) GetBlock(application string, height uint64) (*types.Block, error) {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.blocks.get(height)
+}
+
+// BlockHash returns the header hash of the stored block of application at height, which state sync trusts
+func (m *Manager) BlockHash(application string, height uint64) ([]byte, error) {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := app.blocks.get(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return blk.GetHeader().Hash(app.config.GetHash())
+}
+
+// snapshot has application of h snapshot its state if the committed height is a snapshot one,
+// the snapshot and its chunks are stored, app.mutex must be held
+func (m *Manager) snapshot(app *application, h *handler) error {
+	interval := app.config.GetSnapshotInterval()
+	if interval == 0 || h.height%interval != 0 || app.snapshots.has(h.height) {
+		return nil
+	}
+
+	resp := new(types.TakeSnapshotResponse)
+	if err := h.request(types.SNAPSHOT, &types.TakeSnapshotRequest{Height: h.height}, resp); err != nil {
+		return err
+	}
+
+	snapshot := resp.GetSnapshot()
+	if snapshot.GetHeight() != h.height {
+		return errors.Errorf("snapshot at %d, expect %d", snapshot.GetHeight(), h.height)
+	}
+
+	chunks := make([][]byte, len(snapshot.GetChunkHashes()))
+	for i := range chunks {
+		chunkResp := new(types.LoadSnapshotChunkResponse)
+		if err := h.request(types.LOAD_SNAPSHOT_CHUNK, &types.LoadSnapshotChunkRequest{Height: h.height, Index: uint32(i)}, chunkResp); err != nil {
+			return errors.Wrapf(err, "load chunk %d error", i)
+		}
+		if err := snapshot.VerifyChunk(uint32(i), chunkResp.GetChunk(), app.config.GetHash()); err != nil {
+			return err
+		}
+		chunks[i] = chunkResp.GetChunk()
+	}
+
+	if err := app.snapshots.put(snapshot, chunks); err != nil {
+		return err
+	}
+
+	logger.Infof("application %s snapshot at %d taken, %d chunks", h.meta.GetName(), h.height, len(chunks))
+	return nil
+}
+
+// stateSyncContext returns the context of a call to the source of a state sync,
+// done after stateSyncTimeout or once m stops
+func (m *Manager) stateSyncContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), stateSyncTimeout)
+	go func() {
+		select {
+		case <-m.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// download fetches the trusted snapshot of ss of application name with its chunks, verified by hasher hash.
+// It runs without app.mutex held, so that a stalling source doesn't block the application.
+func (m *Manager) download(name string, ss *stateSync, hash string) (*snapshotDownload, error) {
+	ctx, cancel := m.stateSyncContext()
+	snapshots, err := ss.source.ListSnapshots(ctx, name)
+	cancel()
+	if err != nil {
+		return nil, errors.Wrap(err, "list snapshots error")
+	}
+
+	snapshot, blk, err := m.trustedSnapshot(name, ss.source, snapshots, ss.trustHash, ss.trustAppHash, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("application %s downloading snapshot at %d", name, snapshot.GetHeight())
+	chunks := make([][]byte, len(snapshot.GetChunkHashes()))
+	for i := range chunks {
+		ctx, cancel := m.stateSyncContext()
+		chunk, err := ss.source.GetSnapshotChunk(ctx, name, snapshot.GetHeight(), uint32(i))
+		cancel()
+		if err != nil {
+			return nil, errors.Wrapf(err, "get chunk %d error", i)
+		}
+		if err := snapshot.VerifyChunk(uint32(i), chunk, hash); err != nil {
+			return nil, err
+		}
+		chunks[i] = chunk
+	}
+
+	return &snapshotDownload{snapshot: snapshot, blk: blk, chunks: chunks}, nil
+}
+
+// restore has application of h restore its state from the snapshot dl downloaded,
+// the block at snapshot height becomes the first one stored, app.mutex must be held
+func (m *Manager) restore(app *application, h *handler, dl *snapshotDownload) error {
+	name, snapshot := h.meta.GetName(), dl.snapshot
+
+	logger.Infof("application %s restoring snapshot at %d", name, snapshot.GetHeight())
+	var appHash []byte
+	for i, chunk := range dl.chunks {
+		resp := new(types.ApplySnapshotChunkResponse)
+		if err := h.request(types.APPLY_SNAPSHOT_CHUNK, &types.ApplySnapshotChunkRequest{Snapshot: snapshot, Index: uint32(i), Chunk: chunk}, resp); err != nil {
+			return errors.Wrapf(err, "apply chunk %d error", i)
+		}
+		appHash = resp.GetAppHash()
+	}
+
+	if !bytes.Equal(appHash, snapshot.GetAppHash()) {
+		return errors.Wrapf(ErrAppHashMismatch, "application %s restored %X, snapshot %X", name, appHash, snapshot.GetAppHash())
+	}
+
+	if err := app.blocks.reset(dl.blk); err != nil {
+		return err
+	}
+	h.height = snapshot.GetHeight()
+	app.stateSync = nil
+
+	if err := app.versions.record(h.meta.GetVersion(), h.height); err != nil {
+		return errors.Wrap(err, "record application version error")
+	}
+
+	// restored snapshot is served to other fresh nodes as well
+	if err := app.snapshots.put(snapshot, dl.chunks); err != nil {
+		logger.Warningf("application %s store snapshot at %d error: %s", name, h.height, err)
+	}
+
+	logger.Infof("application %s state synced to block %d", name, h.height)
+	return nil
+}
+
+// trustedSnapshot returns the newest snapshot of snapshots of app hash trustAppHash whose block header hashes to trustHash, with its block
+func (m *Manager) trustedSnapshot(name string, source SnapshotSource, snapshots []*types.Snapshot, trustHash, trustAppHash []byte, hash string) (*types.Snapshot, *types.Block, error) {
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		snapshot := snapshots[i]
+		// the app hash of snapshot comes from source, only the trusted one is taken
+		if !bytes.Equal(snapshot.GetAppHash(), trustAppHash) {
+			continue
+		}
+
+		ctx, cancel := m.stateSyncContext()
+		blk, err := source.GetBlock(ctx, name, snapshot.GetHeight())
+		cancel()
+		if err != nil {
+			logger.Warningf("application %s get block %d error: %s", name, snapshot.GetHeight(), err)
+			continue
+		}
+		if blk.GetHeader().GetBlockHeight() != snapshot.GetHeight() {
+			continue
+		}
+
+		headerHash, err := blk.GetHeader().Hash(hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !bytes.Equal(headerHash, trustHash) {
+			continue
+		}
+
+		if txroot := blk.GetHeader().GetTxroot(); len(txroot) != 0 {
+			txsHash, err := blk.GetTxs().Hash(hash)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !bytes.Equal(txsHash, txroot) {
+				return nil, nil, errors.Wrapf(ErrSnapshotUntrusted, "block %d txs mismatch txroot", snapshot.GetHeight())
+			}
+		}
+
+		return snapshot, blk, nil
+	}
+
+	return nil, nil, errors.Wrapf(ErrSnapshotUntrusted, "application %s trust hash %X app hash %X", name, trustHash, trustAppHash)
+}
+
+type statesyncapi struct {
+	m *Manager
+}
+
+// NewStateSyncServer returns a types.StateSyncServer serving the snapshots and blocks stored by Manager m
+func NewStateSyncServer(m *Manager) types.StateSyncServer {
+	return &statesyncapi{m: m}
+}
+
+func (api *statesyncapi) ListSnapshots(ctx context.Context, req *types.ListSnapshotsRequest) (*types.ListSnapshotsResponse, error) {
+	snapshots, err := api.m.ListSnapshots(req.GetApplication())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &types.ListSnapshotsResponse{Snapshots: snapshots}, nil
+}
+
+func (api *statesyncapi) GetSnapshotChunk(ctx context.Context, req *types.GetSnapshotChunkRequest) (*types.GetSnapshotChunkResponse, error) {
+	chunk, err := api.m.GetSnapshotChunk(req.GetApplication(), req.GetHeight(), req.GetIndex())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &types.GetSnapshotChunkResponse{Chunk: chunk}, nil
+}
+
+func (api *statesyncapi) GetBlock(ctx context.Context, req *types.GetBlockRequest) (*types.Block, error) {
+	blk, err := api.m.GetBlock(req.GetApplication(), req.GetHeight())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return blk, nil
+}
+
+// stateSyncSource is a SnapshotSource backed by a StateSync client
+type stateSyncSource struct {
+	cli types.StateSyncClient
+}
+
+// NewStateSyncSource returns a SnapshotSource fetching from the node cli connects to
+func NewStateSyncSource(cli types.StateSyncClient) SnapshotSource {
+	return &stateSyncSource{cli: cli}
+}
+
+func (s *stateSyncSource) ListSnapshots(ctx context.Context, application string) ([]*types.Snapshot, error) {
+	resp, err := s.cli.ListSnapshots(ctx, &types.ListSnapshotsRequest{Application: application})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetSnapshots(), nil
+}
+
+func (s *stateSyncSource) GetSnapshotChunk(ctx context.Context, application string, height uint64, index uint32) ([]byte, error) {
+	resp, err := s.cli.GetSnapshotChunk(ctx, &types.GetSnapshotChunkRequest{
+		Application: application,
+		Height:      height,
+		Index:       index,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetChunk(), nil
+}
+
+func (s *stateSyncSource) GetBlock(ctx context.Context, application string, height uint64) (*types.Block, error) {
+	return s.cli.GetBlock(ctx, &types.GetBlockRequest{Application: application, Height: height})
+}
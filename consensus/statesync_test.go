@@ -0,0 +1,213 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	appsdk "github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// managerSource is a SnapshotSource serving the snapshots and blocks of a Manager in-process
+type managerSource struct {
+	m *Manager
+}
+
+func (s managerSource) ListSnapshots(ctx context.Context, application string) ([]*types.Snapshot, error) {
+	return s.m.ListSnapshots(application)
+}
+
+func (s managerSource) GetSnapshotChunk(ctx context.Context, application string, height uint64, index uint32) ([]byte, error) {
+	return s.m.GetSnapshotChunk(application, height, index)
+}
+
+func (s managerSource) GetBlock(ctx context.Context, application string, height uint64) (*types.Block, error) {
+	return s.m.GetBlock(application, height)
+}
+
+// stalledSource is a SnapshotSource never answering, until ctx done
+type stalledSource struct {
+	called chan struct{}
+}
+
+func (s *stalledSource) stall(ctx context.Context) error {
+	select {
+	case s.called <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *stalledSource) ListSnapshots(ctx context.Context, application string) ([]*types.Snapshot, error) {
+	return nil, s.stall(ctx)
+}
+
+func (s *stalledSource) GetSnapshotChunk(ctx context.Context, application string, height uint64, index uint32) ([]byte, error) {
+	return nil, s.stall(ctx)
+}
+
+func (s *stalledSource) GetBlock(ctx context.Context, application string, height uint64) (*types.Block, error) {
+	return nil, s.stall(ctx)
+}
+
+// newHeightsManager returns a new Manager of heights application snapshotting every interval blocks, with the application and a free address to serve at
+func newHeightsManager(t *testing.T, interval uint64) (*Manager, *heights, string) {
+	m := NewManager()
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{
+		Name:   "heights",
+		Config: &types.AppConfig{SnapshotInterval: interval},
+	}), nil))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := lis.Addr().String()
+	lis.Close()
+
+	return m, &heights{address: address}, address
+}
+
+// waitHeight waits application name of m delivered block height
+func waitHeight(t *testing.T, m *Manager, name string, height uint64) {
+	for i := 0; i < 100; i++ {
+		if h, _ := m.height(name); h == height {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatalf("application %s not at height %d", name, height)
+}
+
+func TestManager_StateSync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// source node snapshots every 2 blocks, keeps the latest ones
+	source, app, address := newHeightsManager(t, 2)
+	server := serveManager(t, source, address)
+	defer server.Stop()
+	go appsdk.RunContext(ctx, app)
+
+	waitRegistered(t, source, "heights", true)
+	for height := uint64(1); height <= 6; height++ {
+		_, err := source.DeliverBlock("heights", testBlock(height))
+		assert.NoError(t, err)
+	}
+
+	snapshots, err := source.ListSnapshots("heights")
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, snapshotsKept)
+	assert.Equal(t, uint64(4), snapshots[0].GetHeight())
+	assert.Equal(t, uint64(6), snapshots[1].GetHeight())
+	assert.Len(t, snapshots[1].GetChunkHashes(), 6)
+
+	_, err = source.GetSnapshotChunk("heights", 2, 0)
+	assert.Equal(t, ErrSnapshotNotFound, errors.Cause(err))
+
+	trustHash, err := source.BlockHash("heights", 6)
+	assert.NoError(t, err)
+
+	trustAppHash := snapshots[1].GetAppHash()
+
+	// source already has blocks
+	assert.Equal(t, ErrApplicationNotFresh, errors.Cause(source.StateSync("heights", managerSource{source}, trustHash, trustAppHash)))
+	assert.Equal(t, ErrTrustRequired, errors.Cause(source.StateSync("heights", managerSource{source}, trustHash, nil)))
+
+	// only the snapshot at the trusted block of the trusted state is accepted
+	_, _, err = source.trustedSnapshot("heights", managerSource{source}, snapshots, []byte("untrusted"), trustAppHash, "")
+	assert.Equal(t, ErrSnapshotUntrusted, errors.Cause(err))
+	trustHash4, err := source.BlockHash("heights", 4)
+	assert.NoError(t, err)
+	_, _, err = source.trustedSnapshot("heights", managerSource{source}, snapshots, trustHash4, trustAppHash, "")
+	assert.Equal(t, ErrSnapshotUntrusted, errors.Cause(err))
+
+	// state forged by source is rejected though the block is trusted
+	forged := []*types.Snapshot{{Height: 6, AppHash: []byte("forged"), ChunkHashes: snapshots[1].GetChunkHashes()}}
+	_, _, err = source.trustedSnapshot("heights", managerSource{source}, forged, trustHash, []byte("forged"), "")
+	assert.NoError(t, err)
+	_, _, err = source.trustedSnapshot("heights", managerSource{source}, forged, trustHash, trustAppHash, "")
+	assert.Equal(t, ErrSnapshotUntrusted, errors.Cause(err))
+
+	snapshot, blk, err := source.trustedSnapshot("heights", managerSource{source}, snapshots, trustHash4, snapshots[0].GetAppHash(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), snapshot.GetHeight())
+	assert.True(t, testBlock(4).Equal(blk))
+
+	// fresh node restores from source over gRPC, then continues from the snapshot height
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	fresh, freshApp, freshAddress := newHeightsManager(t, 2)
+	assert.NoError(t, fresh.StateSync("heights", NewStateSyncSource(types.NewStateSyncClient(conn)), trustHash, trustAppHash))
+	freshServer := serveManager(t, fresh, freshAddress)
+	defer freshServer.Stop()
+	go appsdk.RunContext(ctx, freshApp)
+
+	waitHeight(t, fresh, "heights", 6)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6}, freshApp.heights())
+
+	_, err = fresh.GetBlock("heights", 5)
+	assert.Equal(t, ErrBlockNotFound, errors.Cause(err))
+	appHash, err := fresh.DeliverBlock("heights", testBlock(7))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{7}, appHash)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7}, freshApp.heights())
+
+	// restored snapshot is served by the fresh node as well
+	snapshots, err = fresh.ListSnapshots("heights")
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, uint64(6), snapshots[0].GetHeight())
+
+	ranges, err := fresh.VersionHistory("heights")
+	assert.NoError(t, err)
+	assert.Len(t, ranges, 1)
+	assert.Equal(t, uint64(6), ranges[0].GetStartHeight())
+	assert.Equal(t, uint64(7), ranges[0].GetEndHeight())
+}
+
+func TestManager_StateSyncStalled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, app, address := newHeightsManager(t, 2)
+	source := &stalledSource{called: make(chan struct{}, 1)}
+	assert.NoError(t, m.StateSync("heights", source, []byte("trusted"), []byte("trusted")))
+
+	server := serveManager(t, m, address)
+	defer server.Stop()
+	go appsdk.RunContext(ctx, app)
+
+	select {
+	case <-source.called:
+	case <-time.After(5 * time.Second):
+		t.Fatal("snapshots not listed")
+	}
+
+	// application isn't locked while downloading, stopping the manager gives up the download
+	returnsIn(t, func() {
+		height, err := m.height("heights")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), height)
+	})
+	returnsIn(t, m.Stop)
+}
@@ -0,0 +1,224 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// upgradeKey is the key the scheduled upgrade stored under in application's bucket
+	upgradeKey = "upgrade"
+
+	// UpgradeScheduled is the state of upgrade whose height isn't reached
+	UpgradeScheduled = "scheduled"
+
+	// UpgradeHalted is the state of upgrade whose height is reached, blocks wait for the upgraded application
+	UpgradeHalted = "halted"
+
+	// UpgradeApplied is the state of upgrade whose height is processed by the upgraded application
+	UpgradeApplied = "applied"
+)
+
+var (
+	// ErrUpgradeRequired means application must be upgraded to process the block
+	ErrUpgradeRequired = errors.New("application upgrade required")
+
+	// ErrInvalidUpgrade means upgrade proposal is invalid
+	ErrInvalidUpgrade = errors.New("invalid upgrade proposal")
+
+	// ErrApplicationNotRunning means application has no consensus instance running
+	ErrApplicationNotRunning = errors.New("application not running")
+)
+
+// UpgradeStatus reports the latest upgrade of application
+type UpgradeStatus struct {
+	Version string `json:"version"`
+	Height  uint64 `json:"height"`
+	State   string `json:"state"`
+}
+
+// upgradeStore keeps the latest upgrade scheduled by UPGRADE_TX of application,
+// persisted in application's bucket if db given, or in memory
+type upgradeStore struct {
+	name string
+	db   database.Database
+
+	mutex    sync.RWMutex
+	proposal *types.UpgradeProposal
+}
+
+// loadUpgradeStore loads the scheduled upgrade of application name from db
+func loadUpgradeStore(name string, db database.Database) (*upgradeStore, error) {
+	us := &upgradeStore{
+		name: name,
+		db:   db,
+	}
+	if db == nil {
+		return us, nil
+	}
+
+	proposalBytes, err := db.Get(name, upgradeKey)
+	switch err {
+	case nil:
+		us.proposal = new(types.UpgradeProposal)
+		if err := proto.Unmarshal(proposalBytes, us.proposal); err != nil {
+			return nil, errors.Wrap(err, "unmarshal upgrade proposal error")
+		}
+	case database.ErrKeyNotFound:
+	default:
+		return nil, err
+	}
+
+	return us, nil
+}
+
+// latest returns the latest scheduled upgrade, nil if none
+func (us *upgradeStore) latest() *types.UpgradeProposal {
+	us.mutex.RLock()
+	defer us.mutex.RUnlock()
+
+	return us.proposal
+}
+
+// schedule replaces the latest upgrade by proposal
+func (us *upgradeStore) schedule(proposal *types.UpgradeProposal) error {
+	us.mutex.Lock()
+	defer us.mutex.Unlock()
+
+	if us.db != nil {
+		proposalBytes, err := proto.Marshal(proposal)
+		if err != nil {
+			return err
+		}
+
+		if err := us.db.Set(us.name, upgradeKey, proposalBytes); err != nil {
+			return err
+		}
+	}
+
+	us.proposal = proposal
+	return nil
+}
+
+// allows returns ErrUpgradeRequired if block of height must be processed by an application upgraded from version
+func (us *upgradeStore) allows(version *types.AppVersion, height uint64) error {
+	proposal := us.latest()
+	if proposal == nil || height < proposal.GetHeight() {
+		return nil
+	}
+
+	if !version.Upgrades(proposal.GetVersion()) {
+		return errors.Wrapf(ErrUpgradeRequired, "application %s version %s, block %d requires %s", us.name, version.Text(), height, proposal.GetVersion().Text())
+	}
+
+	return nil
+}
+
+// ProposeUpgrade has the consensus instance of application order an UPGRADE_TX, scheduling application upgraded to version at height.
+// From height on, blocks are delivered only to application of version, or a newer one compatible with it.
+func (m *Manager) ProposeUpgrade(application string, version *types.AppVersion, height uint64) error {
+	app, err := m.getApplication(application)
+	if err != nil {
+		return err
+	}
+
+	if err := checkUpgrade(app, &types.UpgradeProposal{Version: version, Height: height}, app.blocks.height()+1); err != nil {
+		return err
+	}
+
+	tx, err := types.NewUpgradeTx(version, height)
+	if err != nil {
+		return err
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.chain == nil {
+		return errors.Wrapf(ErrApplicationNotRunning, "application %s", application)
+	}
+	app.chain.add(tx)
+
+	logger.Infof("application %s upgrade to %s at %d proposed", application, version.Text(), height)
+	return nil
+}
+
+// schedule schedules the upgrade proposed by tx of the block at height, invalid proposal is ignored
+func (m *Manager) schedule(app *application, tx *types.Transaction, height uint64) {
+	proposal := new(types.UpgradeProposal)
+	if err := proto.Unmarshal(tx.GetPayload(), proposal); err != nil {
+		logger.Warningf("application %s block %d unmarshal upgrade proposal error: %s", app.versions.name, height, err)
+		return
+	}
+
+	if err := checkUpgrade(app, proposal, height); err != nil {
+		logger.Warningf("application %s block %d upgrade ignored: %s", app.versions.name, height, err)
+		return
+	}
+
+	if err := app.upgrade.schedule(proposal); err != nil {
+		logger.Errorf("application %s schedule upgrade error: %s", app.versions.name, err)
+		return
+	}
+
+	logger.Infof("application %s upgrade to %s scheduled at %d", app.versions.name, proposal.GetVersion().Text(), proposal.GetHeight())
+}
+
+// checkUpgrade checks proposal included in block of height upgrades application to a newer version at a later height
+func checkUpgrade(app *application, proposal *types.UpgradeProposal, height uint64) error {
+	version := proposal.GetVersion()
+	if version.GetVersion() == nil || version.GetBackwards() == nil {
+		return errors.Wrap(ErrInvalidUpgrade, "missing version")
+	}
+	if proposal.GetHeight() <= height {
+		return errors.Wrapf(ErrInvalidUpgrade, "upgrade height %d not after block %d", proposal.GetHeight(), height)
+	}
+	if current := app.versions.current(); current.GetVersion() != nil && !version.Newer(current) {
+		return errors.Wrapf(ErrInvalidUpgrade, "version %s not newer than %s", version.Text(), current.Text())
+	}
+
+	return nil
+}
+
+// upgradeStatus reports the latest upgrade of app, nil if none
+func upgradeStatus(app *application, height uint64) *UpgradeStatus {
+	proposal := app.upgrade.latest()
+	if proposal == nil {
+		return nil
+	}
+
+	status := &UpgradeStatus{
+		Version: proposal.GetVersion().Text(),
+		Height:  proposal.GetHeight(),
+		State:   UpgradeScheduled,
+	}
+
+	var processed uint64
+	if ranges := app.versions.ranges(); len(ranges) > 0 {
+		processed = ranges[len(ranges)-1].GetEndHeight()
+	}
+	switch {
+	case processed >= proposal.GetHeight():
+		status.State = UpgradeApplied
+	case height >= proposal.GetHeight():
+		status.State = UpgradeHalted
+	}
+
+	return status
+}
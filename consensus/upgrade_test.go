@@ -0,0 +1,107 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsdk "github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitHeights waits app committed blocks up to height
+func waitHeights(t *testing.T, app *heights, height uint64) {
+	for i := 0; i < 100; i++ {
+		if committed := app.heights(); len(committed) > 0 && committed[len(committed)-1] == height {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatalf("application not committed block %d", height)
+}
+
+func TestManager_Upgrade(t *testing.T) {
+	v1, err := types.NewAppVersion("1.0.0", "1.0.0")
+	assert.NoError(t, err)
+	v2, err := types.NewAppVersion("2.0.0", "2.0.0")
+	assert.NoError(t, err)
+
+	m := NewManager()
+	defer m.Stop()
+	assert.NoError(t, m.AddApplication(testGenesis(t, &types.GenesisTxProposal{Name: "heights", Version: v1}), nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	old := &heights{version: v1}
+	oldc := make(chan error, 1)
+	go func() {
+		oldc <- appsdk.RunWith(ctx, old, NewLocalClient(m))
+	}()
+	waitRegistered(t, m, "heights", true)
+
+	// proposals are ordered by the consensus instance
+	assert.Equal(t, ErrApplicationNotRunning, errors.Cause(m.ProposeUpgrade("heights", v2, 3)))
+	assert.Equal(t, ErrInvalidUpgrade, errors.Cause(m.ProposeUpgrade("heights", v2, 1)))
+	assert.Equal(t, ErrInvalidUpgrade, errors.Cause(m.ProposeUpgrade("heights", v1, 3)))
+
+	upgradeTx, err := types.NewUpgradeTx(v2, 3)
+	assert.NoError(t, err)
+	blk := testBlock(1)
+	blk.Txs.Txs = append(blk.Txs.Txs, upgradeTx)
+	_, err = m.DeliverBlock("heights", blk)
+	assert.NoError(t, err)
+
+	status, err := m.Status("heights")
+	assert.NoError(t, err)
+	assert.Equal(t, &UpgradeStatus{Version: v2.Text(), Height: 3, State: UpgradeScheduled}, status.Upgrade)
+
+	// old version processes blocks before upgrade height only, then it's unregistered
+	_, err = m.DeliverBlock("heights", testBlock(2))
+	assert.NoError(t, err)
+	_, err = m.DeliverBlock("heights", testBlock(3))
+	assert.Equal(t, ErrUpgradeRequired, errors.Cause(err))
+	assert.Equal(t, appsdk.ErrRegisterRejected, errors.Cause(<-oldc))
+	assert.Equal(t, []uint64{1, 2}, old.heights())
+
+	_, err = m.DeliverBlock("heights", testBlock(4))
+	assert.Equal(t, ErrApplicationUnregistered, err)
+
+	status, err = m.Status("heights")
+	assert.NoError(t, err)
+	assert.Equal(t, UpgradeHalted, status.Upgrade.State)
+	assert.Equal(t, ErrUpgradeRequired, errors.Cause(m.CheckVersion(&types.AppMetadata{Name: "heights", Version: v1})))
+
+	// upgraded application resumes from the upgrade height
+	upgraded := &heights{version: v2, committed: old.heights()}
+	go appsdk.RunWith(ctx, upgraded, NewLocalClient(m))
+	waitHeights(t, upgraded, 4)
+	assert.Equal(t, []uint64{1, 2, 3, 4}, upgraded.heights())
+
+	status, err = m.Status("heights")
+	assert.NoError(t, err)
+	assert.Equal(t, UpgradeApplied, status.Upgrade.State)
+
+	ranges, err := m.VersionHistory("heights")
+	assert.NoError(t, err)
+	assert.Len(t, ranges, 2)
+	assert.Equal(t, uint64(2), ranges[0].GetEndHeight())
+	assert.True(t, v2.Equal(ranges[1].GetVersion()))
+	assert.Equal(t, uint64(3), ranges[1].GetStartHeight())
+	assert.Equal(t, uint64(4), ranges[1].GetEndHeight())
+}
@@ -0,0 +1,142 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// versionsKey is the key version history stored under in application's bucket
+	versionsKey = "versions"
+)
+
+var (
+	// ErrApplicationIncompatible means application version is incompatible with the recorded one
+	ErrApplicationIncompatible = errors.New("application version incompatible")
+)
+
+// appVersions records which application version processed which block range,
+// persisted in application's bucket if db given
+type appVersions struct {
+	name    string
+	genesis *types.AppVersion
+	db      database.Database
+
+	mutex   sync.Mutex
+	history *types.AppVersionHistory
+}
+
+// loadAppVersions loads version history of application name from db, genesis is the version recorded in genesis block
+func loadAppVersions(name string, genesis *types.AppVersion, db database.Database) (*appVersions, error) {
+	av := &appVersions{
+		name:    name,
+		genesis: genesis,
+		db:      db,
+		history: new(types.AppVersionHistory),
+	}
+	if db == nil {
+		return av, nil
+	}
+
+	historyBytes, err := db.Get(name, versionsKey)
+	switch err {
+	case nil:
+		if err := proto.Unmarshal(historyBytes, av.history); err != nil {
+			return nil, errors.Wrap(err, "unmarshal version history error")
+		}
+	case database.ErrKeyNotFound:
+	default:
+		return nil, err
+	}
+
+	return av, nil
+}
+
+// current returns the version processed the latest block, or genesis version if no block processed
+func (av *appVersions) current() *types.AppVersion {
+	av.mutex.Lock()
+	defer av.mutex.Unlock()
+
+	if n := len(av.history.Ranges); n > 0 {
+		return av.history.Ranges[n-1].GetVersion()
+	}
+
+	return av.genesis
+}
+
+// check checks version is compatible with the current one, any version is accepted if none recorded
+func (av *appVersions) check(version *types.AppVersion) error {
+	current := av.current()
+	if current == nil || current.GetVersion() == nil {
+		return nil
+	}
+
+	if !current.Compatible(version) {
+		return errors.Wrapf(ErrApplicationIncompatible, "application %s version %s, recorded %s", av.name, version.Text(), current.Text())
+	}
+
+	return nil
+}
+
+// record records block of height processed by version, blocks replayed to a resumed application
+// are recorded by the version processed them first
+func (av *appVersions) record(version *types.AppVersion, height uint64) error {
+	av.mutex.Lock()
+	defer av.mutex.Unlock()
+
+	ranges := av.history.Ranges
+	if n := len(ranges); n > 0 && height <= ranges[n-1].EndHeight {
+		return nil
+	}
+
+	if n := len(ranges); n > 0 && ranges[n-1].GetVersion().Equal(version) {
+		ranges[n-1].EndHeight = height
+	} else {
+		logger.Infof("application %s version %s processes blocks from %d", av.name, version.Text(), height)
+		av.history.Ranges = append(ranges, &types.AppVersionRange{
+			Version:     version,
+			StartHeight: height,
+			EndHeight:   height,
+		})
+	}
+
+	if av.db == nil {
+		return nil
+	}
+
+	historyBytes, err := proto.Marshal(av.history)
+	if err != nil {
+		return err
+	}
+
+	return av.db.Set(av.name, versionsKey, historyBytes)
+}
+
+// ranges returns a copy of the recorded version ranges
+func (av *appVersions) ranges() []*types.AppVersionRange {
+	av.mutex.Lock()
+	defer av.mutex.Unlock()
+
+	ranges := make([]*types.AppVersionRange, len(av.history.Ranges))
+	for i, r := range av.history.Ranges {
+		ranges[i] = proto.Clone(r).(*types.AppVersionRange)
+	}
+
+	return ranges
+}
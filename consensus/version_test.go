@@ -0,0 +1,71 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package consensus
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mintzhao/topachain/common/database/badger"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var testdbdir = "./testdata"
+
+func mustAppVersion(t *testing.T, version, backwards string) *types.AppVersion {
+	av, err := types.NewAppVersion(version, backwards)
+	assert.NoError(t, err)
+
+	return av
+}
+
+func TestAppVersions(t *testing.T) {
+	os.Mkdir(testdbdir, os.ModePerm)
+	defer os.RemoveAll(testdbdir)
+
+	db, err := badger.New(testdbdir)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	v100 := mustAppVersion(t, "1.0.0", "1.0.0")
+	v110 := mustAppVersion(t, "1.1.0", "1.0.0")
+	v200 := mustAppVersion(t, "2.0.0", "2.0.0")
+
+	// any version accepted without genesis version
+	av, err := loadAppVersions("kvset", nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, av.check(v200))
+
+	av, err = loadAppVersions("kvset", v100, db)
+	assert.NoError(t, err)
+	assert.NoError(t, av.check(v110))
+	assert.Equal(t, ErrApplicationIncompatible, errors.Cause(av.check(v200)))
+
+	assert.NoError(t, av.record(v100, 1))
+	assert.NoError(t, av.record(v100, 2))
+	assert.NoError(t, av.record(v110, 3))
+
+	// history survives reload, latest version is checked against
+	av, err = loadAppVersions("kvset", v100, db)
+	assert.NoError(t, err)
+	ranges := av.ranges()
+	assert.Len(t, ranges, 2)
+	assert.True(t, v100.Equal(ranges[0].GetVersion()))
+	assert.Equal(t, uint64(1), ranges[0].GetStartHeight())
+	assert.Equal(t, uint64(2), ranges[0].GetEndHeight())
+	assert.True(t, v110.Equal(ranges[1].GetVersion()))
+	assert.Equal(t, uint64(3), ranges[1].GetStartHeight())
+	assert.True(t, v110.Equal(av.current()))
+}
@@ -12,7 +12,11 @@
 // limitations under the License.
 package main
 
-import "github.com/mintzhao/topachain/cmd"
+import (
+	// applications linked into the binary, served in-process if configured local
+	_ "github.com/mintzhao/topachain/application/kvset"
+	"github.com/mintzhao/topachain/cmd"
+)
 
 func main() {
 	cmd.Execute()
@@ -0,0 +1,208 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	topalogging "github.com/mintzhao/topachain/common/logging"
+	"github.com/mintzhao/topachain/consensus"
+	"github.com/mintzhao/topachain/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// loggingPath is the admin endpoint of logging levels,
+	// GET /logging lists configured module levels, PUT /logging/<module> with level as body changes one
+	loggingPath = "/logging"
+
+	// snapshotsPath is the admin endpoint of snapshots, GET /snapshots/<application> lists the stored ones,
+	// with the header hash of their blocks and their app hash, which other nodes state sync trusting
+	snapshotsPath = "/snapshots/"
+
+	// applicationsPath is the admin endpoint of hosted applications, GET /applications reports status of each,
+	// GET /applications/<application> reports one, POST /applications/<application>/start or /stop starts or stops it,
+	// POST /applications/<application>/upgrade with upgradeRequest as body proposes an upgrade
+	applicationsPath = "/applications"
+)
+
+// upgradeRequest proposes application upgraded to Version at Height
+type upgradeRequest struct {
+	Version   string `json:"version"`
+	Backwards string `json:"backwards"`
+	Height    uint64 `json:"height"`
+}
+
+// snapshotInfo describes a stored snapshot
+type snapshotInfo struct {
+	Height     uint64 `json:"height"`
+	Chunks     int    `json:"chunks"`
+	AppHash    string `json:"appHash"`
+	HeaderHash string `json:"headerHash"`
+}
+
+// newAdminServer creates the admin http server of node n listening at address
+func newAdminServer(address string, n *Node) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(loggingPath, handleLogging)
+	mux.HandleFunc(loggingPath+"/", handleLogging)
+	mux.HandleFunc(snapshotsPath, func(w http.ResponseWriter, r *http.Request) {
+		handleSnapshots(w, r, n.manager)
+	})
+	mux.HandleFunc(applicationsPath, func(w http.ResponseWriter, r *http.Request) {
+		handleApplications(w, r, n)
+	})
+	mux.HandleFunc(applicationsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		handleApplications(w, r, n)
+	})
+
+	return &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+}
+
+// handleLogging lists or changes logging levels
+func handleLogging(w http.ResponseWriter, r *http.Request) {
+	module := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, loggingPath), "/")
+
+	switch {
+	case r.Method == http.MethodGet && module == "":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(topalogging.Levels())
+	case r.Method == http.MethodPut && module != "":
+		level, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := topalogging.SetLevel(module, strings.TrimSpace(string(level))); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		logger.Infof("logging level of module %q set by admin %s", module, r.RemoteAddr)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// handleSnapshots lists the stored snapshots of application
+func handleSnapshots(w http.ResponseWriter, r *http.Request, manager *consensus.Manager) {
+	application := strings.TrimPrefix(r.URL.Path, snapshotsPath)
+	if r.Method != http.MethodGet || application == "" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	snapshots, err := manager.ListSnapshots(application)
+	if errors.Cause(err) == consensus.ErrApplicationUnknown {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	infos := make([]*snapshotInfo, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		headerHash, err := manager.BlockHash(application, snapshot.GetHeight())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		infos = append(infos, &snapshotInfo{
+			Height:     snapshot.GetHeight(),
+			Chunks:     len(snapshot.GetChunkHashes()),
+			AppHash:    hex.EncodeToString(snapshot.GetAppHash()),
+			HeaderHash: hex.EncodeToString(headerHash),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(infos)
+}
+
+// handleApplications reports status of hosted applications, or starts or stops one
+func handleApplications(w http.ResponseWriter, r *http.Request, n *Node) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, applicationsPath), "/")
+	parts := strings.Split(path, "/")
+
+	var (
+		result interface{}
+		err    error
+	)
+	switch {
+	case r.Method == http.MethodGet && path == "":
+		result, err = n.Status()
+	case r.Method == http.MethodGet && len(parts) == 1:
+		result, err = n.manager.Status(parts[0])
+	case r.Method == http.MethodPost && len(parts) == 2 && parts[1] == "start":
+		if err = n.StartApplication(parts[0]); err == nil {
+			logger.Infof("application %s started by admin %s", parts[0], r.RemoteAddr)
+			result, err = n.manager.Status(parts[0])
+		}
+	case r.Method == http.MethodPost && len(parts) == 2 && parts[1] == "stop":
+		if err = n.StopApplication(parts[0]); err == nil {
+			logger.Infof("application %s stopped by admin %s", parts[0], r.RemoteAddr)
+			result, err = n.manager.Status(parts[0])
+		}
+	case r.Method == http.MethodPost && len(parts) == 2 && parts[1] == "upgrade":
+		req := new(upgradeRequest)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Backwards == "" {
+			req.Backwards = req.Version
+		}
+
+		var version *types.AppVersion
+		if version, err = types.NewAppVersion(req.Version, req.Backwards); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err = n.manager.ProposeUpgrade(parts[0], version, req.Height); err == nil {
+			logger.Infof("application %s upgrade to %s at %d proposed by admin %s", parts[0], version.Text(), req.Height, r.RemoteAddr)
+			result, err = n.manager.Status(parts[0])
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch errors.Cause(err) {
+	case consensus.ErrApplicationUnknown:
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case consensus.ErrInvalidUpgrade, consensus.ErrApplicationNotRunning:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,49 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mintzhao/topachain/common/genesis"
+	"github.com/mintzhao/topachain/consensus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleApplications_Upgrade(t *testing.T) {
+	n := &Node{manager: consensus.NewManager()}
+	defer n.manager.Stop()
+	blk, err := genesis.GenesisBlock("kvset", nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, n.manager.AddApplication(blk, nil))
+	server := httptest.NewServer(newAdminServer("", n).Handler)
+	defer server.Close()
+
+	upgrade := func(application, body string) int {
+		resp, err := http.Post(server.URL+applicationsPath+"/"+application+"/upgrade", "application/json", strings.NewReader(body))
+		assert.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusBadRequest, upgrade("kvset", "not json"))
+	assert.Equal(t, http.StatusBadRequest, upgrade("kvset", `{"version": "x.y"}`))
+	assert.Equal(t, http.StatusNotFound, upgrade("unknown", `{"version": "1.1.0", "height": 10}`))
+
+	// refused by consensus
+	assert.Equal(t, http.StatusBadRequest, upgrade("kvset", `{"version": "1.1.0", "height": 0}`))
+	assert.Equal(t, http.StatusBadRequest, upgrade("kvset", `{"version": "1.1.0", "height": 10}`))
+}
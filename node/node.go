@@ -0,0 +1,454 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mintzhao/topachain/application"
+	"github.com/mintzhao/topachain/common/comm"
+	"github.com/mintzhao/topachain/common/database"
+	"github.com/mintzhao/topachain/common/database/badger"
+	"github.com/mintzhao/topachain/common/genesis"
+	_ "github.com/mintzhao/topachain/common/logging"
+	"github.com/mintzhao/topachain/config"
+	"github.com/mintzhao/topachain/consensus"
+	"github.com/mintzhao/topachain/types"
+	"github.com/op/go-logging"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+const (
+	// genesisKey is the key genesis block stored under in application's bucket
+	genesisKey = "genesis"
+
+	// localSuffix suffixes the state directory of local applications
+	localSuffix = ".local"
+)
+
+var (
+	// ErrMissingConfig indicates a required config section is absent
+	ErrMissingConfig = errors.New("missing config")
+
+	// ErrUnsupportedDatabase indicates config.Database.Type is unknown
+	ErrUnsupportedDatabase = errors.New("unsupported database type")
+
+	// ErrGenesisMismatch indicates the genesis block differs from the one stored in database
+	ErrGenesisMismatch = errors.New("genesis block mismatch with database")
+
+	// ErrNoApplication indicates node starts without any genesis block
+	ErrNoApplication = errors.New("no application genesis block")
+
+	// ErrApplicationDuplicated indicates genesis blocks of the same application loaded
+	ErrApplicationDuplicated = errors.New("application duplicated")
+
+	// ErrInvalidApplicationName means application name can't name its database directory
+	ErrInvalidApplicationName = errors.New("invalid application name")
+
+	// logger
+	logger = logging.MustGetLogger("node")
+)
+
+// Node is a full consensus node, hosting the consensus manager and serving applications over gRPC.
+// A node hosts any number of applications, each loaded from its own genesis block with its own database,
+// block sequence and consensus instance.
+type Node struct {
+	conf    *config.Config
+	manager *consensus.Manager
+	server  *grpc.Server
+	admin   *http.Server
+
+	mutex sync.Mutex
+
+	// dbs are databases of the hosted applications
+	dbs map[string]database.Database
+
+	// stateSyncs restore fresh applications from peer nodes, keyed by application name
+	stateSyncs map[string]*stateSyncPeer
+
+	// locals are the running local applications
+	locals map[string]*localApp
+
+	// ctx is cancelled on Stop, which stops local applications
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// localApp is an application served in-process
+type localApp struct {
+	cancel context.CancelFunc
+
+	// done is closed once application stopped serving and closed
+	done chan struct{}
+}
+
+// stateSyncPeer is the peer node a fresh application restores its state from
+type stateSyncPeer struct {
+	address      string
+	trustHash    []byte
+	trustAppHash []byte
+}
+
+// New constructs a Node from conf, nothing is started until Start
+func New(conf *config.Config) (*Node, error) {
+	if conf.Node == nil || conf.Common == nil || conf.Common.Database == nil {
+		return nil, ErrMissingConfig
+	}
+
+	server, err := comm.NewServerBuilder(conf.Node.GRPC).
+		WithTLS(conf.Node.TLS).
+		WithUnaryInterceptors(comm.RecoveryUnaryServer, comm.RequestIDUnaryServer, comm.LoggingUnaryServer).
+		WithStreamInterceptors(comm.RecoveryStreamServer, comm.RequestIDStreamServer, comm.LoggingStreamServer).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &Node{
+		conf:       conf,
+		manager:    consensus.NewManager(),
+		server:     server,
+		dbs:        make(map[string]database.Database),
+		stateSyncs: make(map[string]*stateSyncPeer),
+		locals:     make(map[string]*localApp),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	// blocks fit the message size limits of server
+	if conf.Node.GRPC != nil {
+		n.manager.SetMaxBlockSize(conf.Node.GRPC.MaxBlockSize)
+	}
+	types.RegisterApplicationServer(n.server, consensus.NewApplicationServer(n.manager))
+	types.RegisterStateSyncServer(n.server, consensus.NewStateSyncServer(n.manager))
+	types.RegisterEventsServer(n.server, consensus.NewEventsServer(n.manager))
+	types.RegisterHealthServer(n.server, comm.NewHealthServer())
+	if conf.Node.AdminAddress != "" {
+		n.admin = newAdminServer(conf.Node.AdminAddress, n)
+	}
+
+	return n, nil
+}
+
+// StateSync has fresh application restore its state from a snapshot served by the node at peer address,
+// taken at the block whose header hashes to trustHash, of state hashing to trustAppHash.
+// Empty application means the only one hosted. It must be called before Start.
+func (n *Node) StateSync(application, peer string, trustHash, trustAppHash []byte) {
+	n.stateSyncs[application] = &stateSyncPeer{
+		address:      peer,
+		trustHash:    trustHash,
+		trustAppHash: trustAppHash,
+	}
+}
+
+// Start loads an application from each of genesisFiles, starts them and serves applications until Stop,
+// databases are closed before Start returns
+func (n *Node) Start(genesisFiles ...string) error {
+	if len(genesisFiles) == 0 {
+		return ErrNoApplication
+	}
+
+	// databases are closed once blocks being delivered are done
+	defer n.closeDatabases()
+	defer n.manager.Stop()
+	for _, genesisFile := range genesisFiles {
+		if err := n.loadGenesis(genesisFile); err != nil {
+			return errors.Wrapf(err, "load genesis block %s error", genesisFile)
+		}
+	}
+
+	if err := n.startStateSyncs(); err != nil {
+		return err
+	}
+
+	// applications stop with node
+	defer n.stopLocals()
+	for _, name := range n.manager.Applications() {
+		if err := n.StartApplication(name); err != nil {
+			return err
+		}
+	}
+	for name, transport := range n.conf.Node.Applications {
+		if _, ok := n.dbs[name]; !ok && transport == "local" {
+			logger.Warningf("local application %s has no genesis block loaded, not served", name)
+		}
+	}
+
+	lis, err := net.Listen("tcp", n.conf.Node.Address)
+	if err != nil {
+		return errors.Wrap(err, "listen error")
+	}
+
+	if n.admin != nil {
+		adminLis, err := net.Listen("tcp", n.admin.Addr)
+		if err != nil {
+			lis.Close()
+			return errors.Wrap(err, "admin listen error")
+		}
+
+		go func() {
+			logger.Infof("admin serving at %s", adminLis.Addr())
+			if err := n.admin.Serve(adminLis); err != nil && err != http.ErrServerClosed {
+				logger.Errorf("admin serve error: %s", err)
+			}
+		}()
+	}
+
+	logger.Infof("node serving at %s", lis.Addr())
+	return n.server.Serve(lis)
+}
+
+// Stop stops serving gracefully, which makes Start return
+func (n *Node) Stop() {
+	n.cancel()
+	if n.admin != nil {
+		if err := n.admin.Shutdown(context.Background()); err != nil {
+			logger.Errorf("stop admin error: %s", err)
+		}
+	}
+	n.manager.Stop()
+	n.server.GracefulStop()
+}
+
+// StartApplication starts the consensus instance of hosted application, and serves it in-process if it's configured local
+func (n *Node) StartApplication(name string) error {
+	if err := n.manager.StartApplication(name); err != nil {
+		return err
+	}
+
+	if n.conf.Node.Applications[name] != "local" {
+		return nil
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if _, ok := n.locals[name]; ok {
+		return nil
+	}
+
+	factory, err := application.GetFactory(name)
+	if err != nil {
+		return err
+	}
+
+	app, err := factory(localDir(n.conf.Common.Database, name))
+	if err != nil {
+		return errors.Wrapf(err, "create application %s error", name)
+	}
+
+	ctx, cancel := context.WithCancel(n.ctx)
+	local := &localApp{cancel: cancel, done: make(chan struct{})}
+	n.locals[name] = local
+	go func() {
+		defer close(local.done)
+		if err := application.RunWith(ctx, app, consensus.NewLocalClient(n.manager)); err != nil && err != context.Canceled {
+			logger.Errorf("local application %s stopped: %s", name, err)
+		}
+
+		if closer, ok := app.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				logger.Errorf("close local application %s error: %s", name, err)
+			}
+		}
+	}()
+
+	logger.Infof("application %s served in-process", name)
+	return nil
+}
+
+// StopApplication stops hosted application, other applications keep running.
+// Local application is closed before it returns, so that it can be started again.
+func (n *Node) StopApplication(name string) error {
+	n.mutex.Lock()
+	local, ok := n.locals[name]
+	delete(n.locals, name)
+	n.mutex.Unlock()
+
+	if ok {
+		local.cancel()
+		<-local.done
+	}
+
+	return n.manager.StopApplication(name)
+}
+
+// stopLocals stops all applications served in-process and waits them closed
+func (n *Node) stopLocals() {
+	n.cancel()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for name, local := range n.locals {
+		<-local.done
+		delete(n.locals, name)
+	}
+}
+
+// Status reports the state of each hosted application
+func (n *Node) Status() ([]*consensus.AppStatus, error) {
+	var statuses []*consensus.AppStatus
+	for _, name := range n.manager.Applications() {
+		status, err := n.manager.Status(name)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// loadGenesis reads the genesis block, opens the database of its application, stores the block at first start
+// and adds the application to manager
+func (n *Node) loadGenesis(genesisFile string) error {
+	f, err := os.Open(genesisFile)
+	if err != nil {
+		return errors.Wrap(err, "open genesis block error")
+	}
+	defer f.Close()
+
+	blk, err := genesis.ReadGenesisBlock(f)
+	if err != nil {
+		return err
+	}
+
+	if err := genesis.VerifyGenesisBlock(blk); err != nil {
+		return err
+	}
+
+	gtxp, err := genesis.GenesisProposal(blk)
+	if err != nil {
+		return err
+	}
+
+	blkBytes, err := proto.Marshal(blk)
+	if err != nil {
+		return err
+	}
+
+	name := gtxp.GetName()
+	if _, ok := n.dbs[name]; ok {
+		return errors.Wrapf(ErrApplicationDuplicated, "application %s", name)
+	}
+
+	db, err := openDatabase(n.conf.Common.Database, name)
+	if err != nil {
+		return err
+	}
+	n.dbs[name] = db
+
+	stored, err := db.Get(name, genesisKey)
+	switch err {
+	case nil:
+		if !bytes.Equal(stored, blkBytes) {
+			return ErrGenesisMismatch
+		}
+	case database.ErrKeyNotFound:
+		if err := db.Set(name, genesisKey, blkBytes); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	logger.Infof("genesis block of application %s loaded", name)
+	return n.manager.AddApplication(blk, db)
+}
+
+// startStateSyncs dials the peers fresh applications restore from, connections are closed with node
+func (n *Node) startStateSyncs() error {
+	for name, peer := range n.stateSyncs {
+		if name == "" {
+			applications := n.manager.Applications()
+			if len(applications) != 1 {
+				return errors.Errorf("state sync application unspecified, %d applications hosted", len(applications))
+			}
+			name = applications[0]
+		}
+
+		conn, err := comm.NewClientBuilder(n.conf.Node.GRPC).
+			WithTLS(n.conf.Node.TLS).
+			WithNonBlocking().
+			WithUnaryInterceptors(comm.RequestIDUnaryClient).
+			Dial(peer.address)
+		if err != nil {
+			return errors.Wrap(err, "dial state sync peer error")
+		}
+		go func() {
+			<-n.ctx.Done()
+			conn.Close()
+		}()
+
+		source := consensus.NewStateSyncSource(types.NewStateSyncClient(conn))
+		if err := n.manager.StateSync(name, source, peer.trustHash, peer.trustAppHash); err != nil {
+			return err
+		}
+		logger.Infof("application %s syncs state from %s", name, peer.address)
+	}
+
+	return nil
+}
+
+// closeDatabases closes databases of the hosted applications
+func (n *Node) closeDatabases() {
+	for name, db := range n.dbs {
+		if err := db.Close(); err != nil {
+			logger.Errorf("close database of application %s error: %s", name, err)
+		}
+	}
+}
+
+// openDatabase opens the database of application configured by conf, each application has its own
+func openDatabase(conf *config.Database, application string) (database.Database, error) {
+	switch conf.Type {
+	case "badger":
+		if conf.Badger == nil {
+			return nil, ErrMissingConfig
+		}
+
+		// the name comes from genesis block, it must not escape the data directory
+		if application == "" || application == "." || application == ".." || strings.ContainsAny(application, `/\`) {
+			return nil, errors.Wrapf(ErrInvalidApplicationName, "%q", application)
+		}
+
+		// the state directories of local applications are reserved
+		if strings.HasSuffix(application, localSuffix) {
+			return nil, errors.Wrapf(ErrInvalidApplicationName, "%q", application)
+		}
+
+		dir := filepath.Join(conf.Badger.Dir, application)
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return nil, err
+		}
+
+		return badger.New(dir)
+	default:
+		return nil, ErrUnsupportedDatabase
+	}
+}
+
+// localDir returns the directory local application keeps its own state under, next to its consensus database
+func localDir(conf *config.Database, application string) string {
+	return filepath.Join(conf.Badger.Dir, application+localSuffix)
+}
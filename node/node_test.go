@@ -0,0 +1,43 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mintzhao/topachain/config"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenDatabase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "topa-node")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := &config.Database{Type: "badger", Badger: &config.Badger{Dir: filepath.Join(dir, "data")}}
+	db, err := openDatabase(conf, "kvset")
+	assert.NoError(t, err)
+	db.Close()
+
+	// application names never escape the data directory
+	for _, name := range []string{"", ".", "..", "../kvset", "a/b", `a\b`, "kvset.local"} {
+		_, err := openDatabase(conf, name)
+		assert.Equal(t, ErrInvalidApplicationName, errors.Cause(err), name)
+	}
+	_, err = os.Stat(filepath.Join(dir, "kvset"))
+	assert.True(t, os.IsNotExist(err))
+}
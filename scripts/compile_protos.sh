@@ -19,3 +19,8 @@ set -eux
 for protos in $(find "types" -name '*.proto' -exec dirname {} \; | sort | uniq) ; do
    protoc --proto_path="types" --gogoslick_out=plugins=grpc:$GOPATH/src "$protos"/*.proto
 done
+
+# example applications
+for protos in $(find "application" -name '*.proto' -exec dirname {} \; | sort | uniq) ; do
+   protoc --proto_path="$protos" --gogoslick_out=plugins=grpc:$GOPATH/src "$protos"/*.proto
+done
@@ -13,14 +13,24 @@
 package types
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 )
 
-// Software versioning: https://en.wikipedia.org/wiki/Software_versioning
+// Semantic Versioning 2.0.0: https://semver.org
 var (
 	ErrEmptyVersion = errors.New("empty version string")
+
+	ErrInvalidVersion = errors.New("invalid semantic version")
+
+	// semverRegexp is the regular expression suggested by semver.org
+	semverRegexp = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
 )
 
 func newVersion(ver string) (*Version, error) {
@@ -29,26 +39,28 @@ func newVersion(ver string) (*Version, error) {
 		return nil, ErrEmptyVersion
 	}
 
-	var major, minor, build string
-	vslice := strings.SplitN(ver, ".", 3)
-	if len(vslice) >= 1 {
-		major = vslice[0]
-	}
-	if len(vslice) >= 2 {
-		minor = vslice[1]
+	matches := semverRegexp.FindStringSubmatch(ver)
+	if matches == nil {
+		return nil, errors.Wrap(ErrInvalidVersion, ver)
 	}
-	if len(vslice) >= 3 {
-		build = vslice[2]
+
+	// numeric identifiers must fit in uint64
+	for _, num := range matches[1:4] {
+		if _, err := strconv.ParseUint(num, 10, 64); err != nil {
+			return nil, errors.Wrap(ErrInvalidVersion, ver)
+		}
 	}
 
 	return &Version{
-		Major: major,
-		Minor: minor,
-		Build: build,
+		Major:      matches[1],
+		Minor:      matches[2],
+		Build:      matches[3],
+		Prerelease: matches[4],
+		Metadata:   matches[5],
 	}, nil
 }
 
-// Compare returns an integer comparing two versions lexicographically.
+// Compare returns an integer comparing two versions by semantic versioning precedence, build metadata is ignored.
 // The result will be 0 if v==other, -1 if v < other, and +1 if v > other.
 func (v *Version) compare(other *Version) int {
 	if v == nil {
@@ -59,31 +71,94 @@ func (v *Version) compare(other *Version) int {
 		return 1
 	}
 
-	if v.GetMajor() > other.GetMajor() {
-		return 1
+	if c := compareNumeric(v.GetMajor(), other.GetMajor()); c != 0 {
+		return c
 	}
 
-	if v.GetMajor() < other.GetMajor() {
-		return -1
+	if c := compareNumeric(v.GetMinor(), other.GetMinor()); c != 0 {
+		return c
+	}
+
+	if c := compareNumeric(v.GetBuild(), other.GetBuild()); c != 0 {
+		return c
 	}
 
-	if v.GetMinor() > other.GetMinor() {
+	return comparePrerelease(v.GetPrerelease(), other.GetPrerelease())
+}
+
+// compareNumeric compares two numeric identifiers, which is 0 if not a number, e.g. missing in old versions
+func compareNumeric(a, b string) int {
+	an, _ := strconv.ParseUint(a, 10, 64)
+	bn, _ := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case an > bn:
 		return 1
+	case an < bn:
+		return -1
+	default:
+		return 0
 	}
+}
 
-	if v.GetMinor() < other.GetMinor() {
+// comparePrerelease compares two pre-release versions, a version without pre-release has higher precedence
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
 		return -1
 	}
 
-	if v.GetBuild() > other.GetBuild() {
+	aids := strings.Split(a, ".")
+	bids := strings.Split(b, ".")
+	for i := 0; i < len(aids) && i < len(bids); i++ {
+		if c := compareIdentifier(aids[i], bids[i]); c != 0 {
+			return c
+		}
+	}
+
+	// a larger set of pre-release fields has higher precedence if all the preceding identifiers are equal
+	switch {
+	case len(aids) > len(bids):
 		return 1
+	case len(aids) < len(bids):
+		return -1
+	default:
+		return 0
 	}
+}
 
-	if v.GetBuild() < other.GetBuild() {
+// compareIdentifier compares two pre-release identifiers, numeric ones have lower precedence than alphanumeric ones
+func compareIdentifier(a, b string) int {
+	_, aerr := strconv.ParseUint(a, 10, 64)
+	_, berr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case aerr == nil && berr == nil:
+		return compareNumeric(a, b)
+	case aerr == nil:
 		return -1
+	case berr == nil:
+		return 1
 	}
 
-	return 0
+	return strings.Compare(a, b)
+}
+
+// Text returns version in its string form, e.g. 1.0.2-rc.1+build.5
+func (v *Version) Text() string {
+	text := strings.Join([]string{v.GetMajor(), v.GetMinor(), v.GetBuild()}, ".")
+	if v.GetPrerelease() != "" {
+		text += "-" + v.GetPrerelease()
+	}
+	if v.GetMetadata() != "" {
+		text += "+" + v.GetMetadata()
+	}
+
+	return text
 }
 
 // Equal check whether two version at same stage.
@@ -103,14 +178,23 @@ func NewAppVersion(version, backwards string) (*AppVersion, error) {
 		return nil, err
 	}
 
+	if ver.compare(back) < 0 {
+		return nil, errors.Errorf("backwards version %s is newer than version %s", backwards, version)
+	}
+
 	return &AppVersion{
 		Version:   ver,
 		Backwards: back,
 	}, nil
 }
 
-// Compatible check whether av & other can compatible with each other
+// Compatible check whether av & other can compatible with each other,
+// that is the newer one's backwards version isn't newer than the older one.
 func (av *AppVersion) Compatible(other *AppVersion) bool {
+	if av == nil || other == nil {
+		return false
+	}
+
 	if av.Version.equal(other.Version) {
 		return true
 	}
@@ -121,3 +205,26 @@ func (av *AppVersion) Compatible(other *AppVersion) bool {
 
 	return av.Version.compare(other.Backwards) >= 0
 }
+
+// Newer returns whether av is a newer version than other
+func (av *AppVersion) Newer(other *AppVersion) bool {
+	if av.GetVersion() == nil || other.GetVersion() == nil {
+		return false
+	}
+
+	return av.Version.compare(other.Version) > 0
+}
+
+// Upgrades returns whether av is target version, or a newer one compatible with it
+func (av *AppVersion) Upgrades(target *AppVersion) bool {
+	if av.GetVersion() == nil || target.GetVersion() == nil {
+		return false
+	}
+
+	return av.Version.compare(target.Version) >= 0 && target.Compatible(av)
+}
+
+// Text returns app version in string form, e.g. 1.2.0(backwards 1.0.1)
+func (av *AppVersion) Text() string {
+	return fmt.Sprintf("%s(backwards %s)", av.GetVersion().Text(), av.GetBackwards().Text())
+}
@@ -9,22 +9,62 @@
 		common.proto
 		config.proto
 		consensus.proto
+		event.proto
+		health.proto
+		snapshot.proto
 
 	It has these top-level messages:
 		AppMessage
 		AppMessageHeader
+		RegisterRequest
+		RegisterAck
+		CheckTxRequest
+		CheckTxResponse
+		BeginBlockRequest
+		BeginBlockResponse
+		DeliverTxRequest
+		DeliverTxResponse
+		EndBlockRequest
+		EndBlockResponse
+		CommitRequest
+		CommitResponse
+		QueryRequest
+		QueryResponse
+		AppError
+		Heartbeat
+		TakeSnapshotRequest
+		TakeSnapshotResponse
+		LoadSnapshotChunkRequest
+		LoadSnapshotChunkResponse
+		ApplySnapshotChunkRequest
+		ApplySnapshotChunkResponse
 		AppMetadata
-		Version
-		AppVersion
+		AppVersionRange
+		AppVersionHistory
 		Empty
 		BlockHeader
 		BlockTxs
 		Block
 		Transaction
+		UpgradeProposal
 		GenesisTxProposal
+		Version
+		AppVersion
 		AppConfig
 		ConsensusBlockConfig
 		TxResponseSync
+		Event
+		EventAttribute
+		TxEvent
+		EventFilter
+		GetEventsRequest
+		GetEventsResponse
+		Snapshot
+		ListSnapshotsRequest
+		ListSnapshotsResponse
+		GetSnapshotChunkRequest
+		GetSnapshotChunkResponse
+		GetBlockRequest
 */
 package types
 
@@ -58,17 +98,58 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 // AppMessageType
+// Consensus module sends requests to application, application answers each with a message of the same type
+// and request id, or ERROR. Payload of each type is the message named in comment, in request/response order.
 type AppMessageType int32
 
 const (
-	UNKNOWN AppMessageType = 0
+	UNKNOWN              AppMessageType = 0
+	REGISTER             AppMessageType = 1
+	CHECK_TX             AppMessageType = 2
+	BEGIN_BLOCK          AppMessageType = 3
+	DELIVER_TX           AppMessageType = 4
+	END_BLOCK            AppMessageType = 5
+	COMMIT               AppMessageType = 6
+	QUERY                AppMessageType = 7
+	ERROR                AppMessageType = 8
+	REGISTER_ACK         AppMessageType = 9
+	HEARTBEAT            AppMessageType = 10
+	SNAPSHOT             AppMessageType = 11
+	LOAD_SNAPSHOT_CHUNK  AppMessageType = 12
+	APPLY_SNAPSHOT_CHUNK AppMessageType = 13
 )
 
 var AppMessageType_name = map[int32]string{
-	0: "UNKNOWN",
+	0:  "UNKNOWN",
+	1:  "REGISTER",
+	2:  "CHECK_TX",
+	3:  "BEGIN_BLOCK",
+	4:  "DELIVER_TX",
+	5:  "END_BLOCK",
+	6:  "COMMIT",
+	7:  "QUERY",
+	8:  "ERROR",
+	9:  "REGISTER_ACK",
+	10: "HEARTBEAT",
+	11: "SNAPSHOT",
+	12: "LOAD_SNAPSHOT_CHUNK",
+	13: "APPLY_SNAPSHOT_CHUNK",
 }
 var AppMessageType_value = map[string]int32{
-	"UNKNOWN": 0,
+	"UNKNOWN":              0,
+	"REGISTER":             1,
+	"CHECK_TX":             2,
+	"BEGIN_BLOCK":          3,
+	"DELIVER_TX":           4,
+	"END_BLOCK":            5,
+	"COMMIT":               6,
+	"QUERY":                7,
+	"ERROR":                8,
+	"REGISTER_ACK":         9,
+	"HEARTBEAT":            10,
+	"SNAPSHOT":             11,
+	"LOAD_SNAPSHOT_CHUNK":  12,
+	"APPLY_SNAPSHOT_CHUNK": 13,
 }
 
 func (AppMessageType) EnumDescriptor() ([]byte, []int) { return fileDescriptorApplication, []int{0} }
@@ -99,9 +180,11 @@ func (m *AppMessage) GetPayload() []byte {
 
 // AppMessageHeader
 type AppMessageHeader struct {
-	Meta      *AppMetadata   `protobuf:"bytes,1,opt,name=meta" json:"meta,omitempty"`
-	Timestamp int64          `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Type      AppMessageType `protobuf:"varint,3,opt,name=type,proto3,enum=types.AppMessageType" json:"type,omitempty"`
+	Meta            *AppMetadata   `protobuf:"bytes,1,opt,name=meta" json:"meta,omitempty"`
+	Timestamp       int64          `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Type            AppMessageType `protobuf:"varint,3,opt,name=type,proto3,enum=types.AppMessageType" json:"type,omitempty"`
+	ProtocolVersion uint32         `protobuf:"varint,4,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	RequestId       uint64         `protobuf:"varint,5,opt,name=requestId,proto3" json:"requestId,omitempty"`
 }
 
 func (m *AppMessageHeader) Reset()                    { *m = AppMessageHeader{} }
@@ -129,6 +212,430 @@ func (m *AppMessageHeader) GetType() AppMessageType {
 	return UNKNOWN
 }
 
+func (m *AppMessageHeader) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *AppMessageHeader) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+// RegisterRequest identifies the application on AppStream
+type RegisterRequest struct {
+	Meta       *AppMetadata `protobuf:"bytes,1,opt,name=meta" json:"meta,omitempty"`
+	LastHeight uint64       `protobuf:"varint,2,opt,name=lastHeight,proto3" json:"lastHeight,omitempty"`
+}
+
+func (m *RegisterRequest) Reset()                    { *m = RegisterRequest{} }
+func (*RegisterRequest) ProtoMessage()               {}
+func (*RegisterRequest) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{2} }
+
+func (m *RegisterRequest) GetMeta() *AppMetadata {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *RegisterRequest) GetLastHeight() uint64 {
+	if m != nil {
+		return m.LastHeight
+	}
+	return 0
+}
+
+// RegisterAck accepts the application
+type RegisterAck struct {
+	ProtocolVersion uint32 `protobuf:"varint,1,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Height          uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *RegisterAck) Reset()                    { *m = RegisterAck{} }
+func (*RegisterAck) ProtoMessage()               {}
+func (*RegisterAck) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{3} }
+
+func (m *RegisterAck) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *RegisterAck) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// CheckTxRequest
+type CheckTxRequest struct {
+	Tx *Transaction `protobuf:"bytes,1,opt,name=tx" json:"tx,omitempty"`
+}
+
+func (m *CheckTxRequest) Reset()                    { *m = CheckTxRequest{} }
+func (*CheckTxRequest) ProtoMessage()               {}
+func (*CheckTxRequest) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{4} }
+
+func (m *CheckTxRequest) GetTx() *Transaction {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+// CheckTxResponse, code 0 means tx is valid
+type CheckTxResponse struct {
+	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Log  string `protobuf:"bytes,2,opt,name=log,proto3" json:"log,omitempty"`
+}
+
+func (m *CheckTxResponse) Reset()                    { *m = CheckTxResponse{} }
+func (*CheckTxResponse) ProtoMessage()               {}
+func (*CheckTxResponse) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{5} }
+
+func (m *CheckTxResponse) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *CheckTxResponse) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
+}
+
+// BeginBlockRequest
+type BeginBlockRequest struct {
+	Header *BlockHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+}
+
+func (m *BeginBlockRequest) Reset()                    { *m = BeginBlockRequest{} }
+func (*BeginBlockRequest) ProtoMessage()               {}
+func (*BeginBlockRequest) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{6} }
+
+func (m *BeginBlockRequest) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+// BeginBlockResponse
+type BeginBlockResponse struct {
+}
+
+func (m *BeginBlockResponse) Reset()                    { *m = BeginBlockResponse{} }
+func (*BeginBlockResponse) ProtoMessage()               {}
+func (*BeginBlockResponse) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{7} }
+
+// DeliverTxRequest
+type DeliverTxRequest struct {
+	Tx *Transaction `protobuf:"bytes,1,opt,name=tx" json:"tx,omitempty"`
+}
+
+func (m *DeliverTxRequest) Reset()                    { *m = DeliverTxRequest{} }
+func (*DeliverTxRequest) ProtoMessage()               {}
+func (*DeliverTxRequest) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{8} }
+
+func (m *DeliverTxRequest) GetTx() *Transaction {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+// DeliverTxResponse, code 0 means tx is executed
+type DeliverTxResponse struct {
+	Code   uint32   `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Log    string   `protobuf:"bytes,2,opt,name=log,proto3" json:"log,omitempty"`
+	Events []*Event `protobuf:"bytes,3,rep,name=events" json:"events,omitempty"`
+}
+
+func (m *DeliverTxResponse) Reset()                    { *m = DeliverTxResponse{} }
+func (*DeliverTxResponse) ProtoMessage()               {}
+func (*DeliverTxResponse) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{9} }
+
+func (m *DeliverTxResponse) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *DeliverTxResponse) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
+}
+
+func (m *DeliverTxResponse) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// EndBlockRequest
+type EndBlockRequest struct {
+	Header *BlockHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+}
+
+func (m *EndBlockRequest) Reset()                    { *m = EndBlockRequest{} }
+func (*EndBlockRequest) ProtoMessage()               {}
+func (*EndBlockRequest) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{10} }
+
+func (m *EndBlockRequest) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+// EndBlockResponse
+type EndBlockResponse struct {
+}
+
+func (m *EndBlockResponse) Reset()                    { *m = EndBlockResponse{} }
+func (*EndBlockResponse) ProtoMessage()               {}
+func (*EndBlockResponse) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{11} }
+
+// CommitRequest
+type CommitRequest struct {
+}
+
+func (m *CommitRequest) Reset()                    { *m = CommitRequest{} }
+func (*CommitRequest) ProtoMessage()               {}
+func (*CommitRequest) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{12} }
+
+// CommitResponse
+type CommitResponse struct {
+	AppHash []byte `protobuf:"bytes,1,opt,name=appHash,proto3" json:"appHash,omitempty"`
+}
+
+func (m *CommitResponse) Reset()                    { *m = CommitResponse{} }
+func (*CommitResponse) ProtoMessage()               {}
+func (*CommitResponse) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{13} }
+
+func (m *CommitResponse) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
+// QueryRequest
+type QueryRequest struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *QueryRequest) Reset()                    { *m = QueryRequest{} }
+func (*QueryRequest) ProtoMessage()               {}
+func (*QueryRequest) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{14} }
+
+func (m *QueryRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// QueryResponse, code 0 means value is valid
+type QueryResponse struct {
+	Code  uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Log   string `protobuf:"bytes,2,opt,name=log,proto3" json:"log,omitempty"`
+	Value []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
+func (*QueryResponse) ProtoMessage()               {}
+func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{15} }
+
+func (m *QueryResponse) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *QueryResponse) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
+}
+
+func (m *QueryResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+// AppError
+type AppError struct {
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *AppError) Reset()                    { *m = AppError{} }
+func (*AppError) ProtoMessage()               {}
+func (*AppError) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{16} }
+
+func (m *AppError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Heartbeat
+type Heartbeat struct {
+}
+
+func (m *Heartbeat) Reset()                    { *m = Heartbeat{} }
+func (*Heartbeat) ProtoMessage()               {}
+func (*Heartbeat) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{17} }
+
+// TakeSnapshotRequest asks application to snapshot the state committed at height, sent right after its commit
+type TakeSnapshotRequest struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *TakeSnapshotRequest) Reset()                    { *m = TakeSnapshotRequest{} }
+func (*TakeSnapshotRequest) ProtoMessage()               {}
+func (*TakeSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{18} }
+
+func (m *TakeSnapshotRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// TakeSnapshotResponse
+type TakeSnapshotResponse struct {
+	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot" json:"snapshot,omitempty"`
+}
+
+func (m *TakeSnapshotResponse) Reset()      { *m = TakeSnapshotResponse{} }
+func (*TakeSnapshotResponse) ProtoMessage() {}
+func (*TakeSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApplication, []int{19}
+}
+
+func (m *TakeSnapshotResponse) GetSnapshot() *Snapshot {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+// LoadSnapshotChunkRequest loads a chunk of the snapshot taken at height
+type LoadSnapshotChunkRequest struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Index  uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (m *LoadSnapshotChunkRequest) Reset()      { *m = LoadSnapshotChunkRequest{} }
+func (*LoadSnapshotChunkRequest) ProtoMessage() {}
+func (*LoadSnapshotChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorApplication, []int{20}
+}
+
+func (m *LoadSnapshotChunkRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *LoadSnapshotChunkRequest) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+// LoadSnapshotChunkResponse
+type LoadSnapshotChunkResponse struct {
+	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (m *LoadSnapshotChunkResponse) Reset()      { *m = LoadSnapshotChunkResponse{} }
+func (*LoadSnapshotChunkResponse) ProtoMessage() {}
+func (*LoadSnapshotChunkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApplication, []int{21}
+}
+
+func (m *LoadSnapshotChunkResponse) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+// ApplySnapshotChunkRequest restores application state from a chunk of snapshot, chunks are applied in index order
+type ApplySnapshotChunkRequest struct {
+	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot" json:"snapshot,omitempty"`
+	Index    uint32    `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Chunk    []byte    `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (m *ApplySnapshotChunkRequest) Reset()      { *m = ApplySnapshotChunkRequest{} }
+func (*ApplySnapshotChunkRequest) ProtoMessage() {}
+func (*ApplySnapshotChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorApplication, []int{22}
+}
+
+func (m *ApplySnapshotChunkRequest) GetSnapshot() *Snapshot {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+func (m *ApplySnapshotChunkRequest) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ApplySnapshotChunkRequest) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+// ApplySnapshotChunkResponse
+type ApplySnapshotChunkResponse struct {
+	AppHash []byte `protobuf:"bytes,1,opt,name=appHash,proto3" json:"appHash,omitempty"`
+}
+
+func (m *ApplySnapshotChunkResponse) Reset()      { *m = ApplySnapshotChunkResponse{} }
+func (*ApplySnapshotChunkResponse) ProtoMessage() {}
+func (*ApplySnapshotChunkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApplication, []int{23}
+}
+
+func (m *ApplySnapshotChunkResponse) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
 // AppMetadata
 type AppMetadata struct {
 	Name    string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -137,7 +644,7 @@ type AppMetadata struct {
 
 func (m *AppMetadata) Reset()                    { *m = AppMetadata{} }
 func (*AppMetadata) ProtoMessage()               {}
-func (*AppMetadata) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{2} }
+func (*AppMetadata) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{24} }
 
 func (m *AppMetadata) GetName() string {
 	if m != nil {
@@ -153,62 +660,50 @@ func (m *AppMetadata) GetVersion() *AppVersion {
 	return nil
 }
 
-// Version
-type Version struct {
-	Major string `protobuf:"bytes,1,opt,name=major,proto3" json:"major,omitempty"`
-	Minor string `protobuf:"bytes,2,opt,name=minor,proto3" json:"minor,omitempty"`
-	Build string `protobuf:"bytes,3,opt,name=build,proto3" json:"build,omitempty"`
+// AppVersionRange records the application version processed blocks from startHeight to endHeight
+type AppVersionRange struct {
+	Version     *AppVersion `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	StartHeight uint64      `protobuf:"varint,2,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	EndHeight   uint64      `protobuf:"varint,3,opt,name=endHeight,proto3" json:"endHeight,omitempty"`
 }
 
-func (m *Version) Reset()                    { *m = Version{} }
-func (*Version) ProtoMessage()               {}
-func (*Version) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{3} }
+func (m *AppVersionRange) Reset()                    { *m = AppVersionRange{} }
+func (*AppVersionRange) ProtoMessage()               {}
+func (*AppVersionRange) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{25} }
 
-func (m *Version) GetMajor() string {
+func (m *AppVersionRange) GetVersion() *AppVersion {
 	if m != nil {
-		return m.Major
+		return m.Version
 	}
-	return ""
+	return nil
 }
 
-func (m *Version) GetMinor() string {
+func (m *AppVersionRange) GetStartHeight() uint64 {
 	if m != nil {
-		return m.Minor
+		return m.StartHeight
 	}
-	return ""
+	return 0
 }
 
-func (m *Version) GetBuild() string {
+func (m *AppVersionRange) GetEndHeight() uint64 {
 	if m != nil {
-		return m.Build
+		return m.EndHeight
 	}
-	return ""
+	return 0
 }
 
-// Application version
-// Features:
-// 1. upgrade version value must larger than current version
-// 2. newest application can compatible a specific version of application,
-//    older version than Backwards shouldn't be used in the blockchain network.
-type AppVersion struct {
-	Version   *Version `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
-	Backwards *Version `protobuf:"bytes,2,opt,name=backwards" json:"backwards,omitempty"`
+// AppVersionHistory
+type AppVersionHistory struct {
+	Ranges []*AppVersionRange `protobuf:"bytes,1,rep,name=ranges" json:"ranges,omitempty"`
 }
 
-func (m *AppVersion) Reset()                    { *m = AppVersion{} }
-func (*AppVersion) ProtoMessage()               {}
-func (*AppVersion) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{4} }
-
-func (m *AppVersion) GetVersion() *Version {
-	if m != nil {
-		return m.Version
-	}
-	return nil
-}
+func (m *AppVersionHistory) Reset()                    { *m = AppVersionHistory{} }
+func (*AppVersionHistory) ProtoMessage()               {}
+func (*AppVersionHistory) Descriptor() ([]byte, []int) { return fileDescriptorApplication, []int{26} }
 
-func (m *AppVersion) GetBackwards() *Version {
+func (m *AppVersionHistory) GetRanges() []*AppVersionRange {
 	if m != nil {
-		return m.Backwards
+		return m.Ranges
 	}
 	return nil
 }
@@ -216,9 +711,31 @@ func (m *AppVersion) GetBackwards() *Version {
 func init() {
 	proto.RegisterType((*AppMessage)(nil), "types.AppMessage")
 	proto.RegisterType((*AppMessageHeader)(nil), "types.AppMessageHeader")
+	proto.RegisterType((*RegisterRequest)(nil), "types.RegisterRequest")
+	proto.RegisterType((*RegisterAck)(nil), "types.RegisterAck")
+	proto.RegisterType((*CheckTxRequest)(nil), "types.CheckTxRequest")
+	proto.RegisterType((*CheckTxResponse)(nil), "types.CheckTxResponse")
+	proto.RegisterType((*BeginBlockRequest)(nil), "types.BeginBlockRequest")
+	proto.RegisterType((*BeginBlockResponse)(nil), "types.BeginBlockResponse")
+	proto.RegisterType((*DeliverTxRequest)(nil), "types.DeliverTxRequest")
+	proto.RegisterType((*DeliverTxResponse)(nil), "types.DeliverTxResponse")
+	proto.RegisterType((*EndBlockRequest)(nil), "types.EndBlockRequest")
+	proto.RegisterType((*EndBlockResponse)(nil), "types.EndBlockResponse")
+	proto.RegisterType((*CommitRequest)(nil), "types.CommitRequest")
+	proto.RegisterType((*CommitResponse)(nil), "types.CommitResponse")
+	proto.RegisterType((*QueryRequest)(nil), "types.QueryRequest")
+	proto.RegisterType((*QueryResponse)(nil), "types.QueryResponse")
+	proto.RegisterType((*AppError)(nil), "types.AppError")
+	proto.RegisterType((*Heartbeat)(nil), "types.Heartbeat")
+	proto.RegisterType((*TakeSnapshotRequest)(nil), "types.TakeSnapshotRequest")
+	proto.RegisterType((*TakeSnapshotResponse)(nil), "types.TakeSnapshotResponse")
+	proto.RegisterType((*LoadSnapshotChunkRequest)(nil), "types.LoadSnapshotChunkRequest")
+	proto.RegisterType((*LoadSnapshotChunkResponse)(nil), "types.LoadSnapshotChunkResponse")
+	proto.RegisterType((*ApplySnapshotChunkRequest)(nil), "types.ApplySnapshotChunkRequest")
+	proto.RegisterType((*ApplySnapshotChunkResponse)(nil), "types.ApplySnapshotChunkResponse")
 	proto.RegisterType((*AppMetadata)(nil), "types.AppMetadata")
-	proto.RegisterType((*Version)(nil), "types.Version")
-	proto.RegisterType((*AppVersion)(nil), "types.AppVersion")
+	proto.RegisterType((*AppVersionRange)(nil), "types.AppVersionRange")
+	proto.RegisterType((*AppVersionHistory)(nil), "types.AppVersionHistory")
 	proto.RegisterEnum("types.AppMessageType", AppMessageType_name, AppMessageType_value)
 }
 func (x AppMessageType) String() string {
@@ -295,9 +812,15 @@ func (this *AppMessageHeader) Equal(that interface{}) bool {
 	if this.Type != that1.Type {
 		return false
 	}
+	if this.ProtocolVersion != that1.ProtocolVersion {
+		return false
+	}
+	if this.RequestId != that1.RequestId {
+		return false
+	}
 	return true
 }
-func (this *AppMetadata) Equal(that interface{}) bool {
+func (this *RegisterRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -305,9 +828,9 @@ func (this *AppMetadata) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*AppMetadata)
+	that1, ok := that.(*RegisterRequest)
 	if !ok {
-		that2, ok := that.(AppMetadata)
+		that2, ok := that.(RegisterRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -322,15 +845,15 @@ func (this *AppMetadata) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Name != that1.Name {
+	if !this.Meta.Equal(that1.Meta) {
 		return false
 	}
-	if !this.Version.Equal(that1.Version) {
+	if this.LastHeight != that1.LastHeight {
 		return false
 	}
 	return true
 }
-func (this *Version) Equal(that interface{}) bool {
+func (this *RegisterAck) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -338,9 +861,9 @@ func (this *Version) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*Version)
+	that1, ok := that.(*RegisterAck)
 	if !ok {
-		that2, ok := that.(Version)
+		that2, ok := that.(RegisterAck)
 		if ok {
 			that1 = &that2
 		} else {
@@ -355,18 +878,15 @@ func (this *Version) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Major != that1.Major {
-		return false
-	}
-	if this.Minor != that1.Minor {
+	if this.ProtocolVersion != that1.ProtocolVersion {
 		return false
 	}
-	if this.Build != that1.Build {
+	if this.Height != that1.Height {
 		return false
 	}
 	return true
 }
-func (this *AppVersion) Equal(that interface{}) bool {
+func (this *CheckTxRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -374,9 +894,9 @@ func (this *AppVersion) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*AppVersion)
+	that1, ok := that.(*CheckTxRequest)
 	if !ok {
-		that2, ok := that.(AppVersion)
+		that2, ok := that.(CheckTxRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -391,594 +911,4310 @@ func (this *AppVersion) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Version.Equal(that1.Version) {
-		return false
-	}
-	if !this.Backwards.Equal(that1.Backwards) {
+	if !this.Tx.Equal(that1.Tx) {
 		return false
 	}
 	return true
 }
-func (this *AppMessage) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&types.AppMessage{")
-	if this.Header != nil {
-		s = append(s, "Header: "+fmt.Sprintf("%#v", this.Header)+",\n")
+func (this *CheckTxResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *AppMessageHeader) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*CheckTxResponse)
+	if !ok {
+		that2, ok := that.(CheckTxResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&types.AppMessageHeader{")
-	if this.Meta != nil {
-		s = append(s, "Meta: "+fmt.Sprintf("%#v", this.Meta)+",\n")
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *AppMetadata) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Code != that1.Code {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&types.AppMetadata{")
-	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
-	if this.Version != nil {
-		s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	if this.Log != that1.Log {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *Version) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *BeginBlockRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&types.Version{")
-	s = append(s, "Major: "+fmt.Sprintf("%#v", this.Major)+",\n")
-	s = append(s, "Minor: "+fmt.Sprintf("%#v", this.Minor)+",\n")
-	s = append(s, "Build: "+fmt.Sprintf("%#v", this.Build)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *AppVersion) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*BeginBlockRequest)
+	if !ok {
+		that2, ok := that.(BeginBlockRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&types.AppVersion{")
-	if this.Version != nil {
-		s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	if this.Backwards != nil {
-		s = append(s, "Backwards: "+fmt.Sprintf("%#v", this.Backwards)+",\n")
+	if !this.Header.Equal(that1.Header) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func valueToGoStringApplication(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+func (this *BeginBlockResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Application service
-
-type ApplicationClient interface {
-	// Register is used to register application to consensus module
-	Register(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
-	// AppStream used for sending and receiving messages between application & consensus module
-	AppStream(ctx context.Context, opts ...grpc.CallOption) (Application_AppStreamClient, error)
-}
-
-type applicationClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewApplicationClient(cc *grpc.ClientConn) ApplicationClient {
-	return &applicationClient{cc}
-}
 
-func (c *applicationClient) Register(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/types.Application/Register", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*BeginBlockResponse)
+	if !ok {
+		that2, ok := that.(BeginBlockResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *applicationClient) AppStream(ctx context.Context, opts ...grpc.CallOption) (Application_AppStreamClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Application_serviceDesc.Streams[0], c.cc, "/types.Application/AppStream", opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	x := &applicationAppStreamClient{stream}
-	return x, nil
-}
-
-type Application_AppStreamClient interface {
-	Send(*AppMessage) error
-	Recv() (*AppMessage, error)
-	grpc.ClientStream
-}
-
-type applicationAppStreamClient struct {
-	grpc.ClientStream
-}
-
-func (x *applicationAppStreamClient) Send(m *AppMessage) error {
-	return x.ClientStream.SendMsg(m)
+	return true
 }
-
-func (x *applicationAppStreamClient) Recv() (*AppMessage, error) {
-	m := new(AppMessage)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (this *DeliverTxRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	return m, nil
-}
-
-// Server API for Application service
-
-type ApplicationServer interface {
-	// Register is used to register application to consensus module
-	Register(context.Context, *Empty) (*Empty, error)
-	// AppStream used for sending and receiving messages between application & consensus module
-	AppStream(Application_AppStreamServer) error
-}
 
-func RegisterApplicationServer(s *grpc.Server, srv ApplicationServer) {
-	s.RegisterService(&_Application_serviceDesc, srv)
+	that1, ok := that.(*DeliverTxRequest)
+	if !ok {
+		that2, ok := that.(DeliverTxRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Tx.Equal(that1.Tx) {
+		return false
+	}
+	return true
 }
+func (this *DeliverTxResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func _Application_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*DeliverTxResponse)
+	if !ok {
+		that2, ok := that.(DeliverTxResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ApplicationServer).Register(ctx, in)
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.Application/Register",
+	if this.Code != that1.Code {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApplicationServer).Register(ctx, req.(*Empty))
+	if this.Log != that1.Log {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Application_AppStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ApplicationServer).AppStream(&applicationAppStreamServer{stream})
+	if len(this.Events) != len(that1.Events) {
+		return false
+	}
+	for i := range this.Events {
+		if !this.Events[i].Equal(that1.Events[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *EndBlockRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-type Application_AppStreamServer interface {
-	Send(*AppMessage) error
-	Recv() (*AppMessage, error)
-	grpc.ServerStream
-}
-
-type applicationAppStreamServer struct {
-	grpc.ServerStream
-}
-
-func (x *applicationAppStreamServer) Send(m *AppMessage) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func (x *applicationAppStreamServer) Recv() (*AppMessage, error) {
-	m := new(AppMessage)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
-
-var _Application_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.Application",
-	HandlerType: (*ApplicationServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Register",
-			Handler:    _Application_Register_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "AppStream",
-			Handler:       _Application_AppStream_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-	},
-	Metadata: "application.proto",
-}
-
-func (m *AppMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*EndBlockRequest)
+	if !ok {
+		that2, ok := that.(EndBlockRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *AppMessage) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(m.Header.Size()))
-		n1, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if that1 == nil {
+		if this == nil {
+			return true
 		}
-		i += n1
+		return false
+	} else if this == nil {
+		return false
 	}
-	if len(m.Payload) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(len(m.Payload)))
-		i += copy(dAtA[i:], m.Payload)
+	if !this.Header.Equal(that1.Header) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *AppMessageHeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *EndBlockResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	return dAtA[:n], nil
-}
 
-func (m *AppMessageHeader) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Meta != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(m.Meta.Size()))
-		n2, err := m.Meta.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*EndBlockResponse)
+	if !ok {
+		that2, ok := that.(EndBlockResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n2
-	}
-	if m.Timestamp != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(m.Timestamp))
 	}
-	if m.Type != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(m.Type))
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *AppMetadata) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *CommitRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	return dAtA[:n], nil
-}
 
-func (m *AppMetadata) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+	that1, ok := that.(*CommitRequest)
+	if !ok {
+		that2, ok := that.(CommitRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Version != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(m.Version.Size()))
-		n3, err := m.Version.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if that1 == nil {
+		if this == nil {
+			return true
 		}
-		i += n3
+		return false
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *Version) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *CommitResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	return dAtA[:n], nil
-}
 
-func (m *Version) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Major) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(len(m.Major)))
-		i += copy(dAtA[i:], m.Major)
+	that1, ok := that.(*CommitResponse)
+	if !ok {
+		that2, ok := that.(CommitResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Minor) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(len(m.Minor)))
-		i += copy(dAtA[i:], m.Minor)
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	if len(m.Build) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(len(m.Build)))
-		i += copy(dAtA[i:], m.Build)
+	if !bytes.Equal(this.AppHash, that1.AppHash) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *AppVersion) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *QueryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	return dAtA[:n], nil
-}
 
-func (m *AppVersion) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Version != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(m.Version.Size()))
-		n4, err := m.Version.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*QueryRequest)
+	if !ok {
+		that2, ok := that.(QueryRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n4
 	}
-	if m.Backwards != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintApplication(dAtA, i, uint64(m.Backwards.Size()))
-		n5, err := m.Backwards.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if that1 == nil {
+		if this == nil {
+			return true
 		}
-		i += n5
+		return false
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	return true
 }
+func (this *QueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func encodeFixed64Application(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Application(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintApplication(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	that1, ok := that.(*QueryResponse)
+	if !ok {
+		that2, ok := that.(QueryResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func (m *AppMessage) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovApplication(uint64(l))
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovApplication(uint64(l))
+	if this.Code != that1.Code {
+		return false
 	}
-	return n
+	if this.Log != that1.Log {
+		return false
+	}
+	if !bytes.Equal(this.Value, that1.Value) {
+		return false
+	}
+	return true
 }
+func (this *AppError) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *AppMessageHeader) Size() (n int) {
-	var l int
-	_ = l
-	if m.Meta != nil {
-		l = m.Meta.Size()
-		n += 1 + l + sovApplication(uint64(l))
+	that1, ok := that.(*AppError)
+	if !ok {
+		that2, ok := that.(AppError)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Timestamp != 0 {
-		n += 1 + sovApplication(uint64(m.Timestamp))
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	if m.Type != 0 {
-		n += 1 + sovApplication(uint64(m.Type))
+	if this.Message != that1.Message {
+		return false
 	}
-	return n
+	return true
 }
+func (this *Heartbeat) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *AppMetadata) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovApplication(uint64(l))
+	that1, ok := that.(*Heartbeat)
+	if !ok {
+		that2, ok := that.(Heartbeat)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Version != nil {
-		l = m.Version.Size()
-		n += 1 + l + sovApplication(uint64(l))
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	return n
+	return true
 }
+func (this *TakeSnapshotRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *Version) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Major)
-	if l > 0 {
-		n += 1 + l + sovApplication(uint64(l))
+	that1, ok := that.(*TakeSnapshotRequest)
+	if !ok {
+		that2, ok := that.(TakeSnapshotRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	l = len(m.Minor)
-	if l > 0 {
-		n += 1 + l + sovApplication(uint64(l))
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	l = len(m.Build)
-	if l > 0 {
-		n += 1 + l + sovApplication(uint64(l))
+	if this.Height != that1.Height {
+		return false
 	}
-	return n
+	return true
 }
+func (this *TakeSnapshotResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *AppVersion) Size() (n int) {
-	var l int
-	_ = l
-	if m.Version != nil {
-		l = m.Version.Size()
-		n += 1 + l + sovApplication(uint64(l))
+	that1, ok := that.(*TakeSnapshotResponse)
+	if !ok {
+		that2, ok := that.(TakeSnapshotResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Backwards != nil {
-		l = m.Backwards.Size()
-		n += 1 + l + sovApplication(uint64(l))
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	return n
+	if !this.Snapshot.Equal(that1.Snapshot) {
+		return false
+	}
+	return true
 }
+func (this *LoadSnapshotChunkRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func sovApplication(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+	that1, ok := that.(*LoadSnapshotChunkRequest)
+	if !ok {
+		that2, ok := that.(LoadSnapshotChunkRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	return n
-}
-func sozApplication(x uint64) (n int) {
-	return sovApplication(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *AppMessage) String() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	s := strings.Join([]string{`&AppMessage{`,
-		`Header:` + strings.Replace(fmt.Sprintf("%v", this.Header), "AppMessageHeader", "AppMessageHeader", 1) + `,`,
-		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *AppMessageHeader) String() string {
-	if this == nil {
-		return "nil"
+	if this.Height != that1.Height {
+		return false
 	}
-	s := strings.Join([]string{`&AppMessageHeader{`,
-		`Meta:` + strings.Replace(fmt.Sprintf("%v", this.Meta), "AppMetadata", "AppMetadata", 1) + `,`,
-		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *AppMetadata) String() string {
-	if this == nil {
-		return "nil"
+	if this.Index != that1.Index {
+		return false
 	}
-	s := strings.Join([]string{`&AppMetadata{`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`Version:` + strings.Replace(fmt.Sprintf("%v", this.Version), "AppVersion", "AppVersion", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return true
 }
-func (this *Version) String() string {
-	if this == nil {
-		return "nil"
+func (this *LoadSnapshotChunkResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s := strings.Join([]string{`&Version{`,
-		`Major:` + fmt.Sprintf("%v", this.Major) + `,`,
-		`Minor:` + fmt.Sprintf("%v", this.Minor) + `,`,
-		`Build:` + fmt.Sprintf("%v", this.Build) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *AppVersion) String() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*LoadSnapshotChunkResponse)
+	if !ok {
+		that2, ok := that.(LoadSnapshotChunkResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := strings.Join([]string{`&AppVersion{`,
-		`Version:` + strings.Replace(fmt.Sprintf("%v", this.Version), "Version", "Version", 1) + `,`,
-		`Backwards:` + strings.Replace(fmt.Sprintf("%v", this.Backwards), "Version", "Version", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Chunk, that1.Chunk) {
+		return false
+	}
+	return true
 }
-func valueToStringApplication(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
+func (this *ApplySnapshotChunkRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*ApplySnapshotChunkRequest)
+	if !ok {
+		that2, ok := that.(ApplySnapshotChunkRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Snapshot.Equal(that1.Snapshot) {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if !bytes.Equal(this.Chunk, that1.Chunk) {
+		return false
+	}
+	return true
+}
+func (this *ApplySnapshotChunkResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*ApplySnapshotChunkResponse)
+	if !ok {
+		that2, ok := that.(ApplySnapshotChunkResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.AppHash, that1.AppHash) {
+		return false
+	}
+	return true
+}
+func (this *AppMetadata) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*AppMetadata)
+	if !ok {
+		that2, ok := that.(AppMetadata)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	if !this.Version.Equal(that1.Version) {
+		return false
+	}
+	return true
+}
+func (this *AppVersionRange) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*AppVersionRange)
+	if !ok {
+		that2, ok := that.(AppVersionRange)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Version.Equal(that1.Version) {
+		return false
+	}
+	if this.StartHeight != that1.StartHeight {
+		return false
+	}
+	if this.EndHeight != that1.EndHeight {
+		return false
+	}
+	return true
+}
+func (this *AppVersionHistory) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*AppVersionHistory)
+	if !ok {
+		that2, ok := that.(AppVersionHistory)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if len(this.Ranges) != len(that1.Ranges) {
+		return false
+	}
+	for i := range this.Ranges {
+		if !this.Ranges[i].Equal(that1.Ranges[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *AppMessage) GoString() string {
+	if this == nil {
 		return "nil"
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	s := make([]string, 0, 6)
+	s = append(s, "&types.AppMessage{")
+	if this.Header != nil {
+		s = append(s, "Header: "+fmt.Sprintf("%#v", this.Header)+",\n")
+	}
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AppMessageHeader) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&types.AppMessageHeader{")
+	if this.Meta != nil {
+		s = append(s, "Meta: "+fmt.Sprintf("%#v", this.Meta)+",\n")
+	}
+	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "ProtocolVersion: "+fmt.Sprintf("%#v", this.ProtocolVersion)+",\n")
+	s = append(s, "RequestId: "+fmt.Sprintf("%#v", this.RequestId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RegisterRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&types.RegisterRequest{")
+	if this.Meta != nil {
+		s = append(s, "Meta: "+fmt.Sprintf("%#v", this.Meta)+",\n")
+	}
+	s = append(s, "LastHeight: "+fmt.Sprintf("%#v", this.LastHeight)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RegisterAck) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&types.RegisterAck{")
+	s = append(s, "ProtocolVersion: "+fmt.Sprintf("%#v", this.ProtocolVersion)+",\n")
+	s = append(s, "Height: "+fmt.Sprintf("%#v", this.Height)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CheckTxRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.CheckTxRequest{")
+	if this.Tx != nil {
+		s = append(s, "Tx: "+fmt.Sprintf("%#v", this.Tx)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CheckTxResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&types.CheckTxResponse{")
+	s = append(s, "Code: "+fmt.Sprintf("%#v", this.Code)+",\n")
+	s = append(s, "Log: "+fmt.Sprintf("%#v", this.Log)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *BeginBlockRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.BeginBlockRequest{")
+	if this.Header != nil {
+		s = append(s, "Header: "+fmt.Sprintf("%#v", this.Header)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *BeginBlockResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&types.BeginBlockResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeliverTxRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.DeliverTxRequest{")
+	if this.Tx != nil {
+		s = append(s, "Tx: "+fmt.Sprintf("%#v", this.Tx)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeliverTxResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&types.DeliverTxResponse{")
+	s = append(s, "Code: "+fmt.Sprintf("%#v", this.Code)+",\n")
+	s = append(s, "Log: "+fmt.Sprintf("%#v", this.Log)+",\n")
+	if this.Events != nil {
+		s = append(s, "Events: "+fmt.Sprintf("%#v", this.Events)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *EndBlockRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.EndBlockRequest{")
+	if this.Header != nil {
+		s = append(s, "Header: "+fmt.Sprintf("%#v", this.Header)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *EndBlockResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&types.EndBlockResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CommitRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&types.CommitRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CommitResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.CommitResponse{")
+	s = append(s, "AppHash: "+fmt.Sprintf("%#v", this.AppHash)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *QueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.QueryRequest{")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *QueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&types.QueryResponse{")
+	s = append(s, "Code: "+fmt.Sprintf("%#v", this.Code)+",\n")
+	s = append(s, "Log: "+fmt.Sprintf("%#v", this.Log)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AppError) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.AppError{")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Heartbeat) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&types.Heartbeat{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TakeSnapshotRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.TakeSnapshotRequest{")
+	s = append(s, "Height: "+fmt.Sprintf("%#v", this.Height)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TakeSnapshotResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.TakeSnapshotResponse{")
+	if this.Snapshot != nil {
+		s = append(s, "Snapshot: "+fmt.Sprintf("%#v", this.Snapshot)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LoadSnapshotChunkRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&types.LoadSnapshotChunkRequest{")
+	s = append(s, "Height: "+fmt.Sprintf("%#v", this.Height)+",\n")
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LoadSnapshotChunkResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.LoadSnapshotChunkResponse{")
+	s = append(s, "Chunk: "+fmt.Sprintf("%#v", this.Chunk)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ApplySnapshotChunkRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&types.ApplySnapshotChunkRequest{")
+	if this.Snapshot != nil {
+		s = append(s, "Snapshot: "+fmt.Sprintf("%#v", this.Snapshot)+",\n")
+	}
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "Chunk: "+fmt.Sprintf("%#v", this.Chunk)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ApplySnapshotChunkResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.ApplySnapshotChunkResponse{")
+	s = append(s, "AppHash: "+fmt.Sprintf("%#v", this.AppHash)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AppMetadata) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&types.AppMetadata{")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	if this.Version != nil {
+		s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AppVersionRange) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&types.AppVersionRange{")
+	if this.Version != nil {
+		s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	}
+	s = append(s, "StartHeight: "+fmt.Sprintf("%#v", this.StartHeight)+",\n")
+	s = append(s, "EndHeight: "+fmt.Sprintf("%#v", this.EndHeight)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AppVersionHistory) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.AppVersionHistory{")
+	if this.Ranges != nil {
+		s = append(s, "Ranges: "+fmt.Sprintf("%#v", this.Ranges)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringApplication(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Application service
+
+type ApplicationClient interface {
+	// Register is used to register application to consensus module,
+	// application version incompatible with the recorded one is rejected
+	Register(ctx context.Context, in *AppMetadata, opts ...grpc.CallOption) (*Empty, error)
+	// AppStream used for sending and receiving messages between application & consensus module
+	AppStream(ctx context.Context, opts ...grpc.CallOption) (Application_AppStreamClient, error)
+}
+
+type applicationClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewApplicationClient(cc *grpc.ClientConn) ApplicationClient {
+	return &applicationClient{cc}
+}
+
+func (c *applicationClient) Register(ctx context.Context, in *AppMetadata, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/types.Application/Register", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationClient) AppStream(ctx context.Context, opts ...grpc.CallOption) (Application_AppStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Application_serviceDesc.Streams[0], c.cc, "/types.Application/AppStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &applicationAppStreamClient{stream}
+	return x, nil
+}
+
+type Application_AppStreamClient interface {
+	Send(*AppMessage) error
+	Recv() (*AppMessage, error)
+	grpc.ClientStream
+}
+
+type applicationAppStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *applicationAppStreamClient) Send(m *AppMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *applicationAppStreamClient) Recv() (*AppMessage, error) {
+	m := new(AppMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Application service
+
+type ApplicationServer interface {
+	// Register is used to register application to consensus module,
+	// application version incompatible with the recorded one is rejected
+	Register(context.Context, *AppMetadata) (*Empty, error)
+	// AppStream used for sending and receiving messages between application & consensus module
+	AppStream(Application_AppStreamServer) error
+}
+
+func RegisterApplicationServer(s *grpc.Server, srv ApplicationServer) {
+	s.RegisterService(&_Application_serviceDesc, srv)
+}
+
+func _Application_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Application/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServer).Register(ctx, req.(*AppMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Application_AppStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ApplicationServer).AppStream(&applicationAppStreamServer{stream})
+}
+
+type Application_AppStreamServer interface {
+	Send(*AppMessage) error
+	Recv() (*AppMessage, error)
+	grpc.ServerStream
+}
+
+type applicationAppStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *applicationAppStreamServer) Send(m *AppMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *applicationAppStreamServer) Recv() (*AppMessage, error) {
+	m := new(AppMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Application_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.Application",
+	HandlerType: (*ApplicationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _Application_Register_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AppStream",
+			Handler:       _Application_AppStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "application.proto",
+}
+
+func (m *AppMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Header.Size()))
+		n1, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
+	}
+	return i, nil
+}
+
+func (m *AppMessageHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppMessageHeader) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Meta != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Meta.Size()))
+		n2, err := m.Meta.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Timestamp))
+	}
+	if m.Type != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Type))
+	}
+	if m.ProtocolVersion != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.ProtocolVersion))
+	}
+	if m.RequestId != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.RequestId))
+	}
+	return i, nil
+}
+
+func (m *RegisterRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisterRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Meta != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Meta.Size()))
+		n3, err := m.Meta.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.LastHeight != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.LastHeight))
+	}
+	return i, nil
+}
+
+func (m *RegisterAck) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisterAck) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ProtocolVersion != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.ProtocolVersion))
+	}
+	if m.Height != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Height))
+	}
+	return i, nil
+}
+
+func (m *CheckTxRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckTxRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Tx != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Tx.Size()))
+		n4, err := m.Tx.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	return i, nil
+}
+
+func (m *CheckTxResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckTxResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Log) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Log)))
+		i += copy(dAtA[i:], m.Log)
+	}
+	return i, nil
+}
+
+func (m *BeginBlockRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BeginBlockRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Header.Size()))
+		n5, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	return i, nil
+}
+
+func (m *BeginBlockResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BeginBlockResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *DeliverTxRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeliverTxRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Tx != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Tx.Size()))
+		n6, err := m.Tx.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	return i, nil
+}
+
+func (m *DeliverTxResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeliverTxResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Log) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Log)))
+		i += copy(dAtA[i:], m.Log)
+	}
+	if len(m.Events) > 0 {
+		for _, msg := range m.Events {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintApplication(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *EndBlockRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndBlockRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Header.Size()))
+		n7, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	return i, nil
+}
+
+func (m *EndBlockResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndBlockResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *CommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *CommitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.AppHash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.AppHash)))
+		i += copy(dAtA[i:], m.AppHash)
+	}
+	return i, nil
+}
+
+func (m *QueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	return i, nil
+}
+
+func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Log) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Log)))
+		i += copy(dAtA[i:], m.Log)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
+func (m *AppError) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppError) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Message) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	return i, nil
+}
+
+func (m *Heartbeat) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Heartbeat) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *TakeSnapshotRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TakeSnapshotRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Height))
+	}
+	return i, nil
+}
+
+func (m *TakeSnapshotResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TakeSnapshotResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Snapshot.Size()))
+		n8, err := m.Snapshot.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	return i, nil
+}
+
+func (m *LoadSnapshotChunkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LoadSnapshotChunkRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Height))
+	}
+	if m.Index != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Index))
+	}
+	return i, nil
+}
+
+func (m *LoadSnapshotChunkResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LoadSnapshotChunkResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chunk) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Chunk)))
+		i += copy(dAtA[i:], m.Chunk)
+	}
+	return i, nil
+}
+
+func (m *ApplySnapshotChunkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplySnapshotChunkRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Snapshot.Size()))
+		n9, err := m.Snapshot.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.Index != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Index))
+	}
+	if len(m.Chunk) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Chunk)))
+		i += copy(dAtA[i:], m.Chunk)
+	}
+	return i, nil
+}
+
+func (m *ApplySnapshotChunkResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplySnapshotChunkResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.AppHash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.AppHash)))
+		i += copy(dAtA[i:], m.AppHash)
+	}
+	return i, nil
+}
+
+func (m *AppMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppMetadata) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Version != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Version.Size()))
+		n10, err := m.Version.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	return i, nil
+}
+
+func (m *AppVersionRange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppVersionRange) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Version != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.Version.Size()))
+		n11, err := m.Version.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.StartHeight != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.StartHeight))
+	}
+	if m.EndHeight != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintApplication(dAtA, i, uint64(m.EndHeight))
+	}
+	return i, nil
+}
+
+func (m *AppVersionHistory) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppVersionHistory) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ranges) > 0 {
+		for _, msg := range m.Ranges {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintApplication(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func encodeVarintApplication(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *AppMessage) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *AppMessageHeader) Size() (n int) {
+	var l int
+	_ = l
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovApplication(uint64(m.Timestamp))
+	}
+	if m.Type != 0 {
+		n += 1 + sovApplication(uint64(m.Type))
+	}
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovApplication(uint64(m.ProtocolVersion))
+	}
+	if m.RequestId != 0 {
+		n += 1 + sovApplication(uint64(m.RequestId))
+	}
+	return n
+}
+
+func (m *RegisterRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.LastHeight != 0 {
+		n += 1 + sovApplication(uint64(m.LastHeight))
+	}
+	return n
+}
+
+func (m *RegisterAck) Size() (n int) {
+	var l int
+	_ = l
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovApplication(uint64(m.ProtocolVersion))
+	}
+	if m.Height != 0 {
+		n += 1 + sovApplication(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *CheckTxRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Tx != nil {
+		l = m.Tx.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *CheckTxResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovApplication(uint64(m.Code))
+	}
+	l = len(m.Log)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *BeginBlockRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *BeginBlockResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *DeliverTxRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Tx != nil {
+		l = m.Tx.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *DeliverTxResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovApplication(uint64(m.Code))
+	}
+	l = len(m.Log)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovApplication(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *EndBlockRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *EndBlockResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *CommitRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *CommitResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovApplication(uint64(m.Code))
+	}
+	l = len(m.Log)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *AppError) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *Heartbeat) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *TakeSnapshotRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovApplication(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *TakeSnapshotResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		l = m.Snapshot.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *LoadSnapshotChunkRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovApplication(uint64(m.Height))
+	}
+	if m.Index != 0 {
+		n += 1 + sovApplication(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *LoadSnapshotChunkResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Chunk)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *ApplySnapshotChunkRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		l = m.Snapshot.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovApplication(uint64(m.Index))
+	}
+	l = len(m.Chunk)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *ApplySnapshotChunkResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *AppMetadata) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.Version != nil {
+		l = m.Version.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	return n
+}
+
+func (m *AppVersionRange) Size() (n int) {
+	var l int
+	_ = l
+	if m.Version != nil {
+		l = m.Version.Size()
+		n += 1 + l + sovApplication(uint64(l))
+	}
+	if m.StartHeight != 0 {
+		n += 1 + sovApplication(uint64(m.StartHeight))
+	}
+	if m.EndHeight != 0 {
+		n += 1 + sovApplication(uint64(m.EndHeight))
+	}
+	return n
+}
+
+func (m *AppVersionHistory) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Ranges) > 0 {
+		for _, e := range m.Ranges {
+			l = e.Size()
+			n += 1 + l + sovApplication(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovApplication(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozApplication(x uint64) (n int) {
+	return sovApplication(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *AppMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AppMessage{`,
+		`Header:` + strings.Replace(fmt.Sprintf("%v", this.Header), "AppMessageHeader", "AppMessageHeader", 1) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppMessageHeader) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AppMessageHeader{`,
+		`Meta:` + strings.Replace(fmt.Sprintf("%v", this.Meta), "AppMetadata", "AppMetadata", 1) + `,`,
+		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`ProtocolVersion:` + fmt.Sprintf("%v", this.ProtocolVersion) + `,`,
+		`RequestId:` + fmt.Sprintf("%v", this.RequestId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RegisterRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RegisterRequest{`,
+		`Meta:` + strings.Replace(fmt.Sprintf("%v", this.Meta), "AppMetadata", "AppMetadata", 1) + `,`,
+		`LastHeight:` + fmt.Sprintf("%v", this.LastHeight) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RegisterAck) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RegisterAck{`,
+		`ProtocolVersion:` + fmt.Sprintf("%v", this.ProtocolVersion) + `,`,
+		`Height:` + fmt.Sprintf("%v", this.Height) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CheckTxRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CheckTxRequest{`,
+		`Tx:` + strings.Replace(fmt.Sprintf("%v", this.Tx), "Transaction", "Transaction", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CheckTxResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CheckTxResponse{`,
+		`Code:` + fmt.Sprintf("%v", this.Code) + `,`,
+		`Log:` + fmt.Sprintf("%v", this.Log) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BeginBlockRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BeginBlockRequest{`,
+		`Header:` + strings.Replace(fmt.Sprintf("%v", this.Header), "BlockHeader", "BlockHeader", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BeginBlockResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BeginBlockResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeliverTxRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeliverTxRequest{`,
+		`Tx:` + strings.Replace(fmt.Sprintf("%v", this.Tx), "Transaction", "Transaction", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeliverTxResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeliverTxResponse{`,
+		`Code:` + fmt.Sprintf("%v", this.Code) + `,`,
+		`Log:` + fmt.Sprintf("%v", this.Log) + `,`,
+		`Events:` + strings.Replace(fmt.Sprintf("%v", this.Events), "Event", "Event", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *EndBlockRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&EndBlockRequest{`,
+		`Header:` + strings.Replace(fmt.Sprintf("%v", this.Header), "BlockHeader", "BlockHeader", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *EndBlockResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&EndBlockResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CommitRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CommitRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CommitResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CommitResponse{`,
+		`AppHash:` + fmt.Sprintf("%v", this.AppHash) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&QueryRequest{`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&QueryResponse{`,
+		`Code:` + fmt.Sprintf("%v", this.Code) + `,`,
+		`Log:` + fmt.Sprintf("%v", this.Log) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppError) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AppError{`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Heartbeat) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Heartbeat{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TakeSnapshotRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TakeSnapshotRequest{`,
+		`Height:` + fmt.Sprintf("%v", this.Height) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TakeSnapshotResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TakeSnapshotResponse{`,
+		`Snapshot:` + strings.Replace(fmt.Sprintf("%v", this.Snapshot), "Snapshot", "Snapshot", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LoadSnapshotChunkRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LoadSnapshotChunkRequest{`,
+		`Height:` + fmt.Sprintf("%v", this.Height) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LoadSnapshotChunkResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LoadSnapshotChunkResponse{`,
+		`Chunk:` + fmt.Sprintf("%v", this.Chunk) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ApplySnapshotChunkRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ApplySnapshotChunkRequest{`,
+		`Snapshot:` + strings.Replace(fmt.Sprintf("%v", this.Snapshot), "Snapshot", "Snapshot", 1) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`Chunk:` + fmt.Sprintf("%v", this.Chunk) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ApplySnapshotChunkResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ApplySnapshotChunkResponse{`,
+		`AppHash:` + fmt.Sprintf("%v", this.AppHash) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppMetadata) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AppMetadata{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Version:` + strings.Replace(fmt.Sprintf("%v", this.Version), "AppVersion", "AppVersion", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppVersionRange) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AppVersionRange{`,
+		`Version:` + strings.Replace(fmt.Sprintf("%v", this.Version), "AppVersion", "AppVersion", 1) + `,`,
+		`StartHeight:` + fmt.Sprintf("%v", this.StartHeight) + `,`,
+		`EndHeight:` + fmt.Sprintf("%v", this.EndHeight) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppVersionHistory) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AppVersionHistory{`,
+		`Ranges:` + strings.Replace(fmt.Sprintf("%v", this.Ranges), "AppVersionRange", "AppVersionRange", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringApplication(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *AppMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &AppMessageHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AppMessageHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppMessageHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppMessageHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Meta == nil {
+				m.Meta = &AppMetadata{}
+			}
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (AppMessageType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			m.RequestId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegisterRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisterRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Meta == nil {
+				m.Meta = &AppMetadata{}
+			}
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastHeight", wireType)
+			}
+			m.LastHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastHeight |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegisterAck) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisterAck: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisterAck: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckTxRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckTxRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckTxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tx == nil {
+				m.Tx = &Transaction{}
+			}
+			if err := m.Tx.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckTxResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckTxResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckTxResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Log = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BeginBlockRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BeginBlockRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BeginBlockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &BlockHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BeginBlockResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BeginBlockResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BeginBlockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeliverTxRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeliverTxRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeliverTxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tx == nil {
+				m.Tx = &Transaction{}
+			}
+			if err := m.Tx.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeliverTxResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeliverTxResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeliverTxResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Log = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, &Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndBlockRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndBlockRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndBlockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &BlockHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndBlockResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndBlockResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndBlockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Log = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AppError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Heartbeat) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Heartbeat: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Heartbeat: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TakeSnapshotRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TakeSnapshotRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TakeSnapshotRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TakeSnapshotResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TakeSnapshotResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TakeSnapshotResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Snapshot == nil {
+				m.Snapshot = &Snapshot{}
+			}
+			if err := m.Snapshot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *AppMessage) Unmarshal(dAtA []byte) error {
+func (m *LoadSnapshotChunkRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1001,17 +5237,17 @@ func (m *AppMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AppMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: LoadSnapshotChunkRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AppMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LoadSnapshotChunkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			var msglen int
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApplication
@@ -1021,28 +5257,83 @@ func (m *AppMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Height |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthApplication
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &AppMessageHeader{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LoadSnapshotChunkResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 2:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LoadSnapshotChunkResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LoadSnapshotChunkResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1066,9 +5357,9 @@ func (m *AppMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
+			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chunk == nil {
+				m.Chunk = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -1092,7 +5383,7 @@ func (m *AppMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AppMessageHeader) Unmarshal(dAtA []byte) error {
+func (m *ApplySnapshotChunkRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1115,15 +5406,15 @@ func (m *AppMessageHeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AppMessageHeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: ApplySnapshotChunkRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AppMessageHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ApplySnapshotChunkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1147,18 +5438,18 @@ func (m *AppMessageHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Meta == nil {
-				m.Meta = &AppMetadata{}
+			if m.Snapshot == nil {
+				m.Snapshot = &Snapshot{}
 			}
-			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Snapshot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.Timestamp = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApplication
@@ -1168,16 +5459,16 @@ func (m *AppMessageHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= (int64(b) & 0x7F) << shift
+				m.Index |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
 			}
-			m.Type = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApplication
@@ -1187,11 +5478,104 @@ func (m *AppMessageHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= (AppMessageType(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chunk == nil {
+				m.Chunk = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApplication(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApplication
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApplySnapshotChunkResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApplication
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplySnapshotChunkResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplySnapshotChunkResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApplication
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApplication
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApplication(dAtA[iNdEx:])
@@ -1325,7 +5709,7 @@ func (m *AppMetadata) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Version) Unmarshal(dAtA []byte) error {
+func (m *AppVersionRange) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1348,17 +5732,17 @@ func (m *Version) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Version: wiretype end group for non-group")
+			return fmt.Errorf("proto: AppVersionRange: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Version: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AppVersionRange: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Major", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApplication
@@ -1368,26 +5752,30 @@ func (m *Version) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthApplication
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Major = string(dAtA[iNdEx:postIndex])
+			if m.Version == nil {
+				m.Version = &AppVersion{}
+			}
+			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Minor", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartHeight", wireType)
 			}
-			var stringLen uint64
+			m.StartHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApplication
@@ -1397,26 +5785,16 @@ func (m *Version) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.StartHeight |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthApplication
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Minor = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Build", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndHeight", wireType)
 			}
-			var stringLen uint64
+			m.EndHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApplication
@@ -1426,21 +5804,11 @@ func (m *Version) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.EndHeight |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthApplication
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Build = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApplication(dAtA[iNdEx:])
@@ -1462,7 +5830,7 @@ func (m *Version) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AppVersion) Unmarshal(dAtA []byte) error {
+func (m *AppVersionHistory) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1485,48 +5853,15 @@ func (m *AppVersion) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AppVersion: wiretype end group for non-group")
+			return fmt.Errorf("proto: AppVersionHistory: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AppVersion: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AppVersionHistory: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApplication
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthApplication
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Version == nil {
-				m.Version = &Version{}
-			}
-			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Backwards", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ranges", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1550,10 +5885,8 @@ func (m *AppVersion) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Backwards == nil {
-				m.Backwards = &Version{}
-			}
-			if err := m.Backwards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Ranges = append(m.Ranges, &AppVersionRange{})
+			if err := m.Ranges[len(m.Ranges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1686,34 +6019,71 @@ var (
 func init() { proto.RegisterFile("application.proto", fileDescriptorApplication) }
 
 var fileDescriptorApplication = []byte{
-	// 457 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0xcf, 0x8a, 0x13, 0x41,
-	0x10, 0xc6, 0xa7, 0xdd, 0xec, 0xc6, 0xa9, 0x84, 0xb0, 0x29, 0x14, 0x43, 0xd0, 0x26, 0x8c, 0x20,
-	0xe3, 0x1f, 0x12, 0x89, 0x07, 0xcf, 0x2b, 0x08, 0xc2, 0x62, 0xc4, 0xf1, 0xcf, 0x82, 0xb7, 0xca,
-	0x4c, 0x93, 0xb4, 0xa6, 0xa7, 0x9b, 0x99, 0x5e, 0x25, 0x9e, 0x04, 0x5f, 0xc0, 0xc7, 0xf0, 0x51,
-	0x3c, 0xee, 0xd1, 0xa3, 0x19, 0x2f, 0x1e, 0xf7, 0x11, 0x24, 0x9d, 0x19, 0x66, 0x93, 0xdc, 0xa6,
-	0xbe, 0xfa, 0xea, 0x57, 0x1f, 0x35, 0x0d, 0x5d, 0x32, 0x66, 0x21, 0x63, 0xb2, 0x52, 0xa7, 0x43,
-	0x93, 0x69, 0xab, 0xf1, 0xd0, 0x2e, 0x8d, 0xc8, 0xfb, 0xed, 0x58, 0x2b, 0x55, 0x89, 0xc1, 0x19,
-	0xc0, 0x89, 0x31, 0x2f, 0x45, 0x9e, 0xd3, 0x4c, 0xe0, 0x08, 0x8e, 0xe6, 0x82, 0x12, 0x91, 0xf5,
-	0xd8, 0x80, 0x85, 0xad, 0xf1, 0xad, 0xa1, 0x9b, 0x19, 0xd6, 0x96, 0x17, 0xae, 0x1d, 0x95, 0x36,
-	0xec, 0x41, 0xd3, 0xd0, 0x72, 0xa1, 0x29, 0xe9, 0x5d, 0x1b, 0xb0, 0xb0, 0x1d, 0x55, 0x65, 0xf0,
-	0x9d, 0xc1, 0xf1, 0xee, 0x18, 0xde, 0x83, 0x86, 0x12, 0x96, 0x4a, 0x3a, 0x5e, 0xa5, 0x5b, 0x4a,
-	0xc8, 0x52, 0xe4, 0xfa, 0x78, 0x1b, 0x7c, 0x2b, 0x95, 0xc8, 0x2d, 0x29, 0xe3, 0xc0, 0x07, 0x51,
-	0x2d, 0xe0, 0x7d, 0x68, 0xac, 0x07, 0x7b, 0x07, 0x03, 0x16, 0x76, 0xc6, 0x37, 0xf7, 0x32, 0xbe,
-	0x5d, 0x1a, 0x11, 0x39, 0x4b, 0x30, 0x81, 0xd6, 0x15, 0x3a, 0x22, 0x34, 0x52, 0x52, 0xc2, 0xed,
-	0xf7, 0x23, 0xf7, 0x8d, 0x0f, 0xa1, 0xf9, 0x59, 0x64, 0xb9, 0xd4, 0xa9, 0xdb, 0xd4, 0x1a, 0x77,
-	0x6b, 0xe0, 0xfb, 0x4d, 0x23, 0xaa, 0x1c, 0xc1, 0x29, 0x34, 0x4b, 0x0d, 0x6f, 0xc0, 0xa1, 0xa2,
-	0x8f, 0x3a, 0x2b, 0x61, 0x9b, 0xc2, 0xa9, 0x32, 0xd5, 0x99, 0x63, 0xad, 0xd5, 0x75, 0xb1, 0x56,
-	0xa7, 0xe7, 0x72, 0x91, 0xb8, 0xc8, 0x7e, 0xb4, 0x29, 0x82, 0xc4, 0xdd, 0xbe, 0xe2, 0x85, 0x75,
-	0x8e, 0xcd, 0x79, 0x3a, 0x65, 0x8e, 0xdd, 0x10, 0xf8, 0x08, 0xfc, 0x29, 0xc5, 0x9f, 0xbe, 0x50,
-	0x96, 0xe4, 0x65, 0xe6, 0x5d, 0x6f, 0x6d, 0x78, 0x70, 0x07, 0x3a, 0xdb, 0xa7, 0xc1, 0x16, 0x34,
-	0xdf, 0x4d, 0x4e, 0x27, 0xaf, 0xce, 0x26, 0xc7, 0xde, 0xd8, 0xb8, 0x0b, 0x55, 0x4f, 0x05, 0x43,
-	0xb8, 0x1e, 0x89, 0x99, 0xcc, 0xad, 0xc8, 0xb0, 0x5d, 0x42, 0x9f, 0x2b, 0x63, 0x97, 0xfd, 0xad,
-	0x2a, 0xf0, 0xf0, 0x29, 0xf8, 0x27, 0xc6, 0xbc, 0xb1, 0x99, 0x20, 0x85, 0xdd, 0xbd, 0x9f, 0xd0,
-	0xdf, 0x97, 0x02, 0x2f, 0x64, 0x8f, 0xd9, 0xb3, 0xd7, 0x17, 0x2b, 0xee, 0xfd, 0x5e, 0x71, 0xef,
-	0x72, 0xc5, 0xd9, 0xb7, 0x82, 0xb3, 0x9f, 0x05, 0x67, 0xbf, 0x0a, 0xce, 0x2e, 0x0a, 0xce, 0xfe,
-	0x14, 0x9c, 0xfd, 0x2b, 0xb8, 0x77, 0x59, 0x70, 0xf6, 0xe3, 0x2f, 0xf7, 0x3e, 0xdc, 0x9d, 0x49,
-	0x3b, 0x3f, 0x9f, 0x0e, 0x63, 0xad, 0x46, 0x4a, 0xa6, 0xf6, 0xeb, 0x9c, 0xf4, 0xc8, 0x6a, 0x43,
-	0xf1, 0x9c, 0x64, 0x3a, 0x72, 0x3b, 0xa6, 0x47, 0xee, 0x31, 0x3f, 0xf9, 0x1f, 0x00, 0x00, 0xff,
-	0xff, 0xb2, 0xaa, 0xde, 0x6e, 0xf6, 0x02, 0x00, 0x00,
+	// 1042 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcd, 0x6e, 0x23, 0x45,
+	0x10, 0x76, 0xc7, 0x3f, 0x89, 0xcb, 0x7f, 0xe3, 0xde, 0xb0, 0xeb, 0x8d, 0xd0, 0xc8, 0x6a, 0x56,
+	0xc8, 0x64, 0x85, 0x03, 0x01, 0xed, 0x9e, 0x10, 0xb2, 0x27, 0xa3, 0x75, 0xe4, 0xc4, 0x4e, 0x3a,
+	0xce, 0x2e, 0xe1, 0x62, 0x75, 0xc6, 0x2d, 0x7b, 0x14, 0xcf, 0x0f, 0x33, 0x9d, 0x28, 0xe6, 0x80,
+	0x78, 0x04, 0x1e, 0x83, 0x47, 0x41, 0xe2, 0xb2, 0x47, 0x8e, 0xc4, 0x5c, 0xb8, 0x20, 0xed, 0x23,
+	0xa0, 0x69, 0xf7, 0xc4, 0x76, 0x62, 0x29, 0x84, 0x5b, 0xd7, 0x57, 0xd5, 0xf5, 0x7d, 0xd5, 0x53,
+	0x5d, 0x3d, 0x50, 0x66, 0xbe, 0x3f, 0xb6, 0x2d, 0x26, 0x6c, 0xcf, 0xad, 0xfb, 0x81, 0x27, 0x3c,
+	0x9c, 0x16, 0x13, 0x9f, 0x87, 0x5b, 0x79, 0xcb, 0x73, 0x9c, 0x18, 0xdc, 0xca, 0xf1, 0x2b, 0xee,
+	0x0a, 0x65, 0x14, 0x43, 0x97, 0xf9, 0xe1, 0xc8, 0x53, 0x36, 0x79, 0x07, 0xd0, 0xf0, 0xfd, 0x43,
+	0x1e, 0x86, 0x6c, 0xc8, 0xf1, 0x0e, 0x64, 0x46, 0x9c, 0x0d, 0x78, 0x50, 0x41, 0x55, 0x54, 0xcb,
+	0xed, 0x3e, 0xab, 0xcb, 0x84, 0xf5, 0x79, 0x48, 0x4b, 0xba, 0xa9, 0x0a, 0xc3, 0x15, 0x58, 0xf7,
+	0xd9, 0x64, 0xec, 0xb1, 0x41, 0x65, 0xad, 0x8a, 0x6a, 0x79, 0x1a, 0x9b, 0xe4, 0x77, 0x04, 0xda,
+	0xdd, 0x6d, 0xf8, 0x53, 0x48, 0x39, 0x5c, 0x30, 0x95, 0x1d, 0x2f, 0x66, 0x17, 0x6c, 0xc0, 0x04,
+	0xa3, 0xd2, 0x8f, 0x3f, 0x86, 0xac, 0xb0, 0x1d, 0x1e, 0x0a, 0xe6, 0xf8, 0x32, 0x71, 0x92, 0xce,
+	0x01, 0xfc, 0x19, 0xa4, 0xa2, 0x8d, 0x95, 0x64, 0x15, 0xd5, 0x8a, 0xbb, 0x1f, 0xdd, 0xd3, 0xd8,
+	0x9b, 0xf8, 0x9c, 0xca, 0x10, 0x5c, 0x83, 0x92, 0xac, 0xd3, 0xf2, 0xc6, 0x6f, 0x79, 0x10, 0xda,
+	0x9e, 0x5b, 0x49, 0x55, 0x51, 0xad, 0x40, 0xef, 0xc2, 0x11, 0x65, 0xc0, 0x7f, 0xb8, 0xe4, 0xa1,
+	0xd8, 0x1f, 0x54, 0xd2, 0x55, 0x54, 0x4b, 0xd1, 0x39, 0x40, 0xce, 0xa0, 0x44, 0xf9, 0xd0, 0x0e,
+	0x05, 0x0f, 0xe8, 0x0c, 0xfc, 0xcf, 0xb5, 0xe8, 0x00, 0x63, 0x16, 0x8a, 0x16, 0xb7, 0x87, 0x23,
+	0x21, 0x8b, 0x49, 0xd1, 0x05, 0x84, 0x74, 0x21, 0x17, 0xa7, 0x6e, 0x58, 0x17, 0xab, 0x14, 0xa3,
+	0xd5, 0x8a, 0x9f, 0x46, 0x1f, 0x6b, 0x21, 0xa9, 0xb2, 0xc8, 0xd7, 0x50, 0x34, 0x46, 0xdc, 0xba,
+	0xe8, 0x5d, 0xc7, 0x52, 0x09, 0xac, 0x89, 0xeb, 0x3b, 0x42, 0x7b, 0x01, 0x73, 0x43, 0x66, 0x45,
+	0xcd, 0x43, 0xd7, 0xc4, 0x35, 0x79, 0x0d, 0xa5, 0xdb, 0x5d, 0xa1, 0xef, 0xb9, 0x21, 0xc7, 0x18,
+	0x52, 0x96, 0x37, 0xe0, 0x8a, 0x5f, 0xae, 0xb1, 0x06, 0xc9, 0xb1, 0x37, 0x94, 0x8c, 0x59, 0x1a,
+	0x2d, 0xc9, 0xb7, 0x50, 0x6e, 0xf2, 0xa1, 0xed, 0x36, 0xc7, 0x9e, 0x75, 0x11, 0x33, 0x6e, 0xdf,
+	0x69, 0xa4, 0x98, 0x55, 0x06, 0x2d, 0xf7, 0x10, 0xd9, 0x04, 0xbc, 0x98, 0x60, 0x46, 0x4e, 0x5e,
+	0x81, 0xb6, 0xc7, 0xc7, 0xf6, 0x15, 0x0f, 0x1e, 0x57, 0x47, 0x1f, 0xca, 0x0b, 0xfb, 0x1e, 0x53,
+	0x09, 0x7e, 0x01, 0x19, 0x79, 0x55, 0xc2, 0x4a, 0xb2, 0x9a, 0xac, 0xe5, 0x76, 0xf3, 0x8a, 0xc2,
+	0x8c, 0x40, 0xaa, 0x7c, 0xe4, 0x1b, 0x28, 0x99, 0xee, 0xe0, 0x7f, 0x57, 0x8b, 0x41, 0x9b, 0x6f,
+	0x57, 0xb5, 0x96, 0xa0, 0x60, 0x78, 0x8e, 0x63, 0x0b, 0x95, 0x90, 0x6c, 0x43, 0x31, 0x06, 0x54,
+	0x05, 0x15, 0x58, 0x67, 0xbe, 0xdf, 0x62, 0xe1, 0x48, 0x72, 0xe4, 0x69, 0x6c, 0x12, 0x02, 0xf9,
+	0xe3, 0x4b, 0x1e, 0x4c, 0x62, 0x31, 0x18, 0x52, 0x51, 0xf7, 0xa9, 0x30, 0xb9, 0x26, 0x6d, 0x28,
+	0xa8, 0x98, 0x47, 0x1d, 0xc8, 0x26, 0xa4, 0xaf, 0xd8, 0xf8, 0x72, 0x76, 0xd3, 0xf2, 0x74, 0x66,
+	0x90, 0x17, 0xb0, 0xd1, 0xf0, 0x7d, 0x33, 0x08, 0x3c, 0x79, 0xff, 0x9d, 0xd9, 0xa5, 0x93, 0xa9,
+	0xb2, 0x34, 0x36, 0x49, 0x0e, 0xb2, 0x2d, 0xce, 0x02, 0x71, 0xce, 0x99, 0x20, 0x9f, 0xc3, 0x93,
+	0x1e, 0xbb, 0xe0, 0x27, 0x6a, 0xf6, 0xc4, 0x52, 0xe7, 0x1d, 0x8c, 0x96, 0x3a, 0xd8, 0x80, 0xcd,
+	0xe5, 0x70, 0xa5, 0xfa, 0x25, 0x6c, 0xc4, 0xe3, 0x4b, 0x9d, 0x74, 0x49, 0x9d, 0xf4, 0x6d, 0xe8,
+	0x6d, 0x00, 0x69, 0x41, 0xe5, 0xc0, 0x63, 0x83, 0xd8, 0x63, 0x8c, 0x2e, 0xdd, 0x8b, 0x07, 0x88,
+	0xa3, 0x82, 0x6d, 0x77, 0xc0, 0xaf, 0xe5, 0x21, 0x14, 0xe8, 0xcc, 0x20, 0x5f, 0xc2, 0xf3, 0x15,
+	0x99, 0x94, 0xa6, 0x4d, 0x48, 0x5b, 0x11, 0xa0, 0xce, 0x7b, 0x66, 0x10, 0x01, 0xcf, 0x1b, 0xbe,
+	0x3f, 0x9e, 0xac, 0x64, 0x7f, 0x4c, 0x19, 0xab, 0x25, 0xcd, 0x59, 0x93, 0x8b, 0xac, 0xaf, 0x60,
+	0x6b, 0x15, 0xeb, 0x83, 0x2d, 0xd4, 0x81, 0xdc, 0xc2, 0xdc, 0x8a, 0x9a, 0xc3, 0x65, 0x4e, 0xfc,
+	0x45, 0xe5, 0x1a, 0xbf, 0x84, 0xf5, 0x2b, 0x35, 0x8e, 0xd6, 0xa4, 0xe4, 0xf2, 0x7c, 0xe0, 0xa9,
+	0x81, 0x44, 0xe3, 0x08, 0xf2, 0x13, 0x94, 0x16, 0x60, 0xe6, 0x0e, 0x97, 0xf6, 0xa3, 0x87, 0xf6,
+	0xe3, 0x2a, 0xe4, 0x42, 0xc1, 0x82, 0xe5, 0x99, 0xb9, 0x08, 0x45, 0xd3, 0x9a, 0xbb, 0x03, 0xe5,
+	0x4f, 0xce, 0xa6, 0xf5, 0x2d, 0x40, 0x0c, 0x28, 0xcf, 0xd3, 0xb6, 0xec, 0x50, 0x78, 0xc1, 0x04,
+	0xd7, 0x21, 0x13, 0x44, 0x52, 0xc2, 0x0a, 0x92, 0xb7, 0xfb, 0xe9, 0x7d, 0x01, 0x91, 0x9b, 0xaa,
+	0xa8, 0xed, 0x7f, 0x10, 0x14, 0x97, 0xdf, 0x14, 0x9c, 0x83, 0xf5, 0xd3, 0x4e, 0xbb, 0xd3, 0x7d,
+	0xd7, 0xd1, 0x12, 0x38, 0x0f, 0x1b, 0xd4, 0x7c, 0xb3, 0x7f, 0xd2, 0x33, 0xa9, 0x86, 0x22, 0xcb,
+	0x68, 0x99, 0x46, 0xbb, 0xdf, 0xfb, 0x4e, 0x5b, 0xc3, 0x25, 0xc8, 0x35, 0xcd, 0x37, 0xfb, 0x9d,
+	0x7e, 0xf3, 0xa0, 0x6b, 0xb4, 0xb5, 0x24, 0x2e, 0x02, 0xec, 0x99, 0x07, 0xfb, 0x6f, 0x4d, 0x1a,
+	0x05, 0xa4, 0x70, 0x01, 0xb2, 0x66, 0x67, 0x4f, 0xb9, 0xd3, 0x18, 0x20, 0x63, 0x74, 0x0f, 0x0f,
+	0xf7, 0x7b, 0x5a, 0x06, 0x67, 0x21, 0x7d, 0x7c, 0x6a, 0xd2, 0x33, 0x6d, 0x3d, 0x5a, 0x9a, 0x94,
+	0x76, 0xa9, 0xb6, 0x81, 0x35, 0xc8, 0xc7, 0x6c, 0xfd, 0x86, 0xd1, 0xd6, 0xb2, 0x51, 0x8a, 0x96,
+	0xd9, 0xa0, 0xbd, 0xa6, 0xd9, 0xe8, 0x69, 0x10, 0x09, 0x38, 0xe9, 0x34, 0x8e, 0x4e, 0x5a, 0xdd,
+	0x9e, 0x96, 0xc3, 0xcf, 0xe0, 0xc9, 0x41, 0xb7, 0xb1, 0xd7, 0x8f, 0xa1, 0xbe, 0xd1, 0x3a, 0xed,
+	0xb4, 0xb5, 0x3c, 0xae, 0xc0, 0x66, 0xe3, 0xe8, 0xe8, 0xe0, 0xec, 0xae, 0xa7, 0xb0, 0x7b, 0x25,
+	0x9b, 0x20, 0xfe, 0xa1, 0xc0, 0x75, 0xd8, 0x88, 0x9f, 0x25, 0xbc, 0xe2, 0x71, 0xdb, 0xba, 0x1d,
+	0x8e, 0x8e, 0x2f, 0x26, 0x24, 0x81, 0x5f, 0x43, 0xb6, 0xe1, 0xfb, 0x27, 0x22, 0xe0, 0xcc, 0xc1,
+	0xe5, 0x7b, 0x6f, 0xf2, 0xd6, 0x7d, 0x88, 0x24, 0x6a, 0xe8, 0x0b, 0xd4, 0x3c, 0x7e, 0x7f, 0xa3,
+	0x27, 0xfe, 0xb8, 0xd1, 0x13, 0x1f, 0x6e, 0x74, 0xf4, 0xf3, 0x54, 0x47, 0xbf, 0x4e, 0x75, 0xf4,
+	0xdb, 0x54, 0x47, 0xef, 0xa7, 0x3a, 0xfa, 0x73, 0xaa, 0xa3, 0xbf, 0xa7, 0x7a, 0xe2, 0xc3, 0x54,
+	0x47, 0xbf, 0xfc, 0xa5, 0x27, 0xbe, 0xff, 0x64, 0x68, 0x8b, 0xd1, 0xe5, 0x79, 0xdd, 0xf2, 0x9c,
+	0x1d, 0xc7, 0x76, 0xc5, 0x8f, 0x23, 0xe6, 0xed, 0x08, 0xcf, 0x67, 0xd6, 0x88, 0xd9, 0xee, 0x8e,
+	0xe4, 0x38, 0xcf, 0xc8, 0xa7, 0xf2, 0xab, 0x7f, 0x07, 0x00, 0xb0, 0x77, 0x19, 0x0c, 0x22, 0x09,
+	0x00, 0x00,
 }
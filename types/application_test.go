@@ -15,6 +15,7 @@ package types
 import (
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,6 +35,46 @@ func TestVersion(t *testing.T) {
 
 	assert.Equal(t, -1, ver1.compare(ver2))
 	assert.Equal(t, true, ver2.equal(ver3))
+
+	// numeric ordering
+	ver9, err := newVersion("9.0.0")
+	assert.NoError(t, err)
+	ver10, err := newVersion("10.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ver10.compare(ver9))
+
+	// precedence example of semver.org, build metadata ignored
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0+build.1",
+	}
+	for i := 1; i < len(ordered); i++ {
+		prev, err := newVersion(ordered[i-1])
+		assert.NoError(t, err)
+		cur, err := newVersion(ordered[i])
+		assert.NoError(t, err)
+		assert.Equal(t, -1, prev.compare(cur), "%s < %s", ordered[i-1], ordered[i])
+		assert.Equal(t, 1, cur.compare(prev), "%s > %s", ordered[i], ordered[i-1])
+	}
+
+	meta1, _ := newVersion("1.0.0+build.1")
+	meta2, _ := newVersion("1.0.0+build.2")
+	assert.True(t, meta1.equal(meta2))
+
+	full, err := newVersion("1.2.3-rc.1+build.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "rc.1", full.GetPrerelease())
+	assert.Equal(t, "build.5", full.GetMetadata())
+	assert.Equal(t, "1.2.3-rc.1+build.5", full.Text())
+
+	for _, junk := range []string{"a.b", "1", "1.0", "1.0.0.0", "01.0.0", "1.0.0-", "1.0.0-01", "1.0.0+", "v1.0.0", "1.0.99999999999999999999"} {
+		_, err := newVersion(junk)
+		assert.Equal(t, ErrInvalidVersion, errors.Cause(err), junk)
+	}
+
+	// versions stored before semver parsing compare numerically
+	legacy := &Version{Major: "10", Minor: "0"}
+	assert.Equal(t, 1, legacy.compare(ver9))
 }
 
 func TestAppVersion(t *testing.T) {
@@ -52,4 +93,101 @@ func TestAppVersion(t *testing.T) {
 
 	assert.Equal(t, false, version1.Compatible(version2))
 	assert.Equal(t, true, version2.Compatible(version3))
+
+	version9, err := NewAppVersion("9.0.0", "9.0.0")
+	assert.NoError(t, err)
+
+	version10, err := NewAppVersion("10.0.0", "9.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, true, version10.Compatible(version9))
+	assert.Equal(t, true, version9.Compatible(version10))
+
+	_, err = NewAppVersion("1.0.0", "1.1.0")
+	assert.Error(t, err)
+}
+
+func TestAppMessage(t *testing.T) {
+	meta := &AppMetadata{Name: "kvset"}
+	msg, err := NewAppMessage(meta, CHECK_TX, 7, &CheckTxRequest{Tx: &Transaction{Payload: []byte("tx")}})
+	assert.NoError(t, err)
+	assert.Equal(t, CHECK_TX, msg.GetHeader().GetType())
+	assert.Equal(t, ProtocolVersion, msg.GetHeader().GetProtocolVersion())
+	assert.Equal(t, uint64(7), msg.GetHeader().GetRequestId())
+
+	req := new(CheckTxRequest)
+	assert.NoError(t, msg.DecodePayload(req))
+	assert.Equal(t, []byte("tx"), req.GetTx().GetPayload())
+
+	msg.Payload = []byte{0xff}
+	assert.Error(t, msg.DecodePayload(req))
+}
+
+func TestAppVersionText(t *testing.T) {
+	version, err := NewAppVersion("1.2.0", "1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.0", version.GetVersion().Text())
+	assert.Equal(t, "1.2.0(backwards 1.0.0)", version.Text())
+
+	assert.False(t, version.Compatible(nil))
+	assert.False(t, (*AppVersion)(nil).Compatible(version))
+}
+
+func TestSnapshot(t *testing.T) {
+	chunks := [][]byte{[]byte("chunk0"), []byte("chunk1")}
+	snapshot, err := NewSnapshot(10, []byte("apphash"), chunks, "SHA256")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), snapshot.GetHeight())
+	assert.Len(t, snapshot.GetChunkHashes(), 2)
+
+	assert.NoError(t, snapshot.VerifyChunk(0, chunks[0], "SHA256"))
+	assert.NoError(t, snapshot.VerifyChunk(1, chunks[1], ""))
+	assert.Equal(t, ErrChunkMismatch, errors.Cause(snapshot.VerifyChunk(0, chunks[1], "SHA256")))
+	assert.Equal(t, ErrChunkMismatch, errors.Cause(snapshot.VerifyChunk(2, chunks[0], "SHA256")))
+
+	_, err = NewSnapshot(10, nil, chunks, "unknown")
+	assert.Error(t, err)
+}
+
+func TestEventFilter(t *testing.T) {
+	event := &TxEvent{
+		Application: "kvset",
+		Event:       NewEvent("set", map[string]string{"key": "alice", "value": "100"}),
+	}
+	assert.Equal(t, "key", event.GetEvent().GetAttributes()[0].GetKey())
+
+	for _, filter := range []*EventFilter{
+		{},
+		{Application: "kvset"},
+		{Application: "kvset", Type: "set"},
+		{Attributes: []*EventAttribute{{Key: "key", Value: "alice"}}},
+		{Attributes: []*EventAttribute{{Key: "value"}, {Key: "key", Value: "alice"}}},
+	} {
+		assert.True(t, filter.Match(event), "%v", filter)
+	}
+
+	for _, filter := range []*EventFilter{
+		{Application: "words"},
+		{Type: "delete"},
+		{Attributes: []*EventAttribute{{Key: "key", Value: "bob"}}},
+		{Attributes: []*EventAttribute{{Key: "owner"}}},
+	} {
+		assert.False(t, filter.Match(event), "%v", filter)
+	}
+}
+
+func TestAppVersionUpgrades(t *testing.T) {
+	v1, _ := NewAppVersion("1.0.0", "1.0.0")
+	v2, _ := NewAppVersion("2.0.0", "1.0.0")
+	v21, _ := NewAppVersion("2.1.0", "2.0.0")
+	v3, _ := NewAppVersion("3.0.0", "3.0.0")
+
+	assert.True(t, v2.Newer(v1))
+	assert.False(t, v1.Newer(v2))
+	assert.False(t, v2.Newer(v2))
+
+	assert.True(t, v2.Upgrades(v2))
+	assert.True(t, v21.Upgrades(v2))
+	assert.False(t, v1.Upgrades(v2))
+	assert.False(t, v3.Upgrades(v2))
+	assert.False(t, (*AppVersion)(nil).Upgrades(v2))
 }
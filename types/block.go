@@ -14,14 +14,40 @@ package types
 
 import (
 	"github.com/gogo/protobuf/proto"
-	"github.com/mintzhao/topachain/common/crypto"
 )
 
+// Hash hashes the txs by hash algorithm hash, the default one if empty
 func (b *BlockTxs) Hash(hash string) ([]byte, error) {
 	bbytes, err := proto.Marshal(b)
 	if err != nil {
 		return nil, err
 	}
 
-	return crypto.Hash(bbytes, hash)
+	return hashBytes(bbytes, hash)
+}
+
+// Hash hashes the header by hash algorithm hash, the default one if empty
+func (h *BlockHeader) Hash(hash string) ([]byte, error) {
+	hbytes, err := proto.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return hashBytes(hbytes, hash)
+}
+
+// NewUpgradeTx returns the tx proposing application upgraded to version at block height
+func NewUpgradeTx(version *AppVersion, height uint64) (*Transaction, error) {
+	payload, err := proto.Marshal(&UpgradeProposal{
+		Version: version,
+		Height:  height,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transaction{
+		Payload: payload,
+		Type:    UPGRADE_TX,
+	}, nil
 }
@@ -7,6 +7,8 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 
+import strconv "strconv"
+
 import bytes "bytes"
 
 import strings "strings"
@@ -19,6 +21,25 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// TxType tells who executes a transaction
+type TxType int32
+
+const (
+	APP_TX     TxType = 0
+	UPGRADE_TX TxType = 1
+)
+
+var TxType_name = map[int32]string{
+	0: "APP_TX",
+	1: "UPGRADE_TX",
+}
+var TxType_value = map[string]int32{
+	"APP_TX":     0,
+	"UPGRADE_TX": 1,
+}
+
+func (TxType) EnumDescriptor() ([]byte, []int) { return fileDescriptorCommon, []int{0} }
+
 // Empty message
 type Empty struct {
 }
@@ -102,6 +123,7 @@ func (m *Block) GetTxs() *BlockTxs {
 // transaction
 type Transaction struct {
 	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Type    TxType `protobuf:"varint,2,opt,name=type,proto3,enum=types.TxType" json:"type,omitempty"`
 }
 
 func (m *Transaction) Reset()                    { *m = Transaction{} }
@@ -115,15 +137,48 @@ func (m *Transaction) GetPayload() []byte {
 	return nil
 }
 
+func (m *Transaction) GetType() TxType {
+	if m != nil {
+		return m.Type
+	}
+	return APP_TX
+}
+
+// UpgradeProposal schedules application to be upgraded to version at block height,
+// from which blocks are delivered to applications upgraded only
+type UpgradeProposal struct {
+	Version *AppVersion `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	Height  uint64      `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *UpgradeProposal) Reset()                    { *m = UpgradeProposal{} }
+func (*UpgradeProposal) ProtoMessage()               {}
+func (*UpgradeProposal) Descriptor() ([]byte, []int) { return fileDescriptorCommon, []int{5} }
+
+func (m *UpgradeProposal) GetVersion() *AppVersion {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *UpgradeProposal) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 // genesis transaction proposal, contains configuration of the chain
 type GenesisTxProposal struct {
-	Name   string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Config *AppConfig `protobuf:"bytes,2,opt,name=config" json:"config,omitempty"`
+	Name    string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Config  *AppConfig  `protobuf:"bytes,2,opt,name=config" json:"config,omitempty"`
+	Version *AppVersion `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *GenesisTxProposal) Reset()                    { *m = GenesisTxProposal{} }
 func (*GenesisTxProposal) ProtoMessage()               {}
-func (*GenesisTxProposal) Descriptor() ([]byte, []int) { return fileDescriptorCommon, []int{5} }
+func (*GenesisTxProposal) Descriptor() ([]byte, []int) { return fileDescriptorCommon, []int{6} }
 
 func (m *GenesisTxProposal) GetName() string {
 	if m != nil {
@@ -139,13 +194,107 @@ func (m *GenesisTxProposal) GetConfig() *AppConfig {
 	return nil
 }
 
+func (m *GenesisTxProposal) GetVersion() *AppVersion {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+// Version is a semantic version, see https://semver.org
+type Version struct {
+	Major      string `protobuf:"bytes,1,opt,name=major,proto3" json:"major,omitempty"`
+	Minor      string `protobuf:"bytes,2,opt,name=minor,proto3" json:"minor,omitempty"`
+	Build      string `protobuf:"bytes,3,opt,name=build,proto3" json:"build,omitempty"`
+	Prerelease string `protobuf:"bytes,4,opt,name=prerelease,proto3" json:"prerelease,omitempty"`
+	Metadata   string `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (m *Version) Reset()                    { *m = Version{} }
+func (*Version) ProtoMessage()               {}
+func (*Version) Descriptor() ([]byte, []int) { return fileDescriptorCommon, []int{7} }
+
+func (m *Version) GetMajor() string {
+	if m != nil {
+		return m.Major
+	}
+	return ""
+}
+
+func (m *Version) GetMinor() string {
+	if m != nil {
+		return m.Minor
+	}
+	return ""
+}
+
+func (m *Version) GetBuild() string {
+	if m != nil {
+		return m.Build
+	}
+	return ""
+}
+
+func (m *Version) GetPrerelease() string {
+	if m != nil {
+		return m.Prerelease
+	}
+	return ""
+}
+
+func (m *Version) GetMetadata() string {
+	if m != nil {
+		return m.Metadata
+	}
+	return ""
+}
+
+// Application version
+// Features:
+// 1. upgrade version value must larger than current version
+// 2. newest application can compatible a specific version of application,
+//    older version than Backwards shouldn't be used in the blockchain network.
+type AppVersion struct {
+	Version   *Version `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	Backwards *Version `protobuf:"bytes,2,opt,name=backwards" json:"backwards,omitempty"`
+}
+
+func (m *AppVersion) Reset()                    { *m = AppVersion{} }
+func (*AppVersion) ProtoMessage()               {}
+func (*AppVersion) Descriptor() ([]byte, []int) { return fileDescriptorCommon, []int{8} }
+
+func (m *AppVersion) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *AppVersion) GetBackwards() *Version {
+	if m != nil {
+		return m.Backwards
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "types.Empty")
 	proto.RegisterType((*BlockHeader)(nil), "types.BlockHeader")
 	proto.RegisterType((*BlockTxs)(nil), "types.BlockTxs")
 	proto.RegisterType((*Block)(nil), "types.Block")
 	proto.RegisterType((*Transaction)(nil), "types.Transaction")
+	proto.RegisterType((*UpgradeProposal)(nil), "types.UpgradeProposal")
 	proto.RegisterType((*GenesisTxProposal)(nil), "types.GenesisTxProposal")
+	proto.RegisterType((*Version)(nil), "types.Version")
+	proto.RegisterType((*AppVersion)(nil), "types.AppVersion")
+	proto.RegisterEnum("types.TxType", TxType_name, TxType_value)
+}
+func (x TxType) String() string {
+	s, ok := TxType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
 }
 func (this *Empty) Equal(that interface{}) bool {
 	if that == nil {
@@ -306,6 +455,42 @@ func (this *Transaction) Equal(that interface{}) bool {
 	if !bytes.Equal(this.Payload, that1.Payload) {
 		return false
 	}
+	if this.Type != that1.Type {
+		return false
+	}
+	return true
+}
+func (this *UpgradeProposal) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*UpgradeProposal)
+	if !ok {
+		that2, ok := that.(UpgradeProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Version.Equal(that1.Version) {
+		return false
+	}
+	if this.Height != that1.Height {
+		return false
+	}
 	return true
 }
 func (this *GenesisTxProposal) Equal(that interface{}) bool {
@@ -339,6 +524,84 @@ func (this *GenesisTxProposal) Equal(that interface{}) bool {
 	if !this.Config.Equal(that1.Config) {
 		return false
 	}
+	if !this.Version.Equal(that1.Version) {
+		return false
+	}
+	return true
+}
+func (this *Version) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*Version)
+	if !ok {
+		that2, ok := that.(Version)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.Major != that1.Major {
+		return false
+	}
+	if this.Minor != that1.Minor {
+		return false
+	}
+	if this.Build != that1.Build {
+		return false
+	}
+	if this.Prerelease != that1.Prerelease {
+		return false
+	}
+	if this.Metadata != that1.Metadata {
+		return false
+	}
+	return true
+}
+func (this *AppVersion) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*AppVersion)
+	if !ok {
+		that2, ok := that.(AppVersion)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Version.Equal(that1.Version) {
+		return false
+	}
+	if !this.Backwards.Equal(that1.Backwards) {
+		return false
+	}
 	return true
 }
 func (this *Empty) GoString() string {
@@ -393,22 +656,68 @@ func (this *Transaction) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
+	s := make([]string, 0, 6)
 	s = append(s, "&types.Transaction{")
 	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *GenesisTxProposal) GoString() string {
+func (this *UpgradeProposal) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 6)
+	s = append(s, "&types.UpgradeProposal{")
+	if this.Version != nil {
+		s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	}
+	s = append(s, "Height: "+fmt.Sprintf("%#v", this.Height)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GenesisTxProposal) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
 	s = append(s, "&types.GenesisTxProposal{")
 	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
 	if this.Config != nil {
 		s = append(s, "Config: "+fmt.Sprintf("%#v", this.Config)+",\n")
 	}
+	if this.Version != nil {
+		s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Version) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&types.Version{")
+	s = append(s, "Major: "+fmt.Sprintf("%#v", this.Major)+",\n")
+	s = append(s, "Minor: "+fmt.Sprintf("%#v", this.Minor)+",\n")
+	s = append(s, "Build: "+fmt.Sprintf("%#v", this.Build)+",\n")
+	s = append(s, "Prerelease: "+fmt.Sprintf("%#v", this.Prerelease)+",\n")
+	s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AppVersion) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&types.AppVersion{")
+	if this.Version != nil {
+		s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	}
+	if this.Backwards != nil {
+		s = append(s, "Backwards: "+fmt.Sprintf("%#v", this.Backwards)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -562,6 +871,44 @@ func (m *Transaction) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintCommon(dAtA, i, uint64(len(m.Payload)))
 		i += copy(dAtA[i:], m.Payload)
 	}
+	if m.Type != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(m.Type))
+	}
+	return i, nil
+}
+
+func (m *UpgradeProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpgradeProposal) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Version != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(m.Version.Size()))
+		n3, err := m.Version.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.Height != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(m.Height))
+	}
 	return i, nil
 }
 
@@ -590,54 +937,132 @@ func (m *GenesisTxProposal) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintCommon(dAtA, i, uint64(m.Config.Size()))
-		n3, err := m.Config.MarshalTo(dAtA[i:])
+		n4, err := m.Config.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
+	}
+	if m.Version != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(m.Version.Size()))
+		n5, err := m.Version.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
 	}
 	return i, nil
 }
 
-func encodeFixed64Common(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Common(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintCommon(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Version) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func (m *Empty) Size() (n int) {
-	var l int
-	_ = l
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *BlockHeader) Size() (n int) {
+func (m *Version) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.BlockHeight != 0 {
-		n += 1 + sovCommon(uint64(m.BlockHeight))
-	}
+	if len(m.Major) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Major)))
+		i += copy(dAtA[i:], m.Major)
+	}
+	if len(m.Minor) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Minor)))
+		i += copy(dAtA[i:], m.Minor)
+	}
+	if len(m.Build) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Build)))
+		i += copy(dAtA[i:], m.Build)
+	}
+	if len(m.Prerelease) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Prerelease)))
+		i += copy(dAtA[i:], m.Prerelease)
+	}
+	if len(m.Metadata) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Metadata)))
+		i += copy(dAtA[i:], m.Metadata)
+	}
+	return i, nil
+}
+
+func (m *AppVersion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppVersion) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Version != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(m.Version.Size()))
+		n6, err := m.Version.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.Backwards != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCommon(dAtA, i, uint64(m.Backwards.Size()))
+		n7, err := m.Backwards.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	return i, nil
+}
+
+func encodeVarintCommon(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Empty) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *BlockHeader) Size() (n int) {
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovCommon(uint64(m.BlockHeight))
+	}
 	l = len(m.PreviousBlock)
 	if l > 0 {
 		n += 1 + l + sovCommon(uint64(l))
@@ -682,6 +1107,22 @@ func (m *Transaction) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCommon(uint64(l))
 	}
+	if m.Type != 0 {
+		n += 1 + sovCommon(uint64(m.Type))
+	}
+	return n
+}
+
+func (m *UpgradeProposal) Size() (n int) {
+	var l int
+	_ = l
+	if m.Version != nil {
+		l = m.Version.Size()
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovCommon(uint64(m.Height))
+	}
 	return n
 }
 
@@ -696,6 +1137,50 @@ func (m *GenesisTxProposal) Size() (n int) {
 		l = m.Config.Size()
 		n += 1 + l + sovCommon(uint64(l))
 	}
+	if m.Version != nil {
+		l = m.Version.Size()
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	return n
+}
+
+func (m *Version) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Major)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	l = len(m.Minor)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	l = len(m.Build)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	l = len(m.Prerelease)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	return n
+}
+
+func (m *AppVersion) Size() (n int) {
+	var l int
+	_ = l
+	if m.Version != nil {
+		l = m.Version.Size()
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	if m.Backwards != nil {
+		l = m.Backwards.Size()
+		n += 1 + l + sovCommon(uint64(l))
+	}
 	return n
 }
 
@@ -760,6 +1245,18 @@ func (this *Transaction) String() string {
 	}
 	s := strings.Join([]string{`&Transaction{`,
 		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpgradeProposal) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpgradeProposal{`,
+		`Version:` + strings.Replace(fmt.Sprintf("%v", this.Version), "AppVersion", "AppVersion", 1) + `,`,
+		`Height:` + fmt.Sprintf("%v", this.Height) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -771,6 +1268,32 @@ func (this *GenesisTxProposal) String() string {
 	s := strings.Join([]string{`&GenesisTxProposal{`,
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
 		`Config:` + strings.Replace(fmt.Sprintf("%v", this.Config), "AppConfig", "AppConfig", 1) + `,`,
+		`Version:` + strings.Replace(fmt.Sprintf("%v", this.Version), "AppVersion", "AppVersion", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Version) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Version{`,
+		`Major:` + fmt.Sprintf("%v", this.Major) + `,`,
+		`Minor:` + fmt.Sprintf("%v", this.Minor) + `,`,
+		`Build:` + fmt.Sprintf("%v", this.Build) + `,`,
+		`Prerelease:` + fmt.Sprintf("%v", this.Prerelease) + `,`,
+		`Metadata:` + fmt.Sprintf("%v", this.Metadata) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AppVersion) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AppVersion{`,
+		`Version:` + strings.Replace(fmt.Sprintf("%v", this.Version), "Version", "Version", 1) + `,`,
+		`Backwards:` + strings.Replace(fmt.Sprintf("%v", this.Backwards), "Version", "Version", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1221,6 +1744,127 @@ func (m *Transaction) Unmarshal(dAtA []byte) error {
 				m.Payload = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (TxType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpgradeProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpgradeProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpgradeProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Version == nil {
+				m.Version = &AppVersion{}
+			}
+			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -1333,6 +1977,350 @@ func (m *GenesisTxProposal) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Version == nil {
+				m.Version = &AppVersion{}
+			}
+			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Version) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Version: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Version: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Major", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Major = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Minor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Minor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Build", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Build = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prerelease", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prerelease = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AppVersion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppVersion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppVersion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Version == nil {
+				m.Version = &Version{}
+			}
+			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backwards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Backwards == nil {
+				m.Backwards = &Version{}
+			}
+			if err := m.Backwards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -1462,28 +2450,40 @@ var (
 func init() { proto.RegisterFile("common.proto", fileDescriptorCommon) }
 
 var fileDescriptorCommon = []byte{
-	// 365 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x51, 0x3d, 0x6e, 0xdb, 0x30,
-	0x18, 0x15, 0xfd, 0x23, 0xb7, 0x94, 0x8a, 0xb6, 0x1c, 0x0a, 0xa1, 0x03, 0xa1, 0xaa, 0x06, 0x2a,
-	0x74, 0x90, 0x8b, 0xf6, 0x04, 0x75, 0x10, 0x24, 0x63, 0x2c, 0x08, 0x19, 0xb2, 0xd1, 0xb2, 0x62,
-	0x11, 0xb1, 0xf8, 0x11, 0x22, 0x1d, 0xc8, 0x99, 0x72, 0x84, 0x1c, 0x23, 0x47, 0xc9, 0xe8, 0x31,
-	0x63, 0xac, 0x2c, 0x19, 0x7d, 0x84, 0x20, 0x94, 0x82, 0x28, 0x9b, 0xde, 0xcf, 0xf7, 0xde, 0x83,
-	0x88, 0xdd, 0x14, 0x8a, 0x02, 0x44, 0x24, 0x4b, 0xd0, 0x40, 0x86, 0x7a, 0x23, 0x33, 0xf5, 0xdd,
-	0x4d, 0x41, 0x9c, 0xf3, 0x65, 0x43, 0x06, 0x23, 0x3c, 0x3c, 0x2c, 0xa4, 0xde, 0x04, 0x05, 0x76,
-	0xa6, 0x2b, 0x48, 0x2f, 0x8e, 0x33, 0xb6, 0xc8, 0x4a, 0xe2, 0x63, 0x67, 0xde, 0x40, 0xbe, 0xcc,
-	0xb5, 0x87, 0x7c, 0x14, 0x0e, 0xe2, 0x2e, 0x45, 0xc6, 0xf8, 0x93, 0x2c, 0xb3, 0x4b, 0x0e, 0x6b,
-	0x65, 0x0e, 0xbd, 0x9e, 0x8f, 0x42, 0x37, 0x7e, 0x4f, 0x92, 0x6f, 0xd8, 0xd6, 0x55, 0x09, 0xa0,
-	0xbd, 0xbe, 0x91, 0x5b, 0x14, 0xfc, 0xc1, 0x1f, 0x8c, 0x21, 0xa9, 0x14, 0x19, 0xe3, 0xbe, 0xae,
-	0x94, 0x87, 0xfc, 0x7e, 0xe8, 0xfc, 0x25, 0x91, 0x99, 0x19, 0x25, 0x25, 0x13, 0x8a, 0xa5, 0x9a,
-	0x83, 0x88, 0x5f, 0xe4, 0xe0, 0x14, 0x0f, 0x9b, 0xc8, 0xdf, 0xd8, 0xce, 0xcd, 0x48, 0xb3, 0xea,
-	0xed, 0xa2, 0x33, 0x3f, 0x6e, 0x1d, 0xe4, 0x47, 0x13, 0xdd, 0x33, 0xc6, 0xcf, 0x5d, 0x63, 0x52,
-	0xa9, 0x26, 0xf7, 0x17, 0x76, 0x3a, 0x5d, 0xc4, 0xc3, 0x23, 0xc9, 0x36, 0x2b, 0x60, 0x0b, 0x13,
-	0xef, 0xc6, 0xaf, 0x30, 0x98, 0xe1, 0xaf, 0x47, 0x99, 0xc8, 0x14, 0x57, 0x49, 0x75, 0x52, 0x82,
-	0x04, 0xc5, 0x56, 0x84, 0xe0, 0x81, 0x60, 0x45, 0x66, 0xbc, 0x1f, 0x63, 0xf3, 0x4d, 0x42, 0x6c,
-	0x37, 0xff, 0xb8, 0xed, 0xfd, 0xd2, 0xf6, 0xfe, 0x97, 0xf2, 0xc0, 0xf0, 0x71, 0xab, 0x4f, 0x67,
-	0xdb, 0x1d, 0xb5, 0xee, 0x77, 0xd4, 0xda, 0xef, 0x28, 0xba, 0xae, 0x29, 0xba, 0xad, 0x29, 0xba,
-	0xab, 0x29, 0xda, 0xd6, 0x14, 0x3d, 0xd4, 0x14, 0x3d, 0xd5, 0xd4, 0xda, 0xd7, 0x14, 0xdd, 0x3c,
-	0x52, 0xeb, 0xec, 0xe7, 0x92, 0xeb, 0x7c, 0x3d, 0x8f, 0x52, 0x28, 0x26, 0x05, 0x17, 0xfa, 0x2a,
-	0x67, 0x30, 0xd1, 0x20, 0x59, 0x9a, 0x33, 0x2e, 0x26, 0xa6, 0x64, 0x6e, 0x9b, 0x77, 0xfd, 0xf7,
-	0x1c, 0x00, 0x00, 0xff, 0xff, 0x0e, 0x77, 0x2d, 0xf6, 0xfc, 0x01, 0x00, 0x00,
+	// 551 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0xcb, 0x6e, 0xd3, 0x4c,
+	0x14, 0xce, 0xe4, 0xe2, 0xb4, 0x27, 0xe9, 0x6d, 0xf4, 0xeb, 0x97, 0xd5, 0xc5, 0x28, 0x35, 0x5d,
+	0x44, 0x05, 0xa5, 0xa8, 0x3c, 0x41, 0x0b, 0x55, 0x11, 0xab, 0x60, 0xb9, 0x11, 0x62, 0x83, 0x26,
+	0xf6, 0x10, 0x0f, 0x8d, 0x3d, 0xa3, 0xf1, 0xa4, 0x38, 0x2c, 0x10, 0x3b, 0xb6, 0x3c, 0x06, 0x8f,
+	0xc2, 0xb2, 0x4b, 0x96, 0xc4, 0x6c, 0x58, 0xf6, 0x11, 0x50, 0x66, 0x9c, 0xc6, 0x94, 0x05, 0xbb,
+	0x7c, 0x97, 0xf3, 0x9d, 0xcf, 0xc7, 0x31, 0x74, 0x43, 0x91, 0x24, 0x22, 0x1d, 0x48, 0x25, 0xb4,
+	0xc0, 0x2d, 0x3d, 0x97, 0x2c, 0xdb, 0xef, 0x86, 0x22, 0x7d, 0xcb, 0x27, 0x96, 0xf4, 0xda, 0xd0,
+	0x3a, 0x4f, 0xa4, 0x9e, 0x7b, 0x09, 0x74, 0xce, 0xa6, 0x22, 0xbc, 0x7a, 0xce, 0x68, 0xc4, 0x14,
+	0xee, 0x41, 0x67, 0x6c, 0x21, 0x9f, 0xc4, 0xda, 0x45, 0x3d, 0xd4, 0x6f, 0xfa, 0x55, 0x0a, 0x1f,
+	0xc2, 0x96, 0x54, 0xec, 0x9a, 0x8b, 0x59, 0x66, 0x06, 0xdd, 0x7a, 0x0f, 0xf5, 0xbb, 0xfe, 0x9f,
+	0x24, 0xfe, 0x1f, 0x1c, 0x9d, 0x2b, 0x21, 0xb4, 0xdb, 0x30, 0x72, 0x89, 0xbc, 0xc7, 0xb0, 0x61,
+	0x0c, 0x41, 0x9e, 0xe1, 0x43, 0x68, 0xe8, 0x3c, 0x73, 0x51, 0xaf, 0xd1, 0xef, 0x9c, 0xe0, 0x81,
+	0xa9, 0x39, 0x08, 0x14, 0x4d, 0x33, 0x1a, 0x6a, 0x2e, 0x52, 0x7f, 0x29, 0x7b, 0x23, 0x68, 0xd9,
+	0xc8, 0x23, 0x70, 0x62, 0x53, 0xd2, 0xb4, 0x5a, 0x4f, 0x54, 0xea, 0xfb, 0xa5, 0x03, 0x1f, 0xd8,
+	0xe8, 0xba, 0x31, 0xee, 0x54, 0x8d, 0x41, 0x9e, 0xd9, 0xdc, 0x17, 0xd0, 0xa9, 0xec, 0xc2, 0x2e,
+	0xb4, 0x25, 0x9d, 0x4f, 0x05, 0x8d, 0x4c, 0x7c, 0xd7, 0x5f, 0x41, 0x7c, 0x00, 0xcd, 0xe5, 0xbc,
+	0x09, 0xdb, 0x3e, 0xd9, 0x5a, 0xf5, 0xcc, 0x83, 0xb9, 0x64, 0xbe, 0x91, 0xbc, 0x11, 0xec, 0x5c,
+	0xca, 0x89, 0xa2, 0x11, 0x1b, 0x2a, 0x21, 0x45, 0x46, 0xa7, 0xf8, 0x21, 0xb4, 0xaf, 0x99, 0xca,
+	0xb8, 0x48, 0xcb, 0xba, 0x7b, 0xe5, 0xe0, 0xa9, 0x94, 0x23, 0x2b, 0xf8, 0x2b, 0xc7, 0xf2, 0x5a,
+	0xb1, 0x3d, 0x78, 0xdd, 0x1c, 0xbc, 0x44, 0xde, 0x47, 0xd8, 0xbb, 0x60, 0x29, 0xcb, 0x78, 0x16,
+	0xe4, 0x77, 0xc9, 0x18, 0x9a, 0x29, 0x4d, 0x98, 0x89, 0xdd, 0xf4, 0xcd, 0x6f, 0xdc, 0x07, 0xc7,
+	0xbe, 0xde, 0xf2, 0x91, 0x77, 0xd7, 0xcb, 0x9e, 0x1a, 0xde, 0x2f, 0xf5, 0x6a, 0xaf, 0xc6, 0xbf,
+	0x7a, 0x79, 0x9f, 0x11, 0xb4, 0x4b, 0x12, 0xff, 0x07, 0xad, 0x84, 0xbe, 0x13, 0xaa, 0xdc, 0x6b,
+	0x81, 0x61, 0x79, 0x2a, 0x94, 0x5b, 0x2f, 0x59, 0x9e, 0x5a, 0x76, 0x3c, 0xe3, 0xd3, 0xc8, 0xac,
+	0xd8, 0xf4, 0x2d, 0xc0, 0x04, 0x40, 0x2a, 0xa6, 0xd8, 0x94, 0xd1, 0x8c, 0xb9, 0x4d, 0x23, 0x55,
+	0x18, 0xbc, 0x0f, 0x1b, 0x09, 0xd3, 0x34, 0xa2, 0x9a, 0xba, 0x2d, 0xa3, 0xde, 0x61, 0x2f, 0x02,
+	0x58, 0x17, 0xc4, 0xfd, 0xfb, 0xc7, 0xdd, 0x2e, 0x1f, 0xe2, 0xaf, 0xcb, 0x3e, 0x82, 0xcd, 0x31,
+	0x0d, 0xaf, 0xde, 0x53, 0x15, 0xad, 0xfe, 0x0e, 0xf7, 0xbd, 0x6b, 0xc3, 0xd1, 0x21, 0x38, 0xf6,
+	0xbd, 0x62, 0x00, 0xe7, 0x74, 0x38, 0x7c, 0x13, 0xbc, 0xda, 0xad, 0xe1, 0x6d, 0x80, 0xcb, 0xe1,
+	0x85, 0x7f, 0xfa, 0xec, 0x7c, 0x89, 0xd1, 0xd9, 0xcb, 0x9b, 0x05, 0xa9, 0x7d, 0x5f, 0x90, 0xda,
+	0xed, 0x82, 0xa0, 0x4f, 0x05, 0x41, 0x5f, 0x0b, 0x82, 0xbe, 0x15, 0x04, 0xdd, 0x14, 0x04, 0xfd,
+	0x28, 0x08, 0xfa, 0x55, 0x90, 0xda, 0x6d, 0x41, 0xd0, 0x97, 0x9f, 0xa4, 0xf6, 0xfa, 0xc1, 0x84,
+	0xeb, 0x78, 0x36, 0x1e, 0x84, 0x22, 0x39, 0x4e, 0x78, 0xaa, 0x3f, 0xc4, 0x54, 0x1c, 0x6b, 0x21,
+	0x69, 0x18, 0x53, 0x9e, 0x1e, 0x9b, 0x2e, 0x63, 0xc7, 0x7c, 0x95, 0x4f, 0x7e, 0x0f, 0x00, 0xb7,
+	0x21, 0x64, 0x07, 0xba, 0x03, 0x00, 0x00,
 }
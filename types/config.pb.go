@@ -19,9 +19,11 @@ var _ = math.Inf
 
 // AppConfig
 type AppConfig struct {
-	BlockInterval int64  `protobuf:"varint,1,opt,name=blockInterval,proto3" json:"blockInterval,omitempty"`
-	BlockTxCount  int64  `protobuf:"varint,2,opt,name=blockTxCount,proto3" json:"blockTxCount,omitempty"`
-	Hash          string `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	BlockInterval    int64  `protobuf:"varint,1,opt,name=blockInterval,proto3" json:"blockInterval,omitempty"`
+	BlockTxCount     int64  `protobuf:"varint,2,opt,name=blockTxCount,proto3" json:"blockTxCount,omitempty"`
+	Hash             string `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	MasterAddress    string `protobuf:"bytes,4,opt,name=masterAddress,proto3" json:"masterAddress,omitempty"`
+	SnapshotInterval uint64 `protobuf:"varint,5,opt,name=snapshotInterval,proto3" json:"snapshotInterval,omitempty"`
 }
 
 func (m *AppConfig) Reset()                    { *m = AppConfig{} }
@@ -49,6 +51,20 @@ func (m *AppConfig) GetHash() string {
 	return ""
 }
 
+func (m *AppConfig) GetMasterAddress() string {
+	if m != nil {
+		return m.MasterAddress
+	}
+	return ""
+}
+
+func (m *AppConfig) GetSnapshotInterval() uint64 {
+	if m != nil {
+		return m.SnapshotInterval
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*AppConfig)(nil), "types.AppConfig")
 }
@@ -86,17 +102,25 @@ func (this *AppConfig) Equal(that interface{}) bool {
 	if this.Hash != that1.Hash {
 		return false
 	}
+	if this.MasterAddress != that1.MasterAddress {
+		return false
+	}
+	if this.SnapshotInterval != that1.SnapshotInterval {
+		return false
+	}
 	return true
 }
 func (this *AppConfig) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 9)
 	s = append(s, "&types.AppConfig{")
 	s = append(s, "BlockInterval: "+fmt.Sprintf("%#v", this.BlockInterval)+",\n")
 	s = append(s, "BlockTxCount: "+fmt.Sprintf("%#v", this.BlockTxCount)+",\n")
 	s = append(s, "Hash: "+fmt.Sprintf("%#v", this.Hash)+",\n")
+	s = append(s, "MasterAddress: "+fmt.Sprintf("%#v", this.MasterAddress)+",\n")
+	s = append(s, "SnapshotInterval: "+fmt.Sprintf("%#v", this.SnapshotInterval)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -139,27 +163,20 @@ func (m *AppConfig) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintConfig(dAtA, i, uint64(len(m.Hash)))
 		i += copy(dAtA[i:], m.Hash)
 	}
+	if len(m.MasterAddress) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.MasterAddress)))
+		i += copy(dAtA[i:], m.MasterAddress)
+	}
+	if m.SnapshotInterval != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintConfig(dAtA, i, uint64(m.SnapshotInterval))
+	}
 	return i, nil
 }
 
-func encodeFixed64Config(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Config(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
 func encodeVarintConfig(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -182,6 +199,13 @@ func (m *AppConfig) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovConfig(uint64(l))
 	}
+	l = len(m.MasterAddress)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.SnapshotInterval != 0 {
+		n += 1 + sovConfig(uint64(m.SnapshotInterval))
+	}
 	return n
 }
 
@@ -206,6 +230,8 @@ func (this *AppConfig) String() string {
 		`BlockInterval:` + fmt.Sprintf("%v", this.BlockInterval) + `,`,
 		`BlockTxCount:` + fmt.Sprintf("%v", this.BlockTxCount) + `,`,
 		`Hash:` + fmt.Sprintf("%v", this.Hash) + `,`,
+		`MasterAddress:` + fmt.Sprintf("%v", this.MasterAddress) + `,`,
+		`SnapshotInterval:` + fmt.Sprintf("%v", this.SnapshotInterval) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -314,6 +340,54 @@ func (m *AppConfig) Unmarshal(dAtA []byte) error {
 			}
 			m.Hash = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MasterAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MasterAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotInterval", wireType)
+			}
+			m.SnapshotInterval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotInterval |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -443,18 +517,21 @@ var (
 func init() { proto.RegisterFile("config.proto", fileDescriptorConfig) }
 
 var fileDescriptorConfig = []byte{
-	// 205 bytes of a gzipped FileDescriptorProto
+	// 246 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0xce, 0xcf, 0x4b,
 	0xcb, 0x4c, 0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0xa9, 0x2c, 0x48, 0x2d, 0x56,
-	0xca, 0xe4, 0xe2, 0x74, 0x2c, 0x28, 0x70, 0x06, 0xcb, 0x08, 0xa9, 0x70, 0xf1, 0x26, 0xe5, 0xe4,
-	0x27, 0x67, 0x7b, 0xe6, 0x95, 0xa4, 0x16, 0x95, 0x25, 0xe6, 0x48, 0x30, 0x2a, 0x30, 0x6a, 0x30,
-	0x07, 0xa1, 0x0a, 0x0a, 0x29, 0x71, 0xf1, 0x80, 0x05, 0x42, 0x2a, 0x9c, 0xf3, 0x4b, 0xf3, 0x4a,
-	0x24, 0x98, 0xc0, 0x8a, 0x50, 0xc4, 0x84, 0x84, 0xb8, 0x58, 0x32, 0x12, 0x8b, 0x33, 0x24, 0x98,
-	0x15, 0x18, 0x35, 0x38, 0x83, 0xc0, 0x6c, 0xa7, 0xc0, 0x0b, 0x0f, 0xe5, 0x18, 0x6e, 0x3c, 0x94,
-	0x63, 0xf8, 0xf0, 0x50, 0x8e, 0xb1, 0xe1, 0x91, 0x1c, 0xe3, 0x8a, 0x47, 0x72, 0x8c, 0x27, 0x1e,
-	0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x8b, 0x47, 0x72, 0x0c, 0x1f,
-	0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x10, 0xa5, 0x9c, 0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4,
-	0x97, 0x9c, 0x9f, 0xab, 0x9f, 0x9b, 0x99, 0x57, 0x52, 0x95, 0x91, 0x98, 0xaf, 0x5f, 0x92, 0x5f,
-	0x90, 0x98, 0x9c, 0x91, 0x98, 0x99, 0xa7, 0x0f, 0x76, 0x7d, 0x12, 0x1b, 0xd8, 0x2f, 0xc6, 0x80,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x6e, 0x35, 0x47, 0x8d, 0xdb, 0x00, 0x00, 0x00,
+	0xda, 0xcd, 0xc8, 0xc5, 0xe9, 0x58, 0x50, 0xe0, 0x0c, 0x96, 0x12, 0x52, 0xe1, 0xe2, 0x4d, 0xca,
+	0xc9, 0x4f, 0xce, 0xf6, 0xcc, 0x2b, 0x49, 0x2d, 0x2a, 0x4b, 0xcc, 0x91, 0x60, 0x54, 0x60, 0xd4,
+	0x60, 0x0e, 0x42, 0x15, 0x14, 0x52, 0xe2, 0xe2, 0x01, 0x0b, 0x84, 0x54, 0x38, 0xe7, 0x97, 0xe6,
+	0x95, 0x48, 0x30, 0x81, 0x15, 0xa1, 0x88, 0x09, 0x09, 0x71, 0xb1, 0x64, 0x24, 0x16, 0x67, 0x48,
+	0x30, 0x2b, 0x30, 0x6a, 0x70, 0x06, 0x81, 0xd9, 0x20, 0xd3, 0x73, 0x13, 0x8b, 0x4b, 0x52, 0x8b,
+	0x1c, 0x53, 0x52, 0x8a, 0x52, 0x8b, 0x8b, 0x25, 0x58, 0xc0, 0x92, 0xa8, 0x82, 0x42, 0x5a, 0x5c,
+	0x02, 0xc5, 0x79, 0x89, 0x05, 0xc5, 0x19, 0xf9, 0x25, 0x70, 0x67, 0xb0, 0x2a, 0x30, 0x6a, 0xb0,
+	0x04, 0x61, 0x88, 0x3b, 0x05, 0x5e, 0x78, 0x28, 0xc7, 0x70, 0xe3, 0xa1, 0x1c, 0xc3, 0x87, 0x87,
+	0x72, 0x8c, 0x0d, 0x8f, 0xe4, 0x18, 0x57, 0x3c, 0x92, 0x63, 0x3c, 0xf1, 0x48, 0x8e, 0xf1, 0xc2,
+	0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x5f, 0x3c, 0x92, 0x63, 0xf8, 0xf0, 0x48, 0x8e, 0x71,
+	0xc2, 0x63, 0x39, 0x86, 0x28, 0xe5, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24, 0xbd, 0xe4, 0xfc, 0x5c,
+	0xfd, 0xdc, 0xcc, 0xbc, 0x92, 0xaa, 0x8c, 0xc4, 0x7c, 0xfd, 0x92, 0xfc, 0x82, 0xc4, 0xe4, 0x8c,
+	0xc4, 0xcc, 0x3c, 0x7d, 0x70, 0x80, 0x24, 0xb1, 0x81, 0x83, 0xc7, 0x18, 0x30, 0x00, 0x38, 0x7d,
+	0x18, 0x0c, 0x2e, 0x01, 0x00, 0x00,
 }
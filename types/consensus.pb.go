@@ -74,7 +74,6 @@ func (m *ConsensusBlockConfig) GetMaxBlockSize() uint64 {
 	return 0
 }
 
-//
 type TxResponseSync struct {
 	Id     string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Status TxStatus `protobuf:"varint,2,opt,name=status,proto3,enum=types.TxStatus" json:"status,omitempty"`
@@ -282,24 +281,6 @@ func (m *TxResponseSync) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeFixed64Consensus(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Consensus(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
 func encodeVarintConsensus(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -728,7 +709,7 @@ var (
 func init() { proto.RegisterFile("consensus.proto", fileDescriptorConsensus) }
 
 var fileDescriptorConsensus = []byte{
-	// 299 bytes of a gzipped FileDescriptorProto
+	// 296 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xbf, 0x4a, 0x33, 0x41,
 	0x14, 0xc5, 0x77, 0xf2, 0x25, 0xf9, 0xcc, 0x45, 0x12, 0x19, 0x14, 0xb6, 0x90, 0x4b, 0x88, 0x85,
 	0xc1, 0x62, 0x03, 0xfa, 0x06, 0x49, 0x25, 0x16, 0xe2, 0x6e, 0x0a, 0xb1, 0x91, 0xc9, 0x66, 0xcd,
@@ -746,6 +727,6 @@ var fileDescriptorConsensus = []byte{
 	0x62, 0x59, 0xa1, 0xf7, 0x5a, 0xa1, 0xb7, 0xaa, 0x90, 0x3d, 0x58, 0x64, 0xcf, 0x16, 0xd9, 0x8b,
 	0x45, 0xb6, 0xb4, 0xc8, 0xde, 0x2c, 0xb2, 0x0f, 0x8b, 0xde, 0xca, 0x22, 0x7b, 0x7c, 0x47, 0xef,
 	0xea, 0x60, 0x26, 0x29, 0x2d, 0x26, 0x41, 0xac, 0xb3, 0x41, 0x26, 0x15, 0x2d, 0x52, 0xa1, 0x07,
-	0xa4, 0x73, 0x11, 0xa7, 0x42, 0xaa, 0x81, 0xab, 0x9e, 0x34, 0xdd, 0x5f, 0x4f, 0x3e, 0x03, 0x00,
-	0x00, 0xff, 0xff, 0xf2, 0xe1, 0x0d, 0xaa, 0x6a, 0x01, 0x00, 0x00,
+	0xa4, 0x73, 0x11, 0xa7, 0x42, 0xaa, 0x81, 0xab, 0x9e, 0x34, 0xdd, 0x5f, 0x4f, 0x3e, 0x07, 0x00,
+	0xf2, 0xe1, 0x0d, 0xaa, 0x6a, 0x01, 0x00, 0x00,
 }
@@ -0,0 +1,58 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package types
+
+import (
+	"sort"
+)
+
+// NewEvent returns an event of type typ with attributes in key order
+func NewEvent(typ string, attributes map[string]string) *Event {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	event := &Event{Type: typ}
+	for _, key := range keys {
+		event.Attributes = append(event.Attributes, &EventAttribute{Key: key, Value: attributes[key]})
+	}
+
+	return event
+}
+
+// Match returns whether event matches f
+func (f *EventFilter) Match(event *TxEvent) bool {
+	if f.GetApplication() != "" && f.GetApplication() != event.GetApplication() {
+		return false
+	}
+	if f.GetType() != "" && f.GetType() != event.GetEvent().GetType() {
+		return false
+	}
+
+	for _, want := range f.GetAttributes() {
+		matched := false
+		for _, attr := range event.GetEvent().GetAttributes() {
+			if attr.GetKey() == want.GetKey() && (want.GetValue() == "" || attr.GetValue() == want.GetValue()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,216 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package types
+
+import (
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// localClient is an ApplicationClient calling an ApplicationServer in-process.
+// AppMessages are passed through channels as is, never marshalled, but their payloads are encoded bytes:
+// AppMessage is what the dispatchers of both ends build and read whatever the transport, so they stay transport agnostic.
+type localClient struct {
+	srv ApplicationServer
+}
+
+// NewLocalApplicationClient returns an ApplicationClient calling srv in-process
+func NewLocalApplicationClient(srv ApplicationServer) ApplicationClient {
+	return &localClient{srv: srv}
+}
+
+func (c *localClient) Register(ctx context.Context, in *AppMetadata, opts ...grpc.CallOption) (*Empty, error) {
+	return c.srv.Register(ctx, in)
+}
+
+// AppStream serves a new stream by srv in background, until ctx done or srv returns
+func (c *localClient) AppStream(ctx context.Context, opts ...grpc.CallOption) (Application_AppStreamClient, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	p := &localPipe{
+		ctx:        ctx,
+		toServer:   make(chan *AppMessage),
+		toClient:   make(chan *AppMessage),
+		sendClosed: make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	go func() {
+		err := c.srv.AppStream(&localServerStream{p})
+		if err == nil {
+			err = io.EOF
+		}
+
+		p.err = err
+		close(p.done)
+		cancel()
+	}()
+
+	return &localClientStream{p}, nil
+}
+
+// localPipe connects the two ends of a local stream
+type localPipe struct {
+	ctx      context.Context
+	toServer chan *AppMessage
+	toClient chan *AppMessage
+
+	closeOnce  sync.Once
+	sendClosed chan struct{}
+
+	// done is closed with err set once server returned
+	done chan struct{}
+	err  error
+}
+
+// closedErr returns the error closed the pipe, server's if it returned, otherwise ctx's
+func (p *localPipe) closedErr() error {
+	select {
+	case <-p.done:
+		return p.err
+	default:
+		return p.ctx.Err()
+	}
+}
+
+// localClientStream is the client end of a local stream
+type localClientStream struct {
+	p *localPipe
+}
+
+func (s *localClientStream) Send(msg *AppMessage) error {
+	select {
+	case s.p.toServer <- msg:
+		return nil
+	case <-s.p.done:
+		return io.EOF
+	case <-s.p.ctx.Done():
+		return s.p.closedErr()
+	}
+}
+
+func (s *localClientStream) Recv() (*AppMessage, error) {
+	select {
+	case msg := <-s.p.toClient:
+		return msg, nil
+	case <-s.p.done:
+		return nil, s.p.err
+	case <-s.p.ctx.Done():
+		return nil, s.p.closedErr()
+	}
+}
+
+func (s *localClientStream) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *localClientStream) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+func (s *localClientStream) CloseSend() error {
+	s.p.closeOnce.Do(func() {
+		close(s.p.sendClosed)
+	})
+
+	return nil
+}
+
+func (s *localClientStream) Context() context.Context {
+	return s.p.ctx
+}
+
+func (s *localClientStream) SendMsg(m interface{}) error {
+	return sendMsg(m, s.Send)
+}
+
+func (s *localClientStream) RecvMsg(m interface{}) error {
+	return recvMsg(m, s.Recv)
+}
+
+// localServerStream is the server end of a local stream
+type localServerStream struct {
+	p *localPipe
+}
+
+func (s *localServerStream) Send(msg *AppMessage) error {
+	select {
+	case s.p.toClient <- msg:
+		return nil
+	case <-s.p.ctx.Done():
+		return s.p.ctx.Err()
+	}
+}
+
+func (s *localServerStream) Recv() (*AppMessage, error) {
+	select {
+	case msg := <-s.p.toServer:
+		return msg, nil
+	case <-s.p.sendClosed:
+		return nil, io.EOF
+	case <-s.p.ctx.Done():
+		return nil, s.p.ctx.Err()
+	}
+}
+
+func (s *localServerStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localServerStream) SetTrailer(metadata.MD) {}
+
+func (s *localServerStream) Context() context.Context {
+	return s.p.ctx
+}
+
+func (s *localServerStream) SendMsg(m interface{}) error {
+	return sendMsg(m, s.Send)
+}
+
+func (s *localServerStream) RecvMsg(m interface{}) error {
+	return recvMsg(m, s.Recv)
+}
+
+// sendMsg sends m, which must be *AppMessage, by send
+func sendMsg(m interface{}, send func(*AppMessage) error) error {
+	msg, ok := m.(*AppMessage)
+	if !ok {
+		return errors.Errorf("unexpected message type %T", m)
+	}
+
+	return send(msg)
+}
+
+// recvMsg receives a message by recv into m, which must be *AppMessage
+func recvMsg(m interface{}, recv func() (*AppMessage, error)) error {
+	dst, ok := m.(*AppMessage)
+	if !ok {
+		return errors.Errorf("unexpected message type %T", m)
+	}
+
+	msg, err := recv()
+	if err != nil {
+		return err
+	}
+
+	*dst = *msg
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package types
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProtocolVersion is the version of AppMessage protocol, increased on incompatible changes
+	ProtocolVersion uint32 = 1
+)
+
+// NewAppMessage constructs an AppMessage of typ carrying payload, which should be the payload message of typ
+func NewAppMessage(meta *AppMetadata, typ AppMessageType, requestID uint64, payload proto.Message) (*AppMessage, error) {
+	var payloadBytes []byte
+	if payload != nil {
+		var err error
+		if payloadBytes, err = proto.Marshal(payload); err != nil {
+			return nil, errors.Wrapf(err, "marshal %s payload error", typ)
+		}
+	}
+
+	return &AppMessage{
+		Header: &AppMessageHeader{
+			Meta:            meta,
+			Timestamp:       time.Now().UnixNano(),
+			Type:            typ,
+			ProtocolVersion: ProtocolVersion,
+			RequestId:       requestID,
+		},
+		Payload: payloadBytes,
+	}, nil
+}
+
+// DecodePayload decodes payload of m into payload
+func (m *AppMessage) DecodePayload(payload proto.Message) error {
+	if err := proto.Unmarshal(m.GetPayload(), payload); err != nil {
+		return errors.Wrapf(err, "unmarshal %s payload error", m.GetHeader().GetType())
+	}
+
+	return nil
+}
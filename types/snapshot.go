@@ -0,0 +1,71 @@
+// Copyright © 2018 Zhao Ming <mint.zhao.chiu@gmail.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package types
+
+import (
+	"bytes"
+
+	"github.com/mintzhao/topachain/common/crypto"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrChunkMismatch means snapshot chunk doesn't match its hash
+	ErrChunkMismatch = errors.New("snapshot chunk mismatch")
+)
+
+// NewSnapshot describes the state of appHash committed at height split in chunks,
+// chunks are hashed by hash algorithm hash, the default one if empty
+func NewSnapshot(height uint64, appHash []byte, chunks [][]byte, hash string) (*Snapshot, error) {
+	chunkHashes := make([][]byte, len(chunks))
+	for i, chunk := range chunks {
+		chunkHash, err := hashBytes(chunk, hash)
+		if err != nil {
+			return nil, err
+		}
+		chunkHashes[i] = chunkHash
+	}
+
+	return &Snapshot{
+		Height:      height,
+		AppHash:     appHash,
+		ChunkHashes: chunkHashes,
+	}, nil
+}
+
+// VerifyChunk checks chunk is the index one of s, hashed by hash algorithm hash, the default one if empty
+func (s *Snapshot) VerifyChunk(index uint32, chunk []byte, hash string) error {
+	if int(index) >= len(s.GetChunkHashes()) {
+		return errors.Wrapf(ErrChunkMismatch, "chunk %d out of %d", index, len(s.GetChunkHashes()))
+	}
+
+	chunkHash, err := hashBytes(chunk, hash)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(chunkHash, s.ChunkHashes[index]) {
+		return errors.Wrapf(ErrChunkMismatch, "chunk %d of snapshot at %d", index, s.GetHeight())
+	}
+
+	return nil
+}
+
+// hashBytes hashes msg by hash algorithm hash, the default one if empty
+func hashBytes(msg []byte, hash string) ([]byte, error) {
+	if hash == "" {
+		return crypto.Hash(msg)
+	}
+
+	return crypto.Hash(msg, hash)
+}